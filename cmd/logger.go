@@ -20,9 +20,17 @@ func (h TracingHook) Run(e *zerolog.Event, level zerolog.Level, msg string) {
 	if ip != "" {
 		e.Str("ip_address", ip)
 	}
-	sessionToken := middleware.GetSessionTokenFromContext(ctx)
-	if sessionToken != "" {
-		e.Str("session_token", sessionToken)
+	userContext := middleware.GetUserFromContext(ctx)
+	if userContext != nil {
+		e.Str("user_id", userContext.UserID.String())
+		e.Str("session_id", userContext.SessionID.String())
+		if userContext.IsImpersonated() {
+			e.Str("impersonator_id", userContext.ImpersonatorID().String())
+		}
+	}
+	apiKey := middleware.GetAPIKeyFromContext(ctx)
+	if apiKey != nil {
+		e.Str("api_key_id", apiKey.ID.String())
 	}
 }
 
@@ -7,14 +7,16 @@ import (
 
 	"github.com/mcorrigan89/openmic/internal/application"
 	"github.com/mcorrigan89/openmic/internal/common"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/mcorrigan89/openmic/internal/domain/external"
 	"github.com/mcorrigan89/openmic/internal/domain/services"
 	"github.com/mcorrigan89/openmic/internal/infrastructure/bus"
 	"github.com/mcorrigan89/openmic/internal/infrastructure/email"
 	"github.com/mcorrigan89/openmic/internal/infrastructure/media"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/oidc"
 	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres"
 	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/repositories"
 	"github.com/mcorrigan89/openmic/internal/infrastructure/storage"
-	"github.com/mcorrigan89/openmic/internal/interfaces/http/dto"
 	"github.com/mcorrigan89/openmic/internal/interfaces/http/handlers"
 	"github.com/mcorrigan89/openmic/internal/interfaces/http/middleware"
 	"github.com/mcorrigan89/openmic/internal/interfaces/http/router"
@@ -22,10 +24,15 @@ import (
 	"github.com/rs/zerolog"
 )
 
+// eventChangeReplaySize is how many changes per event a reconnecting SSE
+// client can catch up on before it is sent a fresh snapshot instead.
+const eventChangeReplaySize = 64
+
 type appServer struct {
-	config *common.Config
-	wg     *sync.WaitGroup
-	logger *zerolog.Logger
+	config        *common.Config
+	wg            *sync.WaitGroup
+	logger        *zerolog.Logger
+	shutdownHooks []func()
 }
 
 func main() {
@@ -46,14 +53,14 @@ func main() {
 	wg := sync.WaitGroup{}
 	mux := http.NewServeMux()
 
-	messageBus := bus.NewMessageBus[*dto.EventDto]()
-	defer messageBus.Close()
-
 	postgresUserRepository := repositories.NewPostgresUserRepository()
 	postgresArtistRepositoy := repositories.NewPostgresArtistRepository()
 	postgresEventRepositoy := repositories.NewPostgresEventRepository(&logger)
 	postgresReferenceLinkRepository := repositories.NewPostgresReferenceLinkRepository()
 	postgresImageRepository := repositories.NewPostgresImageRepository()
+	postgresOrganizationRepository := repositories.NewPostgresOrganizationRepository()
+	postgresIdentityRepository := repositories.NewPostgresIdentityRepository()
+	postgresAPIKeyRepository := repositories.NewPostgresAPIKeyRepository()
 	blobStorageService := storage.NewBlobStorageService(&cfg)
 	smtpService := email.NewSmtpService(&cfg)
 	imageMediaService := media.NewImageMediaService(blobStorageService)
@@ -64,25 +71,63 @@ func main() {
 	emailService := services.NewEmailService(smtpService)
 	emailTemplateService := services.NewEmailTemplateService(&cfg)
 	imageService := services.NewImageService(postgresImageRepository)
+	organizationService := services.NewOrganizationService(&logger, postgresOrganizationRepository)
+	apiKeyService := services.NewAPIKeyService(postgresAPIKeyRepository, cfg.ServerToken)
+
+	identityProviders := make([]external.IdentityProvider, 0, len(cfg.OIDC.Providers))
+	for _, providerConfig := range cfg.OIDC.Providers {
+		identityProviders = append(identityProviders, oidc.NewProvider(oidc.Config{
+			Name:         providerConfig.Name,
+			Issuer:       providerConfig.Issuer,
+			ClientID:     providerConfig.ClientID,
+			ClientSecret: providerConfig.ClientSecret,
+			RedirectURL:  cfg.OIDC.RedirectURL,
+		}))
+	}
+	identityService := services.NewIdentityService(userService, postgresIdentityRepository, identityProviders...)
+
+	localMessageBus := bus.NewReplayMessageBus(eventChangeReplaySize, func(change *entities.EventChangeEntity) int64 {
+		return change.Seq
+	})
+
+	var messageBus bus.Bus[*entities.EventChangeEntity]
+	switch cfg.MessageBus {
+	case "postgres":
+		messageBus, err = bus.NewPostgresBus(localMessageBus, db, &logger, "event_change", application.NewEventChangeCodec(db, eventService))
+		if err != nil {
+			logger.Err(err).Msg("Failed to start message bus")
+			os.Exit(1)
+		}
+	case "memory":
+		messageBus = localMessageBus
+	}
+	defer messageBus.Close()
 
 	userApplicationService := application.NewUserApplicationService(db, &wg, &cfg, &logger, userService, emailService, emailTemplateService)
 	imageApplicationService := application.NewImageApplicationService(db, &wg, &cfg, &logger, imageService, userService, imageMediaService)
 	artistApplicationService := application.NewArtistApplicationService(db, &wg, &cfg, &logger, artistService)
-	eventApplicationService := application.NewEventApplicationService(db, &wg, &cfg, &logger, messageBus, eventService)
+	eventApplicationService := application.NewEventApplicationService(db, &wg, &cfg, &logger, messageBus, eventService, artistService, organizationService)
+	organizationApplicationService := application.NewOrganizationApplicationService(db, &wg, &cfg, &logger, organizationService)
+	identityApplicationService := application.NewIdentityApplicationService(db, &wg, &cfg, &logger, identityService)
+	apiKeyApplicationService := application.NewAPIKeyApplicationService(db, &wg, &cfg, &logger, apiKeyService)
 	userHandler := handlers.NewUserHandler(&logger, userApplicationService)
 	imageHandler := handlers.NewImageHandler(&logger, imageApplicationService)
 	artistHandler := handlers.NewArtistHandler(&logger, artistApplicationService)
 	eventHandler := handlers.NewEventHandler(&logger, eventApplicationService)
+	organizationHandler := handlers.NewOrganizationHandler(&logger, organizationApplicationService)
+	identityHandler := handlers.NewIdentityHandler(&logger, identityApplicationService)
+	apiKeyHandler := handlers.NewAPIKeyHandler(&logger, apiKeyApplicationService)
 
-	mdlwr := middleware.CreateMiddleware(&cfg, db, &logger, userService)
+	mdlwr := middleware.CreateMiddleware(&cfg, db, &logger, userService, organizationService, apiKeyService)
 
 	// HTTP Routes
-	httpRoutes := router.NewRouter(mux, mdlwr, userHandler, imageHandler, eventHandler, artistHandler)
+	httpRoutes := router.NewRouter(mux, mdlwr, userHandler, imageHandler, eventHandler, artistHandler, organizationHandler, identityHandler, apiKeyHandler)
 
 	server := &appServer{
-		wg:     &wg,
-		config: &cfg,
-		logger: &logger,
+		wg:            &wg,
+		config:        &cfg,
+		logger:        &logger,
+		shutdownHooks: []func(){messageBus.Close},
 	}
 
 	err = server.serve(httpRoutes)
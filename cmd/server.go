@@ -32,6 +32,12 @@ func (server *appServer) serve(routes http.Handler) error {
 		TLSConfig: tlsConfig,
 	}
 
+	// Long-lived connections such as SSE streams are not closed by Shutdown,
+	// so give them a chance to end on their own.
+	for _, hook := range server.shutdownHooks {
+		srv.RegisterOnShutdown(hook)
+	}
+
 	shutdownError := make(chan error)
 
 	go func() {
@@ -0,0 +1,92 @@
+package application
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mcorrigan89/openmic/internal/application/commands"
+	"github.com/mcorrigan89/openmic/internal/application/queries"
+	"github.com/mcorrigan89/openmic/internal/common"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/mcorrigan89/openmic/internal/domain/services"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+
+	"github.com/rs/zerolog"
+)
+
+type APIKeyApplicationService interface {
+	GetAPIKeys(ctx context.Context, query queries.APIKeysQuery) ([]*entities.APIKeyEntity, error)
+	CreateAPIKey(ctx context.Context, cmd commands.CreateAPIKeyCommand) (*entities.APIKeyEntity, error)
+	RotateAPIKey(ctx context.Context, cmd commands.RotateAPIKeyCommand) (*entities.APIKeyEntity, error)
+	RevokeAPIKey(ctx context.Context, cmd commands.RevokeAPIKeyCommand) (*entities.APIKeyEntity, error)
+}
+
+type apiKeyApplicationService struct {
+	config        *common.Config
+	wg            *sync.WaitGroup
+	logger        *zerolog.Logger
+	db            *pgxpool.Pool
+	queries       models.Querier
+	apiKeyService services.APIKeyService
+}
+
+func NewAPIKeyApplicationService(db *pgxpool.Pool, wg *sync.WaitGroup, cfg *common.Config, logger *zerolog.Logger, apiKeyService services.APIKeyService) *apiKeyApplicationService {
+	dbQueries := models.New(db)
+	return &apiKeyApplicationService{
+		db:            db,
+		config:        cfg,
+		wg:            wg,
+		logger:        logger,
+		queries:       dbQueries,
+		apiKeyService: apiKeyService,
+	}
+}
+
+func (app *apiKeyApplicationService) GetAPIKeys(ctx context.Context, query queries.APIKeysQuery) ([]*entities.APIKeyEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Getting API keys")
+
+	apiKeys, err := app.apiKeyService.GetAPIKeys(ctx, app.queries, query.Issuer)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to get API keys")
+		return nil, err
+	}
+
+	return apiKeys, nil
+}
+
+func (app *apiKeyApplicationService) CreateAPIKey(ctx context.Context, cmd commands.CreateAPIKeyCommand) (*entities.APIKeyEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Creating API key")
+
+	apiKey, err := app.apiKeyService.CreateAPIKey(ctx, app.queries, cmd.Issuer, cmd.ToDomain())
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to create API key")
+		return nil, err
+	}
+
+	return apiKey, nil
+}
+
+func (app *apiKeyApplicationService) RotateAPIKey(ctx context.Context, cmd commands.RotateAPIKeyCommand) (*entities.APIKeyEntity, error) {
+	app.logger.Info().Ctx(ctx).Str("api_key_id", cmd.ID.String()).Msg("Rotating API key")
+
+	apiKey, err := app.apiKeyService.RotateAPIKey(ctx, app.queries, cmd.Issuer, cmd.ID)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to rotate API key")
+		return nil, err
+	}
+
+	return apiKey, nil
+}
+
+func (app *apiKeyApplicationService) RevokeAPIKey(ctx context.Context, cmd commands.RevokeAPIKeyCommand) (*entities.APIKeyEntity, error) {
+	app.logger.Info().Ctx(ctx).Str("api_key_id", cmd.ID.String()).Msg("Revoking API key")
+
+	apiKey, err := app.apiKeyService.RevokeAPIKey(ctx, app.queries, cmd.Issuer, cmd.ID)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to revoke API key")
+		return nil, err
+	}
+
+	return apiKey, nil
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/mcorrigan89/openmic/internal/common"
 	"github.com/mcorrigan89/openmic/internal/domain/entities"
 	"github.com/mcorrigan89/openmic/internal/domain/services"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres"
 	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
 
 	"github.com/rs/zerolog"
@@ -18,7 +19,7 @@ import (
 type ArtistApplicationService interface {
 	GetArtistByID(ctx context.Context, query queries.ArtistByIDQuery) (*entities.ArtistEntity, error)
 	GetArtistsByTitle(ctx context.Context, query queries.ArtistsByTitleQuery) ([]*entities.ArtistEntity, error)
-	GetAllArtists(ctx context.Context) ([]*entities.ArtistEntity, error)
+	GetAllArtists(ctx context.Context, query queries.ArtistsQuery) ([]*entities.ArtistEntity, error)
 	CreateArtist(ctx context.Context, cmd commands.CreateNewArtistCommand) (*entities.ArtistEntity, error)
 	UpdateArtist(ctx context.Context, cmd commands.UpdateArtistCommand) (*entities.ArtistEntity, error)
 	DeleteArtist(ctx context.Context, cmd commands.DeleteArtistCommand) error
@@ -60,7 +61,7 @@ func (app *artistApplicationService) GetArtistByID(ctx context.Context, query qu
 func (app *artistApplicationService) GetArtistsByTitle(ctx context.Context, query queries.ArtistsByTitleQuery) ([]*entities.ArtistEntity, error) {
 	app.logger.Info().Ctx(ctx).Msg("Getting artist by ID")
 
-	artists, err := app.artistService.GetArtistsByTitle(ctx, app.queries, query.Title)
+	artists, err := app.artistService.GetArtistsByTitle(ctx, app.queries, query.OrganizationID, query.Title)
 	if err != nil {
 		app.logger.Err(err).Ctx(ctx).Msg("Failed to get artist by ID")
 		return nil, err
@@ -69,10 +70,10 @@ func (app *artistApplicationService) GetArtistsByTitle(ctx context.Context, quer
 	return artists, nil
 }
 
-func (app *artistApplicationService) GetAllArtists(ctx context.Context) ([]*entities.ArtistEntity, error) {
+func (app *artistApplicationService) GetAllArtists(ctx context.Context, query queries.ArtistsQuery) ([]*entities.ArtistEntity, error) {
 	app.logger.Info().Ctx(ctx).Msg("Getting all artists")
 
-	artists, err := app.artistService.GetAllArtists(ctx, app.queries)
+	artists, err := app.artistService.GetAllArtists(ctx, app.queries, query.OrganizationID)
 	if err != nil {
 		app.logger.Err(err).Ctx(ctx).Msg("Failed to get all artists")
 		return nil, err
@@ -98,25 +99,80 @@ func (app *artistApplicationService) CreateArtist(ctx context.Context, cmd comma
 func (app *artistApplicationService) UpdateArtist(ctx context.Context, cmd commands.UpdateArtistCommand) (*entities.ArtistEntity, error) {
 	app.logger.Info().Ctx(ctx).Msg("Updating artist")
 
+	tx, cancel, err := postgres.CreateTransaction(ctx, app.db)
+	defer cancel()
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to create transaction")
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := models.New(app.db).WithTx(tx)
+
+	currentArtist, err := app.artistService.GetArtistByID(ctx, qtx, cmd.ID)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to get artist by ID")
+		return nil, err
+	}
+
+	err = entities.CheckVersion(currentArtist.Version, cmd.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
 	artistEntity := cmd.ToDomain()
+	artistEntity.Version = currentArtist.Version
 
-	artist, err := app.artistService.UpdateArtist(ctx, app.queries, artistEntity)
+	artist, err := app.artistService.UpdateArtist(ctx, qtx, artistEntity)
 	if err != nil {
 		app.logger.Err(err).Ctx(ctx).Msg("Failed to update artist")
 		return nil, err
 	}
 
+	err = tx.Commit(ctx)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to commit transaction")
+		return nil, err
+	}
+
 	return artist, nil
 }
 
 func (app *artistApplicationService) DeleteArtist(ctx context.Context, query commands.DeleteArtistCommand) error {
 	app.logger.Info().Ctx(ctx).Msg("Deleting artist")
 
-	err := app.artistService.DeleteArtist(ctx, app.queries, query.ID)
+	tx, cancel, err := postgres.CreateTransaction(ctx, app.db)
+	defer cancel()
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to create transaction")
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := models.New(app.db).WithTx(tx)
+
+	artist, err := app.artistService.GetArtistByID(ctx, qtx, query.ID)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to get artist by ID")
+		return err
+	}
+
+	err = entities.CheckVersion(artist.Version, query.ExpectedVersion)
+	if err != nil {
+		return err
+	}
+
+	err = app.artistService.DeleteArtist(ctx, qtx, artist)
 	if err != nil {
 		app.logger.Err(err).Ctx(ctx).Msg("Failed to delete artist")
 		return err
 	}
 
+	err = tx.Commit(ctx)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to commit transaction")
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,36 @@
+package commands
+
+import (
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+)
+
+type CreateAPIKeyCommand struct {
+	Name               string
+	Scopes             []entities.APIKeyScope
+	OrganizationID     *uuid.UUID
+	RateLimitPerMinute int
+	CreatedBy          *uuid.UUID
+	Issuer             *entities.APIKeyEntity
+}
+
+func (cmd *CreateAPIKeyCommand) ToDomain() *entities.APIKeyEntity {
+	return &entities.APIKeyEntity{
+		ID:                 uuid.New(),
+		Name:               cmd.Name,
+		Scopes:             cmd.Scopes,
+		OrganizationID:     cmd.OrganizationID,
+		RateLimitPerMinute: cmd.RateLimitPerMinute,
+		CreatedBy:          cmd.CreatedBy,
+	}
+}
+
+type RotateAPIKeyCommand struct {
+	ID     uuid.UUID
+	Issuer *entities.APIKeyEntity
+}
+
+type RevokeAPIKeyCommand struct {
+	ID     uuid.UUID
+	Issuer *entities.APIKeyEntity
+}
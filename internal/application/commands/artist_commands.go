@@ -6,25 +6,30 @@ import (
 )
 
 type CreateNewArtistCommand struct {
-	Title    string
-	SubTitle *string
-	Bio      *string
+	OrganizationID uuid.UUID
+	Title          string
+	SubTitle       *string
+	Bio            *string
+	UserID         *uuid.UUID
 }
 
 func (c *CreateNewArtistCommand) ToDomain() *entities.ArtistEntity {
 	return &entities.ArtistEntity{
-		ID:       uuid.New(),
-		Title:    c.Title,
-		SubTitle: c.SubTitle,
-		Bio:      c.Bio,
+		ID:             uuid.New(),
+		OrganizationID: c.OrganizationID,
+		Title:          c.Title,
+		SubTitle:       c.SubTitle,
+		Bio:            c.Bio,
+		UserID:         c.UserID,
 	}
 }
 
 type UpdateArtistCommand struct {
-	ID       uuid.UUID
-	Title    string
-	SubTitle *string
-	Bio      *string
+	ID              uuid.UUID
+	Title           string
+	SubTitle        *string
+	Bio             *string
+	ExpectedVersion *int32
 }
 
 func (c *UpdateArtistCommand) ToDomain() *entities.ArtistEntity {
@@ -37,11 +42,33 @@ func (c *UpdateArtistCommand) ToDomain() *entities.ArtistEntity {
 }
 
 type DeleteArtistCommand struct {
-	ID uuid.UUID
+	ID              uuid.UUID
+	ExpectedVersion *int32
 }
 
+// UpdateTimeSlotCommand keeps the timeslot's duration override when
+// DurationOverrideMinutes is nil. Zero clears it.
 type UpdateTimeSlotCommand struct {
-	EventID    uuid.UUID
-	TimeSlotID uuid.UUID
-	SongCount  int32
+	EventID                 uuid.UUID
+	TimeSlotID              uuid.UUID
+	SongCount               int32
+	DurationOverrideMinutes *int32
+	ExpectedVersion         *int32
+}
+
+// PatchTimeSlotCommand changes only the fields it carries. An empty
+// NameOverride or Notes clears it.
+type PatchTimeSlotCommand struct {
+	EventID         uuid.UUID
+	TimeSlotID      uuid.UUID
+	NameOverride    *string
+	SongCount       *int32
+	Notes           *string
+	ExpectedVersion *int32
+}
+
+type DeleteTimeSlotCommand struct {
+	EventID         uuid.UUID
+	TimeSlotID      uuid.UUID
+	ExpectedVersion *int32
 }
@@ -8,38 +8,69 @@ import (
 )
 
 type CreateNewEventCommand struct {
-	StartTime time.Time
-	EndTime   time.Time
-	EventType string
+	VenueID               uuid.UUID
+	StartTime             time.Time
+	EndTime               time.Time
+	EventType             string
+	SlotPolicy            *entities.SlotDurationPolicy
+	AllowDuplicateArtists *bool
 }
 
 func (cmd *CreateNewEventCommand) ToDomain() *entities.EventEntity {
+	slotPolicy := entities.DefaultSlotDurationPolicy()
+	if cmd.SlotPolicy != nil {
+		slotPolicy = *cmd.SlotPolicy
+	}
+
+	allowDuplicateArtists := true
+	if cmd.AllowDuplicateArtists != nil {
+		allowDuplicateArtists = *cmd.AllowDuplicateArtists
+	}
+
 	return &entities.EventEntity{
-		ID:        uuid.New(),
-		StartTime: cmd.StartTime,
-		EndTime:   cmd.EndTime,
-		EventType: cmd.EventType,
+		ID:                    uuid.New(),
+		VenueID:               cmd.VenueID,
+		StartTime:             cmd.StartTime,
+		EndTime:               cmd.EndTime,
+		EventType:             cmd.EventType,
+		SlotPolicy:            slotPolicy,
+		AllowDuplicateArtists: allowDuplicateArtists,
 	}
 }
 
 type UpdateEventCommand struct {
-	ID        uuid.UUID
-	StartTime time.Time
-	EndTime   time.Time
-	EventType string
+	ID                    uuid.UUID
+	StartTime             time.Time
+	EndTime               time.Time
+	EventType             string
+	SlotPolicy            *entities.SlotDurationPolicy
+	AllowDuplicateArtists *bool
+	ExpectedVersion       *int32
 }
 
+// ToDomain leaves SlotPolicy zeroed and AllowDuplicateArtists false when the
+// command does not carry them; the caller is expected to keep the event's
+// existing settings in that case.
 func (cmd *UpdateEventCommand) ToDomain() *entities.EventEntity {
-	return &entities.EventEntity{
+	eventEntity := &entities.EventEntity{
 		ID:        cmd.ID,
 		StartTime: cmd.StartTime,
 		EndTime:   cmd.EndTime,
 		EventType: cmd.EventType,
 	}
+	if cmd.SlotPolicy != nil {
+		eventEntity.SlotPolicy = *cmd.SlotPolicy
+	}
+	if cmd.AllowDuplicateArtists != nil {
+		eventEntity.AllowDuplicateArtists = *cmd.AllowDuplicateArtists
+	}
+
+	return eventEntity
 }
 
 type DeleteEventCommand struct {
-	ID uuid.UUID
+	ID              uuid.UUID
+	ExpectedVersion *int32
 }
 
 type AddArtistToEventCommand struct {
@@ -47,15 +78,40 @@ type AddArtistToEventCommand struct {
 	ArtistID uuid.UUID
 }
 
+type WalkInCommand struct {
+	EventID      uuid.UUID
+	Title        string
+	NameOverride *string
+	SongCount    *int32
+}
+
+type AddLineupItemCommand struct {
+	EventID         uuid.UUID
+	ItemType        entities.LineupItemType
+	Title           string
+	DurationMinutes int32
+	Notes           *string
+}
+
+func (cmd *AddLineupItemCommand) ToDomain() *entities.TimeSlotEntity {
+	return &entities.TimeSlotEntity{
+		ID:                      uuid.New(),
+		ItemType:                cmd.ItemType,
+		NameOverride:            &cmd.Title,
+		DurationOverrideMinutes: &cmd.DurationMinutes,
+		Notes:                   cmd.Notes,
+	}
+}
+
 type RemoveArtistFromEventCommand struct {
 	EventID  uuid.UUID
 	ArtistID uuid.UUID
 }
 
 type SetTimeslotMarkerCommand struct {
-	EventID     uuid.UUID
-	TimeDisplay string
-	SlotIndex   int
+	EventID    uuid.UUID
+	TimeSlotID uuid.UUID
+	Time       time.Time
 }
 
 type DeleteTimeslotMarkerCommand struct {
@@ -70,7 +126,32 @@ type SetSortOrderCommand struct {
 	AfterSlotID   *uuid.UUID
 }
 
+type ReorderLineupCommand struct {
+	EventID     uuid.UUID
+	TimeSlotIDs []uuid.UUID
+}
+
+type RebalanceLineupCommand struct {
+	EventID uuid.UUID
+}
+
 type SetNowPlayingCommand struct {
+	EventID    uuid.UUID
+	TimeSlotID uuid.UUID
+}
+
+type StartShowCommand struct {
+	EventID uuid.UUID
+}
+
+type NextTimeSlotCommand struct {
+	EventID uuid.UUID
+}
+
+type PreviousTimeSlotCommand struct {
+	EventID uuid.UUID
+}
+
+type EndShowCommand struct {
 	EventID uuid.UUID
-	Index   int
 }
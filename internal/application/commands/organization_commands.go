@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+)
+
+type CreateOrganizationCommand struct {
+	Name   string
+	Handle string
+	// OwnerID is added as the first member of the organization.
+	OwnerID uuid.UUID
+}
+
+func (cmd *CreateOrganizationCommand) ToDomain() *entities.OrganizationEntity {
+	return &entities.OrganizationEntity{
+		ID:     uuid.New(),
+		Name:   cmd.Name,
+		Handle: cmd.Handle,
+	}
+}
+
+type UpdateOrganizationCommand struct {
+	ID     uuid.UUID
+	Name   string
+	Handle string
+}
+
+func (cmd *UpdateOrganizationCommand) ToDomain() *entities.OrganizationEntity {
+	return &entities.OrganizationEntity{
+		ID:     cmd.ID,
+		Name:   cmd.Name,
+		Handle: cmd.Handle,
+	}
+}
+
+type AddOrganizationMemberCommand struct {
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+}
+
+type RemoveOrganizationMemberCommand struct {
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+}
+
+type CreateVenueCommand struct {
+	OrganizationID uuid.UUID
+	Name           string
+	Address        *string
+	CurrentWindow  *entities.CurrentEventWindow
+}
+
+func (cmd *CreateVenueCommand) ToDomain() *entities.VenueEntity {
+	return &entities.VenueEntity{
+		ID:             uuid.New(),
+		OrganizationID: cmd.OrganizationID,
+		Name:           cmd.Name,
+		Address:        cmd.Address,
+		CurrentWindow:  cmd.CurrentWindow,
+	}
+}
+
+type UpdateVenueCommand struct {
+	ID            uuid.UUID
+	Name          string
+	Address       *string
+	CurrentWindow *entities.CurrentEventWindow
+}
+
+func (cmd *UpdateVenueCommand) ToDomain() *entities.VenueEntity {
+	return &entities.VenueEntity{
+		ID:            cmd.ID,
+		Name:          cmd.Name,
+		Address:       cmd.Address,
+		CurrentWindow: cmd.CurrentWindow,
+	}
+}
+
+type DeleteVenueCommand struct {
+	ID uuid.UUID
+}
+
+type SetEventTypeWindowCommand struct {
+	OrganizationID uuid.UUID
+	EventType      string
+	Window         entities.CurrentEventWindow
+}
+
+func (cmd *SetEventTypeWindowCommand) ToDomain() *entities.EventTypeWindowEntity {
+	return &entities.EventTypeWindowEntity{
+		OrganizationID: cmd.OrganizationID,
+		EventType:      cmd.EventType,
+		Window:         cmd.Window,
+	}
+}
+
+type DeleteEventTypeWindowCommand struct {
+	OrganizationID uuid.UUID
+	EventType      string
+}
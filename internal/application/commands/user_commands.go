@@ -24,11 +24,12 @@ func (cmd *CreateNewUserCommand) ToDomain() *entities.UserEntity {
 }
 
 type UpdateUserCommand struct {
-	ID         uuid.UUID `json:"id" validate:"required"`
-	Email      string    `json:"email" validate:"required,email"`
-	GivenName  *string   `json:"firstName" validate:"-"`
-	FamilyName *string   `json:"lastName" validate:"-"`
-	Handle     string    `json:"handle" validate:"required"`
+	ID              uuid.UUID `json:"id" validate:"required"`
+	Email           string    `json:"email" validate:"required,email"`
+	GivenName       *string   `json:"firstName" validate:"-"`
+	FamilyName      *string   `json:"lastName" validate:"-"`
+	Handle          string    `json:"handle" validate:"required"`
+	ExpectedVersion *int32    `json:"-" validate:"-"`
 }
 
 func (cmd *UpdateUserCommand) ToDomain() *entities.UserEntity {
@@ -74,3 +75,41 @@ func (cmd *InviteUserCommand) ToDomain() *entities.UserEntity {
 type AcceptInviteReferenceLinkCommand struct {
 	ReferenceLinkToken string `json:"token" validate:"required"`
 }
+
+type SetUserRoleCommand struct {
+	ID   uuid.UUID
+	Role entities.Role
+}
+
+type LogoutCommand struct {
+	UserID    uuid.UUID
+	SessionID uuid.UUID
+}
+
+type LogoutEverywhereCommand struct {
+	UserID uuid.UUID
+}
+
+type RevokeSessionCommand struct {
+	UserID    uuid.UUID
+	SessionID uuid.UUID
+}
+
+type StartImpersonationCommand struct {
+	ImpersonatorID uuid.UUID
+	UserID         uuid.UUID
+}
+
+type EndImpersonationCommand struct {
+	UserContext *entities.UserContextEntity
+}
+
+type StartExternalLoginCommand struct {
+	Provider string
+}
+
+type CompleteExternalLoginCommand struct {
+	State   string
+	Code    string
+	Binding string
+}
@@ -0,0 +1,68 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/mcorrigan89/openmic/internal/domain/services"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+)
+
+type eventChangePayload struct {
+	Kind entities.EventChangeKind `json:"kind"`
+}
+
+// eventChangeCodec sends only the kind of change between instances. The
+// receiving instance loads the event itself, so the event it delivers may
+// already include later changes.
+type eventChangeCodec struct {
+	queries      models.Querier
+	eventService services.EventService
+}
+
+func NewEventChangeCodec(db *pgxpool.Pool, eventService services.EventService) *eventChangeCodec {
+	return &eventChangeCodec{
+		queries:      models.New(db),
+		eventService: eventService,
+	}
+}
+
+func (c *eventChangeCodec) Encode(change *entities.EventChangeEntity) (int64, json.RawMessage, error) {
+	data, err := json.Marshal(eventChangePayload{Kind: change.Kind})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return change.Seq, data, nil
+}
+
+func (c *eventChangeCodec) Decode(ctx context.Context, topic string, seq int64, data json.RawMessage) (*entities.EventChangeEntity, error) {
+	eventID, err := uuid.Parse(topic)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload eventChangePayload
+	err = json.Unmarshal(data, &payload)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.Kind == entities.EventChangeEventDeleted {
+		return &entities.EventChangeEntity{
+			Kind:    payload.Kind,
+			EventID: eventID,
+			Seq:     seq,
+		}, nil
+	}
+
+	event, err := c.eventService.GetEventByID(ctx, c.queries, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.NewEventChangeEntity(payload.Kind, seq, event), nil
+}
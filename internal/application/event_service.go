@@ -2,7 +2,10 @@ package application
 
 import (
 	"context"
+	"errors"
+	"strings"
 	"sync"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -14,7 +17,6 @@ import (
 	"github.com/mcorrigan89/openmic/internal/infrastructure/bus"
 	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres"
 	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
-	"github.com/mcorrigan89/openmic/internal/interfaces/http/dto"
 
 	"github.com/rs/zerolog"
 )
@@ -27,42 +29,64 @@ type EventApplicationService interface {
 	UpdateEvent(ctx context.Context, cmd commands.UpdateEventCommand) (*entities.EventEntity, error)
 	DeleteEvent(ctx context.Context, query commands.DeleteEventCommand) error
 	AddArtistToEvent(ctx context.Context, cmd commands.AddArtistToEventCommand) (*entities.EventEntity, error)
+	WalkIn(ctx context.Context, cmd commands.WalkInCommand) (*entities.WalkInEntity, error)
+	AddLineupItem(ctx context.Context, cmd commands.AddLineupItemCommand) (*entities.EventEntity, error)
 	RemoveArtistFromEvent(ctx context.Context, cmd commands.RemoveArtistFromEventCommand) (*entities.EventEntity, error)
 	SetTimeslotMarker(ctx context.Context, cmd commands.SetTimeslotMarkerCommand) (*entities.EventEntity, error)
 	DeleteTimeslotMarker(ctx context.Context, cmd commands.DeleteTimeslotMarkerCommand) (*entities.EventEntity, error)
 	SetSortOrder(ctx context.Context, cmd commands.SetSortOrderCommand) (*entities.EventEntity, error)
+	ReorderLineup(ctx context.Context, cmd commands.ReorderLineupCommand) (*entities.EventEntity, error)
+	RebalanceLineup(ctx context.Context, cmd commands.RebalanceLineupCommand) (*entities.EventEntity, error)
 	UpdateTimeSlot(ctx context.Context, cmd commands.UpdateTimeSlotCommand) (*entities.EventEntity, error)
+	PatchTimeSlot(ctx context.Context, cmd commands.PatchTimeSlotCommand) (*entities.EventEntity, error)
+	DeleteTimeSlot(ctx context.Context, cmd commands.DeleteTimeSlotCommand) (*entities.EventEntity, error)
 	SetNowPlaying(ctx context.Context, cmd commands.SetNowPlayingCommand) (*entities.EventEntity, error)
-	MessageBus() *bus.MessageBus[*dto.EventDto]
+	StartShow(ctx context.Context, cmd commands.StartShowCommand) (*entities.EventEntity, error)
+	NextTimeSlot(ctx context.Context, cmd commands.NextTimeSlotCommand) (*entities.EventEntity, error)
+	PreviousTimeSlot(ctx context.Context, cmd commands.PreviousTimeSlotCommand) (*entities.EventEntity, error)
+	EndShow(ctx context.Context, cmd commands.EndShowCommand) (*entities.EventEntity, error)
+	MessageBus() bus.Bus[*entities.EventChangeEntity]
 }
 
 type eventApplicationService struct {
-	config       *common.Config
-	wg           *sync.WaitGroup
-	logger       *zerolog.Logger
-	db           *pgxpool.Pool
-	queries      models.Querier
-	bus          *bus.MessageBus[*dto.EventDto]
-	eventService services.EventService
+	config              *common.Config
+	wg                  *sync.WaitGroup
+	logger              *zerolog.Logger
+	db                  *pgxpool.Pool
+	queries             models.Querier
+	bus                 bus.Bus[*entities.EventChangeEntity]
+	eventService        services.EventService
+	artistService       services.ArtistService
+	organizationService services.OrganizationService
 }
 
-func NewEventApplicationService(db *pgxpool.Pool, wg *sync.WaitGroup, cfg *common.Config, logger *zerolog.Logger, bus *bus.MessageBus[*dto.EventDto], eventService services.EventService) *eventApplicationService {
+func NewEventApplicationService(db *pgxpool.Pool, wg *sync.WaitGroup, cfg *common.Config, logger *zerolog.Logger, bus bus.Bus[*entities.EventChangeEntity], eventService services.EventService, artistService services.ArtistService, organizationService services.OrganizationService) *eventApplicationService {
 	dbQueries := models.New(db)
 	return &eventApplicationService{
-		db:           db,
-		config:       cfg,
-		wg:           wg,
-		logger:       logger,
-		queries:      dbQueries,
-		bus:          bus,
-		eventService: eventService,
+		db:                  db,
+		config:              cfg,
+		wg:                  wg,
+		logger:              logger,
+		queries:             dbQueries,
+		bus:                 bus,
+		eventService:        eventService,
+		artistService:       artistService,
+		organizationService: organizationService,
 	}
 }
 
-func (app *eventApplicationService) MessageBus() *bus.MessageBus[*dto.EventDto] {
+func (app *eventApplicationService) MessageBus() bus.Bus[*entities.EventChangeEntity] {
 	return app.bus
 }
 
+// publish notifies listeners of a change. It must only be called once the
+// change has been committed.
+func (app *eventApplicationService) publish(ctx context.Context, change *entities.EventChangeEntity) {
+	app.logger.Info().Ctx(ctx).Str("event_id", change.EventID.String()).Str("kind", string(change.Kind)).Int64("seq", change.Seq).Msg("Publishing event change")
+
+	app.bus.Publish(change.EventID.String(), change)
+}
+
 func (app *eventApplicationService) GetEventByID(ctx context.Context, query queries.EventByIDQuery) (*entities.EventEntity, error) {
 	app.logger.Info().Ctx(ctx).Msg("Getting event by ID")
 
@@ -80,30 +104,12 @@ func (app *eventApplicationService) GetEventByID(ctx context.Context, query quer
 func (app *eventApplicationService) GetCurrentEvent(ctx context.Context, query queries.CurrentEventQuery) (*entities.EventEntity, error) {
 	app.logger.Info().Ctx(ctx).Msg("Getting current event")
 
-	events, err := app.eventService.GetEvents(ctx, app.queries)
+	event, err := app.eventService.GetCurrentEvent(ctx, app.queries, query.OrganizationID, query.VenueID, time.Now())
 	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to get all events")
-		return nil, err
-	}
-
-	// Show up 24 hours before start date
-
-	var currentEventID uuid.UUID
-
-	for _, event := range events {
-		if event.IsCurrent() && event.EventType == "OPEN_MIC" {
-			currentEventID = event.ID
-			break
+		if errors.Is(err, entities.ErrEventNotFound) {
+			return nil, nil
 		}
-	}
-
-	if currentEventID == uuid.Nil {
-		return nil, nil
-	}
-
-	event, err := app.eventService.GetEventByID(ctx, app.queries, currentEventID)
-	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to get current event")
 		return nil, err
 	}
 
@@ -113,7 +119,7 @@ func (app *eventApplicationService) GetCurrentEvent(ctx context.Context, query q
 func (app *eventApplicationService) GetEvents(ctx context.Context, query queries.EventsQuery) ([]*entities.EventEntity, error) {
 	app.logger.Info().Ctx(ctx).Msg("Getting all events")
 
-	events, err := app.eventService.GetEvents(ctx, app.queries)
+	events, err := app.eventService.GetEvents(ctx, app.queries, query.OrganizationID)
 	if err != nil {
 		app.logger.Err(err).Ctx(ctx).Msg("Failed to get all events")
 		return nil, err
@@ -139,211 +145,535 @@ func (app *eventApplicationService) CreateEvent(ctx context.Context, cmd command
 func (app *eventApplicationService) UpdateEvent(ctx context.Context, cmd commands.UpdateEventCommand) (*entities.EventEntity, error) {
 	app.logger.Info().Ctx(ctx).Msg("Updating event")
 
-	eventEntity := cmd.ToDomain()
+	return app.mutateLineup(ctx, cmd.ID, entities.EventChangeEventUpdated, func(qtx models.Querier) error {
+		currentEvent, err := app.eventService.GetEventByID(ctx, qtx, cmd.ID)
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
+			return err
+		}
 
-	event, err := app.eventService.UpdateEvent(ctx, app.queries, eventEntity)
-	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to update event")
-		return nil, err
-	}
+		err = entities.CheckVersion(currentEvent.Version, cmd.ExpectedVersion)
+		if err != nil {
+			return err
+		}
 
-	return event, nil
+		eventEntity := cmd.ToDomain()
+		eventEntity.Version = currentEvent.Version
+
+		if cmd.SlotPolicy == nil {
+			eventEntity.SlotPolicy = currentEvent.SlotPolicy
+		}
+		if cmd.AllowDuplicateArtists == nil {
+			eventEntity.AllowDuplicateArtists = currentEvent.AllowDuplicateArtists
+		}
+
+		_, err = app.eventService.UpdateEvent(ctx, qtx, eventEntity)
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to update event")
+			return err
+		}
+
+		return nil
+	})
 }
 
 func (app *eventApplicationService) DeleteEvent(ctx context.Context, query commands.DeleteEventCommand) error {
 	app.logger.Info().Ctx(ctx).Msg("Deleting event")
 
-	err := app.eventService.DeleteEvent(ctx, app.queries, query.ID)
+	tx, cancel, err := postgres.CreateTransaction(ctx, app.db)
+	defer cancel()
 	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to delete event")
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to create transaction")
 		return err
 	}
+	defer tx.Rollback(ctx)
 
-	return nil
-}
-
-func (app *eventApplicationService) AddArtistToEvent(ctx context.Context, cmd commands.AddArtistToEventCommand) (*entities.EventEntity, error) {
-	app.logger.Info().Ctx(ctx).Msg("Adding artist to event")
+	qtx := models.New(app.db).WithTx(tx)
 
-	err := app.eventService.AddArtistToEvent(ctx, app.queries, cmd.EventID, cmd.ArtistID)
+	event, err := app.eventService.GetEventByID(ctx, qtx, query.ID)
 	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to add artist to event")
-		return nil, err
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
+		return err
 	}
 
-	event, err := app.eventService.GetEventByID(ctx, app.queries, cmd.EventID)
+	err = entities.CheckVersion(event.Version, query.ExpectedVersion)
 	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
-		return nil, err
+		return err
 	}
 
-	return event, nil
-}
-
-func (app *eventApplicationService) RemoveArtistFromEvent(ctx context.Context, cmd commands.RemoveArtistFromEventCommand) (*entities.EventEntity, error) {
-	app.logger.Info().Ctx(ctx).Msg("Removing artist from event")
+	change, err := app.eventService.RecordChange(ctx, qtx, entities.EventChangeEventDeleted, query.ID)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to record event change")
+		return err
+	}
 
-	err := app.eventService.RemoveArtistFromEvent(ctx, app.queries, cmd.EventID, cmd.ArtistID)
+	err = app.eventService.DeleteEvent(ctx, qtx, event)
 	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to remove artist from event")
-		return nil, err
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to delete event")
+		return err
 	}
 
-	event, err := app.eventService.GetEventByID(ctx, app.queries, cmd.EventID)
+	err = tx.Commit(ctx)
 	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
-		return nil, err
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to commit transaction")
+		return err
 	}
 
-	return event, nil
+	app.publish(ctx, change)
+
+	return nil
 }
 
-func (app *eventApplicationService) SetTimeslotMarker(ctx context.Context, cmd commands.SetTimeslotMarkerCommand) (*entities.EventEntity, error) {
-	app.logger.Info().Ctx(ctx).Msg("Setting timeslot marker")
+func (app *eventApplicationService) AddArtistToEvent(ctx context.Context, cmd commands.AddArtistToEventCommand) (*entities.EventEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Adding artist to event")
 
-	tx, cancel, err := postgres.CreateTransaction(ctx, app.db)
-	defer cancel()
-	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to create transaction")
-		return nil, err
-	}
-	defer tx.Rollback(ctx)
+	return app.mutateLineup(ctx, cmd.EventID, entities.EventChangeArtistAdded, func(qtx models.Querier) error {
+		err := app.eventService.AddArtistToEvent(ctx, qtx, cmd.EventID, cmd.ArtistID, nil, entities.DefaultSongCount)
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to add artist to event")
+			return err
+		}
 
-	qtx := models.New(app.db).WithTx(tx)
+		return nil
+	})
+}
 
-	err = app.eventService.SetTimeslotMarker(ctx, qtx, cmd.EventID, cmd.SlotIndex, cmd.TimeDisplay)
-	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to set timeslot")
-		return nil, err
+// WalkIn puts someone on the lineup by name, reusing the organization's artist
+// with that title or creating one, in a single transaction.
+func (app *eventApplicationService) WalkIn(ctx context.Context, cmd commands.WalkInCommand) (*entities.WalkInEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Adding walk-in to event")
+
+	title := strings.TrimSpace(cmd.Title)
+	if title == "" {
+		return nil, entities.ErrArtistTitleRequired
 	}
 
-	event, err := app.eventService.GetEventByID(ctx, qtx, cmd.EventID)
-	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
-		return nil, err
+	songCount := entities.DefaultSongCount
+	if cmd.SongCount != nil {
+		songCount = *cmd.SongCount
 	}
 
-	err = tx.Commit(ctx)
+	var walkIn *entities.WalkInEntity
+	event, err := app.mutateLineup(ctx, cmd.EventID, entities.EventChangeArtistAdded, func(qtx models.Querier) error {
+		organization, err := app.organizationService.GetOrganizationByEventID(ctx, qtx, cmd.EventID)
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to get organization by event ID")
+			return err
+		}
+
+		similarArtists, err := app.artistService.GetArtistsByTitle(ctx, qtx, organization.ID, title)
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to get artists by title")
+			return err
+		}
+
+		artist := entities.FindArtistByTitle(similarArtists, title)
+		artistCreated := artist == nil
+		if artistCreated {
+			artist, err = app.artistService.CreateArtist(ctx, qtx, &entities.ArtistEntity{
+				ID:             uuid.New(),
+				OrganizationID: organization.ID,
+				Title:          title,
+			})
+			if err != nil {
+				app.logger.Err(err).Ctx(ctx).Msg("Failed to create artist")
+				return err
+			}
+		}
+
+		err = app.eventService.AddArtistToEvent(ctx, qtx, cmd.EventID, artist.ID, cmd.NameOverride, songCount)
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to add artist to event")
+			return err
+		}
+
+		walkIn = entities.NewWalkInEntity(artist, artistCreated, similarArtists)
+
+		return nil
+	})
 	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to commit transaction")
 		return nil, err
 	}
 
-	return event, nil
+	walkIn.Event = event
+
+	return walkIn, nil
+}
+
+func (app *eventApplicationService) AddLineupItem(ctx context.Context, cmd commands.AddLineupItemCommand) (*entities.EventEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Adding lineup item")
+
+	return app.mutateLineup(ctx, cmd.EventID, entities.EventChangeItemAdded, func(qtx models.Querier) error {
+		err := app.eventService.AddLineupItem(ctx, qtx, cmd.EventID, cmd.ToDomain())
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to add lineup item")
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (app *eventApplicationService) RemoveArtistFromEvent(ctx context.Context, cmd commands.RemoveArtistFromEventCommand) (*entities.EventEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Removing artist from event")
+
+	return app.mutateLineup(ctx, cmd.EventID, entities.EventChangeArtistRemoved, func(qtx models.Querier) error {
+		err := app.eventService.RemoveArtistFromEvent(ctx, qtx, cmd.EventID, cmd.ArtistID)
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to remove artist from event")
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (app *eventApplicationService) SetTimeslotMarker(ctx context.Context, cmd commands.SetTimeslotMarkerCommand) (*entities.EventEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Setting timeslot marker")
+
+	return app.mutateLineup(ctx, cmd.EventID, entities.EventChangeMarkerSet, func(qtx models.Querier) error {
+		err := app.eventService.SetTimeslotMarker(ctx, qtx, cmd.EventID, cmd.TimeSlotID, cmd.Time)
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to set timeslot")
+			return err
+		}
+
+		return nil
+	})
 }
 
 func (app *eventApplicationService) DeleteTimeslotMarker(ctx context.Context, cmd commands.DeleteTimeslotMarkerCommand) (*entities.EventEntity, error) {
 	app.logger.Info().Ctx(ctx).Msg("Deleting timeslot marker")
 
-	err := app.eventService.DeleteTimeslotMarker(ctx, app.queries, cmd.EventID, cmd.SlotMarkerID)
-	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to set timeslot")
-		return nil, err
-	}
-
-	event, err := app.eventService.GetEventByID(ctx, app.queries, cmd.EventID)
-	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
-		return nil, err
-	}
+	return app.mutateLineup(ctx, cmd.EventID, entities.EventChangeMarkerDeleted, func(qtx models.Querier) error {
+		err := app.eventService.DeleteTimeslotMarker(ctx, qtx, cmd.EventID, cmd.SlotMarkerID)
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to set timeslot")
+			return err
+		}
 
-	return event, nil
+		return nil
+	})
 }
 
 func (app *eventApplicationService) SetSortOrder(ctx context.Context, cmd commands.SetSortOrderCommand) (*entities.EventEntity, error) {
 	app.logger.Info().Ctx(ctx).Msg("Setting sort order")
 
-	event, err := app.eventService.GetEventByID(ctx, app.queries, cmd.EventID)
-	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
-		return nil, err
-	}
+	return app.mutateLineup(ctx, cmd.EventID, entities.EventChangeSlotReordered, func(qtx models.Querier) error {
+		event, err := app.eventService.GetEventByID(ctx, qtx, cmd.EventID)
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
+			return err
+		}
 
-	currentSlot := event.TimeSlotByID(cmd.CurrentSlotID)
-	var beforeSlot, afterSlot *entities.TimeSlotEntity
-	var beforeSlotSortKey, afterSlotSortKey string
-	if currentSlot == nil {
-		return event, nil
-	}
+		currentSlot := event.TimeSlotByID(cmd.CurrentSlotID)
+		var beforeSlot, afterSlot *entities.TimeSlotEntity
+		var beforeSlotSortKey, afterSlotSortKey string
+		if currentSlot == nil {
+			return entities.ErrTimeslotNotFound
+		}
 
-	if cmd.BeforeSlotID != nil {
-		beforeSlot = event.TimeSlotByID(*cmd.BeforeSlotID)
-		afterSlot = event.NextTimeSlotByID(*cmd.BeforeSlotID)
-	}
+		if cmd.BeforeSlotID != nil {
+			beforeSlot = event.TimeSlotByID(*cmd.BeforeSlotID)
+			afterSlot = event.NextTimeSlotByID(*cmd.BeforeSlotID)
+		}
 
-	if cmd.AfterSlotID != nil {
-		afterSlot = event.TimeSlotByID(*cmd.AfterSlotID)
-		beforeSlot = event.PreviousTimeSlotByID(*cmd.AfterSlotID)
-	}
+		if cmd.AfterSlotID != nil {
+			afterSlot = event.TimeSlotByID(*cmd.AfterSlotID)
+			beforeSlot = event.PreviousTimeSlotByID(*cmd.AfterSlotID)
+		}
 
-	if beforeSlot != nil {
-		beforeSlotSortKey = beforeSlot.SortKey
-	} else {
-		beforeSlotSortKey = ""
-	}
+		if beforeSlot != nil {
+			beforeSlotSortKey = beforeSlot.SortKey
+		} else {
+			beforeSlotSortKey = ""
+		}
 
-	if afterSlot != nil {
-		afterSlotSortKey = afterSlot.SortKey
-	} else {
-		afterSlotSortKey = ""
-	}
+		if afterSlot != nil {
+			afterSlotSortKey = afterSlot.SortKey
+		} else {
+			afterSlotSortKey = ""
+		}
 
-	sortKey, err := common.KeyBetween(beforeSlotSortKey, afterSlotSortKey)
-	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to generate sort key")
-		return nil, err
-	}
+		sortKey, err := common.KeyBetween(beforeSlotSortKey, afterSlotSortKey)
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to generate sort key")
+			return err
+		}
 
-	currentSlot.SortKey = sortKey
+		currentSlot.SortKey = sortKey
 
-	err = app.eventService.UpdateTimeSlot(ctx, app.queries, currentSlot)
-	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to update timeslot")
-		return nil, err
-	}
+		err = app.eventService.UpdateTimeSlot(ctx, qtx, currentSlot)
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to update timeslot")
+			return err
+		}
 
-	return event, nil
+		return nil
+	})
+}
+
+func (app *eventApplicationService) ReorderLineup(ctx context.Context, cmd commands.ReorderLineupCommand) (*entities.EventEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Reordering lineup")
+
+	return app.mutateLineup(ctx, cmd.EventID, entities.EventChangeLineupReordered, func(qtx models.Querier) error {
+		return app.eventService.ReorderTimeSlots(ctx, qtx, cmd.EventID, cmd.TimeSlotIDs)
+	})
+}
+
+func (app *eventApplicationService) RebalanceLineup(ctx context.Context, cmd commands.RebalanceLineupCommand) (*entities.EventEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Rebalancing lineup")
+
+	return app.mutateLineup(ctx, cmd.EventID, entities.EventChangeLineupRebalanced, func(qtx models.Querier) error {
+		return app.eventService.RebalanceTimeSlots(ctx, qtx, cmd.EventID)
+	})
 }
 
 func (app *eventApplicationService) UpdateTimeSlot(ctx context.Context, cmd commands.UpdateTimeSlotCommand) (*entities.EventEntity, error) {
 	app.logger.Info().Ctx(ctx).Msg("Updating timeslot")
 
-	event, err := app.eventService.GetEventByID(ctx, app.queries, cmd.EventID)
-	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
-		return nil, err
-	}
+	return app.mutateLineup(ctx, cmd.EventID, entities.EventChangeSlotUpdated, func(qtx models.Querier) error {
+		event, err := app.eventService.GetEventByID(ctx, qtx, cmd.EventID)
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
+			return err
+		}
 
-	timeslot := event.TimeSlotByID(cmd.TimeSlotID)
-	if timeslot == nil {
-		return event, nil
-	}
+		timeslot := event.TimeSlotByID(cmd.TimeSlotID)
+		if timeslot == nil {
+			return entities.ErrTimeslotNotFound
+		}
 
-	timeslot.SongCount = cmd.SongCount
+		err = entities.CheckVersion(timeslot.Version, cmd.ExpectedVersion)
+		if err != nil {
+			return err
+		}
 
-	err = app.eventService.UpdateTimeSlot(ctx, app.queries, timeslot)
-	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to update timeslot")
-		return nil, err
-	}
+		timeslot.SongCount = cmd.SongCount
+		if cmd.DurationOverrideMinutes != nil {
+			timeslot.DurationOverrideMinutes = nilIfZero(*cmd.DurationOverrideMinutes)
+		}
 
-	return event, nil
+		err = app.eventService.UpdateTimeSlot(ctx, qtx, timeslot)
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to update timeslot")
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (app *eventApplicationService) PatchTimeSlot(ctx context.Context, cmd commands.PatchTimeSlotCommand) (*entities.EventEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Patching timeslot")
+
+	return app.mutateLineup(ctx, cmd.EventID, entities.EventChangeSlotUpdated, func(qtx models.Querier) error {
+		event, err := app.eventService.GetEventByID(ctx, qtx, cmd.EventID)
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
+			return err
+		}
+
+		timeslot := event.TimeSlotByID(cmd.TimeSlotID)
+		if timeslot == nil {
+			return entities.ErrTimeslotNotFound
+		}
+
+		err = entities.CheckVersion(timeslot.Version, cmd.ExpectedVersion)
+		if err != nil {
+			return err
+		}
+
+		if cmd.NameOverride != nil {
+			timeslot.NameOverride = nilIfEmpty(*cmd.NameOverride)
+		}
+		if cmd.SongCount != nil {
+			timeslot.SongCount = *cmd.SongCount
+		}
+		if cmd.Notes != nil {
+			timeslot.Notes = nilIfEmpty(*cmd.Notes)
+		}
+
+		err = app.eventService.UpdateTimeSlot(ctx, qtx, timeslot)
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to patch timeslot")
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (app *eventApplicationService) DeleteTimeSlot(ctx context.Context, cmd commands.DeleteTimeSlotCommand) (*entities.EventEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Deleting timeslot")
+
+	return app.mutateLineup(ctx, cmd.EventID, entities.EventChangeSlotRemoved, func(qtx models.Querier) error {
+		event, err := app.eventService.GetEventByID(ctx, qtx, cmd.EventID)
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
+			return err
+		}
+
+		timeslot := event.TimeSlotByID(cmd.TimeSlotID)
+		if timeslot == nil {
+			return entities.ErrTimeslotNotFound
+		}
+
+		err = entities.CheckVersion(timeslot.Version, cmd.ExpectedVersion)
+		if err != nil {
+			return err
+		}
+
+		err = app.eventService.DeleteTimeSlot(ctx, qtx, cmd.EventID, timeslot)
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to delete timeslot")
+			return err
+		}
+
+		return nil
+	})
 }
 
 func (app *eventApplicationService) SetNowPlaying(ctx context.Context, cmd commands.SetNowPlayingCommand) (*entities.EventEntity, error) {
 	app.logger.Info().Ctx(ctx).Msg("Setting now playing")
 
-	err := app.eventService.SetNowPlaying(ctx, app.queries, cmd.EventID, cmd.Index)
+	return app.mutateLineup(ctx, cmd.EventID, entities.EventChangeNowPlayingChanged, func(qtx models.Querier) error {
+		err := app.eventService.SetNowPlaying(ctx, qtx, cmd.EventID, cmd.TimeSlotID, time.Now())
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to update timeslot")
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (app *eventApplicationService) StartShow(ctx context.Context, cmd commands.StartShowCommand) (*entities.EventEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Starting show")
+
+	return app.mutateLineup(ctx, cmd.EventID, entities.EventChangeShowStarted, func(qtx models.Querier) error {
+		err := app.eventService.StartShow(ctx, qtx, cmd.EventID, time.Now())
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to start show")
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (app *eventApplicationService) NextTimeSlot(ctx context.Context, cmd commands.NextTimeSlotCommand) (*entities.EventEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Advancing to next timeslot")
+
+	return app.mutateLineup(ctx, cmd.EventID, entities.EventChangeNowPlayingChanged, func(qtx models.Querier) error {
+		err := app.eventService.NextTimeSlot(ctx, qtx, cmd.EventID, time.Now())
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to advance to next timeslot")
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (app *eventApplicationService) PreviousTimeSlot(ctx context.Context, cmd commands.PreviousTimeSlotCommand) (*entities.EventEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Going back to previous timeslot")
+
+	return app.mutateLineup(ctx, cmd.EventID, entities.EventChangeNowPlayingChanged, func(qtx models.Querier) error {
+		err := app.eventService.PreviousTimeSlot(ctx, qtx, cmd.EventID, time.Now())
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to go back to previous timeslot")
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (app *eventApplicationService) EndShow(ctx context.Context, cmd commands.EndShowCommand) (*entities.EventEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Ending show")
+
+	return app.mutateLineup(ctx, cmd.EventID, entities.EventChangeShowEnded, func(qtx models.Querier) error {
+		err := app.eventService.EndShow(ctx, qtx, cmd.EventID, time.Now())
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to end show")
+			return err
+		}
+
+		return nil
+	})
+}
+
+// mutateLineup runs mutate in a transaction that holds the event's row lock,
+// records the change and publishes it once committed. Concurrent edits to the
+// same lineup therefore apply one after another, and a transaction that still
+// loses a race is run again from the start.
+func (app *eventApplicationService) mutateLineup(ctx context.Context, eventID uuid.UUID, kind entities.EventChangeKind, mutate func(qtx models.Querier) error) (*entities.EventEntity, error) {
+	var change *entities.EventChangeEntity
+
+	err := postgres.RetryTransaction(ctx, func() error {
+		tx, cancel, err := postgres.CreateTransaction(ctx, app.db)
+		defer cancel()
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to create transaction")
+			return err
+		}
+		defer tx.Rollback(ctx)
+
+		qtx := models.New(app.db).WithTx(tx)
+
+		err = app.eventService.LockEvent(ctx, qtx, eventID)
+		if err != nil {
+			return err
+		}
+
+		err = mutate(qtx)
+		if err != nil {
+			return err
+		}
+
+		// Keys that have grown too long are rewritten in the same transaction,
+		// so listeners still see a single change.
+		err = app.eventService.RebalanceTimeSlotsIfNeeded(ctx, qtx, eventID)
+		if err != nil {
+			return err
+		}
+
+		change, err = app.eventService.RecordChange(ctx, qtx, kind, eventID)
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to record event change")
+			return err
+		}
+
+		err = tx.Commit(ctx)
+		if err != nil {
+			app.logger.Err(err).Ctx(ctx).Msg("Failed to commit transaction")
+			return err
+		}
+
+		return nil
+	})
 	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to update timeslot")
 		return nil, err
 	}
 
-	event, err := app.eventService.GetEventByID(ctx, app.queries, cmd.EventID)
-	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
-		return nil, err
+	app.publish(ctx, change)
+
+	return change.Event, nil
+}
+
+// nilIfEmpty turns an empty optional text field into NULL.
+func nilIfEmpty(value string) *string {
+	if value == "" {
+		return nil
 	}
+	return &value
+}
 
-	return event, nil
+// nilIfZero turns a zero duration override into NULL.
+func nilIfZero(value int32) *int32 {
+	if value == 0 {
+		return nil
+	}
+	return &value
 }
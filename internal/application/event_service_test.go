@@ -0,0 +1,203 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/application/commands"
+	"github.com/mcorrigan89/openmic/internal/application/queries"
+	"github.com/mcorrigan89/openmic/internal/common"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/mcorrigan89/openmic/internal/domain/services"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/bus"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/repositories"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestAddArtistToEventConcurrently signs many artists up for the same event at
+// once. It runs against a migrated database named by TEST_DATABASE_URL.
+func TestAddArtistToEventConcurrently(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	cfg := &common.Config{}
+	cfg.DB.DSN = dsn
+	db, err := postgres.OpenDBPool(cfg)
+	require.NoError(t, err)
+	defer db.Close()
+
+	dbQueries := models.New(db)
+
+	organization, err := dbQueries.CreateOrganization(ctx, models.CreateOrganizationParams{
+		ID:                 uuid.New(),
+		OrganizationName:   "Concurrency test",
+		OrganizationHandle: "concurrency-" + uuid.NewString(),
+	})
+	require.NoError(t, err)
+	defer db.Exec(ctx, "DELETE FROM organization WHERE id = $1", organization.ID)
+
+	venue, err := dbQueries.CreateVenue(ctx, models.CreateVenueParams{
+		ID:             uuid.New(),
+		OrganizationID: organization.ID,
+		VenueName:      "Concurrency test",
+	})
+	require.NoError(t, err)
+
+	policy := entities.DefaultSlotDurationPolicy()
+	event, err := dbQueries.CreateEvent(ctx, models.CreateEventParams{
+		ID:                uuid.New(),
+		VenueID:           venue.ID,
+		EventType:         "OPEN_MIC",
+		StartTime:         time.Now(),
+		EndTime:           time.Now().Add(3 * time.Hour),
+		MinutesPerSong:    policy.MinutesPerSong,
+		BaseSlotMinutes:   policy.BaseMinutes,
+		ChangeoverMinutes: policy.ChangeoverMinutes,
+	})
+	require.NoError(t, err)
+
+	const signUps = 20
+	artistIDs := make([]uuid.UUID, 0, signUps)
+	for i := range signUps {
+		artist, err := dbQueries.CreateArtist(ctx, models.CreateArtistParams{
+			ID:             uuid.New(),
+			ArtistTitle:    fmt.Sprintf("Artist %d", i),
+			OrganizationID: organization.ID,
+		})
+		require.NoError(t, err)
+		artistIDs = append(artistIDs, artist.ID)
+	}
+
+	eventService := services.NewEventService(&logger, repositories.NewPostgresEventRepository(&logger))
+	artistService := services.NewArtistService(&logger, repositories.NewPostgresArtistRepository())
+	organizationService := services.NewOrganizationService(&logger, repositories.NewPostgresOrganizationRepository())
+	app := NewEventApplicationService(db, &sync.WaitGroup{}, cfg, &logger, bus.NewMessageBus[*entities.EventChangeEntity](), eventService, artistService, organizationService)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, signUps)
+	for _, artistID := range artistIDs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := app.AddArtistToEvent(ctx, commands.AddArtistToEventCommand{
+				EventID:  event.ID,
+				ArtistID: artistID,
+			})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.NoError(t, err)
+	}
+
+	lineup, err := app.GetEventByID(ctx, queries.EventByIDQuery{ID: event.ID})
+	require.NoError(t, err)
+
+	sortKeys := make(map[string]bool, signUps)
+	for _, timeslot := range lineup.TimeSlots() {
+		assert.False(t, sortKeys[timeslot.SortKey], "duplicate sort key %q", timeslot.SortKey)
+		sortKeys[timeslot.SortKey] = true
+	}
+	assert.Len(t, sortKeys, signUps)
+}
+
+// TestGetCurrentEventVenueWindow checks a venue's own window makes every event
+// at the venue eligible, whatever its type. It runs against a migrated
+// database named by TEST_DATABASE_URL.
+func TestGetCurrentEventVenueWindow(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	ctx := context.Background()
+	logger := zerolog.Nop()
+
+	cfg := &common.Config{}
+	cfg.DB.DSN = dsn
+	db, err := postgres.OpenDBPool(cfg)
+	require.NoError(t, err)
+	defer db.Close()
+
+	dbQueries := models.New(db)
+
+	organization, err := dbQueries.CreateOrganization(ctx, models.CreateOrganizationParams{
+		ID:                 uuid.New(),
+		OrganizationName:   "Current event test",
+		OrganizationHandle: "current-event-" + uuid.NewString(),
+	})
+	require.NoError(t, err)
+	defer db.Exec(ctx, "DELETE FROM organization WHERE id = $1", organization.ID)
+
+	minutes := int32(60)
+	withWindow, err := dbQueries.CreateVenue(ctx, models.CreateVenueParams{
+		ID:                   uuid.New(),
+		OrganizationID:       organization.ID,
+		VenueName:            "With a window",
+		CurrentMinutesBefore: &minutes,
+		CurrentMinutesAfter:  &minutes,
+	})
+	require.NoError(t, err)
+	withoutWindow, err := dbQueries.CreateVenue(ctx, models.CreateVenueParams{
+		ID:             uuid.New(),
+		OrganizationID: organization.ID,
+		VenueName:      "Without a window",
+	})
+	require.NoError(t, err)
+
+	policy := entities.DefaultSlotDurationPolicy()
+	createComedy := func(venueID uuid.UUID) models.Event {
+		startTime := time.Now().Add(30 * time.Minute)
+		event, err := dbQueries.CreateEvent(ctx, models.CreateEventParams{
+			ID:                uuid.New(),
+			VenueID:           venueID,
+			EventType:         "COMEDY",
+			StartTime:         startTime,
+			EndTime:           startTime.Add(2 * time.Hour),
+			MinutesPerSong:    policy.MinutesPerSong,
+			BaseSlotMinutes:   policy.BaseMinutes,
+			ChangeoverMinutes: policy.ChangeoverMinutes,
+		})
+		require.NoError(t, err)
+		return event
+	}
+	comedy := createComedy(withWindow.ID)
+	createComedy(withoutWindow.ID)
+
+	eventService := services.NewEventService(&logger, repositories.NewPostgresEventRepository(&logger))
+	artistService := services.NewArtistService(&logger, repositories.NewPostgresArtistRepository())
+	organizationService := services.NewOrganizationService(&logger, repositories.NewPostgresOrganizationRepository())
+	app := NewEventApplicationService(db, &sync.WaitGroup{}, cfg, &logger, bus.NewMessageBus[*entities.EventChangeEntity](), eventService, artistService, organizationService)
+
+	t.Run("an event at a venue with a window is current", func(t *testing.T) {
+		current, err := app.GetCurrentEvent(ctx, queries.CurrentEventQuery{OrganizationID: organization.ID, VenueID: &withWindow.ID})
+
+		require.NoError(t, err)
+		require.NotNil(t, current)
+		assert.Equal(t, comedy.ID, current.ID)
+	})
+
+	t.Run("other event types still need a window", func(t *testing.T) {
+		current, err := app.GetCurrentEvent(ctx, queries.CurrentEventQuery{OrganizationID: organization.ID, VenueID: &withoutWindow.ID})
+
+		require.NoError(t, err)
+		assert.Nil(t, current)
+	})
+}
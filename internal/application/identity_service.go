@@ -0,0 +1,116 @@
+package application
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mcorrigan89/openmic/internal/application/commands"
+	"github.com/mcorrigan89/openmic/internal/application/queries"
+	"github.com/mcorrigan89/openmic/internal/common"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/mcorrigan89/openmic/internal/domain/services"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+
+	"github.com/rs/zerolog"
+)
+
+type IdentityApplicationService interface {
+	GetProviders(ctx context.Context) []string
+	StartExternalLogin(ctx context.Context, cmd commands.StartExternalLoginCommand) (*entities.LoginRequestEntity, error)
+	CompleteExternalLogin(ctx context.Context, cmd commands.CompleteExternalLoginCommand) (*entities.UserContextEntity, error)
+	GetIdentities(ctx context.Context, query queries.IdentitiesByUserQuery) ([]*entities.IdentityEntity, error)
+}
+
+type identityApplicationService struct {
+	config          *common.Config
+	wg              *sync.WaitGroup
+	logger          *zerolog.Logger
+	db              *pgxpool.Pool
+	queries         models.Querier
+	identityService services.IdentityService
+}
+
+func NewIdentityApplicationService(db *pgxpool.Pool, wg *sync.WaitGroup, cfg *common.Config, logger *zerolog.Logger, identityService services.IdentityService) *identityApplicationService {
+	dbQueries := models.New(db)
+	return &identityApplicationService{
+		db:              db,
+		config:          cfg,
+		wg:              wg,
+		logger:          logger,
+		queries:         dbQueries,
+		identityService: identityService,
+	}
+}
+
+func (app *identityApplicationService) GetProviders(ctx context.Context) []string {
+	return app.identityService.Providers()
+}
+
+func (app *identityApplicationService) StartExternalLogin(ctx context.Context, cmd commands.StartExternalLoginCommand) (*entities.LoginRequestEntity, error) {
+	app.logger.Info().Ctx(ctx).Str("provider", cmd.Provider).Msg("Starting external login")
+
+	loginRequest, err := app.identityService.StartLogin(ctx, app.queries, cmd.Provider)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to start external login")
+		return nil, err
+	}
+
+	return loginRequest, nil
+}
+
+func (app *identityApplicationService) CompleteExternalLogin(ctx context.Context, cmd commands.CompleteExternalLoginCommand) (*entities.UserContextEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Completing external login")
+
+	// The request is consumed before the code is redeemed so a state can only
+	// be tried once, and the provider round trip happens outside the
+	// transaction.
+	loginRequest, err := app.identityService.ConsumeLoginRequest(ctx, app.queries, cmd.State, cmd.Binding)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to consume login request")
+		return nil, err
+	}
+
+	claims, err := app.identityService.VerifyLogin(ctx, loginRequest, cmd.Code)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to verify external login")
+		return nil, err
+	}
+
+	tx, cancel, err := postgres.CreateTransaction(ctx, app.db)
+	defer cancel()
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to create transaction")
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := models.New(app.db).WithTx(tx)
+
+	userSession, err := app.identityService.LoginWithClaims(ctx, qtx, claims)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to login with external identity")
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to commit transaction")
+		return nil, err
+	}
+
+	return userSession, nil
+}
+
+func (app *identityApplicationService) GetIdentities(ctx context.Context, query queries.IdentitiesByUserQuery) ([]*entities.IdentityEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Getting identities by user")
+
+	identities, err := app.identityService.GetIdentities(ctx, app.queries, query.UserID)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to get identities by user")
+		return nil, err
+	}
+
+	return identities, nil
+}
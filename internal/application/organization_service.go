@@ -0,0 +1,258 @@
+package application
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mcorrigan89/openmic/internal/application/commands"
+	"github.com/mcorrigan89/openmic/internal/application/queries"
+	"github.com/mcorrigan89/openmic/internal/common"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/mcorrigan89/openmic/internal/domain/services"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+
+	"github.com/rs/zerolog"
+)
+
+type OrganizationApplicationService interface {
+	GetOrganizationByID(ctx context.Context, query queries.OrganizationByIDQuery) (*entities.OrganizationEntity, error)
+	GetOrganizationsByUser(ctx context.Context, query queries.OrganizationsByUserQuery) ([]*entities.OrganizationEntity, error)
+	CreateOrganization(ctx context.Context, cmd commands.CreateOrganizationCommand) (*entities.OrganizationEntity, error)
+	UpdateOrganization(ctx context.Context, cmd commands.UpdateOrganizationCommand) (*entities.OrganizationEntity, error)
+	GetOrganizationMembers(ctx context.Context, query queries.OrganizationMembersQuery) ([]*entities.UserEntity, error)
+	AddOrganizationMember(ctx context.Context, cmd commands.AddOrganizationMemberCommand) error
+	RemoveOrganizationMember(ctx context.Context, cmd commands.RemoveOrganizationMemberCommand) error
+	GetVenueByID(ctx context.Context, query queries.VenueByIDQuery) (*entities.VenueEntity, error)
+	GetVenuesByOrganization(ctx context.Context, query queries.VenuesByOrganizationQuery) ([]*entities.VenueEntity, error)
+	CreateVenue(ctx context.Context, cmd commands.CreateVenueCommand) (*entities.VenueEntity, error)
+	UpdateVenue(ctx context.Context, cmd commands.UpdateVenueCommand) (*entities.VenueEntity, error)
+	DeleteVenue(ctx context.Context, cmd commands.DeleteVenueCommand) error
+	GetEventTypeWindows(ctx context.Context, query queries.EventTypeWindowsQuery) ([]*entities.EventTypeWindowEntity, error)
+	SetEventTypeWindow(ctx context.Context, cmd commands.SetEventTypeWindowCommand) (*entities.EventTypeWindowEntity, error)
+	DeleteEventTypeWindow(ctx context.Context, cmd commands.DeleteEventTypeWindowCommand) error
+}
+
+type organizationApplicationService struct {
+	config              *common.Config
+	wg                  *sync.WaitGroup
+	logger              *zerolog.Logger
+	db                  *pgxpool.Pool
+	queries             models.Querier
+	organizationService services.OrganizationService
+}
+
+func NewOrganizationApplicationService(db *pgxpool.Pool, wg *sync.WaitGroup, cfg *common.Config, logger *zerolog.Logger, organizationService services.OrganizationService) *organizationApplicationService {
+	dbQueries := models.New(db)
+	return &organizationApplicationService{
+		db:                  db,
+		config:              cfg,
+		wg:                  wg,
+		logger:              logger,
+		queries:             dbQueries,
+		organizationService: organizationService,
+	}
+}
+
+func (app *organizationApplicationService) GetOrganizationByID(ctx context.Context, query queries.OrganizationByIDQuery) (*entities.OrganizationEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Getting organization by ID")
+
+	organization, err := app.organizationService.GetOrganizationByID(ctx, app.queries, query.ID)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to get organization by ID")
+		return nil, err
+	}
+
+	return organization, nil
+}
+
+func (app *organizationApplicationService) GetOrganizationsByUser(ctx context.Context, query queries.OrganizationsByUserQuery) ([]*entities.OrganizationEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Getting organizations by user")
+
+	organizations, err := app.organizationService.GetOrganizationsByUserID(ctx, app.queries, query.UserID)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to get organizations by user")
+		return nil, err
+	}
+
+	return organizations, nil
+}
+
+func (app *organizationApplicationService) CreateOrganization(ctx context.Context, cmd commands.CreateOrganizationCommand) (*entities.OrganizationEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Creating new organization")
+
+	tx, cancel, err := postgres.CreateTransaction(ctx, app.db)
+	defer cancel()
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to create transaction")
+		return nil, err
+	}
+	defer tx.Rollback(ctx)
+
+	qtx := models.New(app.db).WithTx(tx)
+
+	organization, err := app.organizationService.CreateOrganization(ctx, qtx, cmd.ToDomain())
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to create new organization")
+		return nil, err
+	}
+
+	err = app.organizationService.AddOrganizationMember(ctx, qtx, organization.ID, cmd.OwnerID)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to add organization owner")
+		return nil, err
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to commit transaction")
+		return nil, err
+	}
+
+	return organization, nil
+}
+
+func (app *organizationApplicationService) UpdateOrganization(ctx context.Context, cmd commands.UpdateOrganizationCommand) (*entities.OrganizationEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Updating organization")
+
+	organization, err := app.organizationService.UpdateOrganization(ctx, app.queries, cmd.ToDomain())
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to update organization")
+		return nil, err
+	}
+
+	return organization, nil
+}
+
+func (app *organizationApplicationService) GetOrganizationMembers(ctx context.Context, query queries.OrganizationMembersQuery) ([]*entities.UserEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Getting organization members")
+
+	members, err := app.organizationService.GetOrganizationMembers(ctx, app.queries, query.OrganizationID)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to get organization members")
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (app *organizationApplicationService) AddOrganizationMember(ctx context.Context, cmd commands.AddOrganizationMemberCommand) error {
+	app.logger.Info().Ctx(ctx).Msg("Adding organization member")
+
+	err := app.organizationService.AddOrganizationMember(ctx, app.queries, cmd.OrganizationID, cmd.UserID)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to add organization member")
+		return err
+	}
+
+	return nil
+}
+
+func (app *organizationApplicationService) RemoveOrganizationMember(ctx context.Context, cmd commands.RemoveOrganizationMemberCommand) error {
+	app.logger.Info().Ctx(ctx).Msg("Removing organization member")
+
+	err := app.organizationService.RemoveOrganizationMember(ctx, app.queries, cmd.OrganizationID, cmd.UserID)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to remove organization member")
+		return err
+	}
+
+	return nil
+}
+
+func (app *organizationApplicationService) GetVenueByID(ctx context.Context, query queries.VenueByIDQuery) (*entities.VenueEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Getting venue by ID")
+
+	venue, err := app.organizationService.GetVenueByID(ctx, app.queries, query.ID)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to get venue by ID")
+		return nil, err
+	}
+
+	return venue, nil
+}
+
+func (app *organizationApplicationService) GetVenuesByOrganization(ctx context.Context, query queries.VenuesByOrganizationQuery) ([]*entities.VenueEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Getting venues by organization")
+
+	venues, err := app.organizationService.GetVenuesByOrganizationID(ctx, app.queries, query.OrganizationID)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to get venues by organization")
+		return nil, err
+	}
+
+	return venues, nil
+}
+
+func (app *organizationApplicationService) CreateVenue(ctx context.Context, cmd commands.CreateVenueCommand) (*entities.VenueEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Creating new venue")
+
+	venue, err := app.organizationService.CreateVenue(ctx, app.queries, cmd.ToDomain())
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to create new venue")
+		return nil, err
+	}
+
+	return venue, nil
+}
+
+func (app *organizationApplicationService) UpdateVenue(ctx context.Context, cmd commands.UpdateVenueCommand) (*entities.VenueEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Updating venue")
+
+	venue, err := app.organizationService.UpdateVenue(ctx, app.queries, cmd.ToDomain())
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to update venue")
+		return nil, err
+	}
+
+	return venue, nil
+}
+
+func (app *organizationApplicationService) DeleteVenue(ctx context.Context, cmd commands.DeleteVenueCommand) error {
+	app.logger.Info().Ctx(ctx).Msg("Deleting venue")
+
+	err := app.organizationService.DeleteVenue(ctx, app.queries, cmd.ID)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to delete venue")
+		return err
+	}
+
+	return nil
+}
+
+func (app *organizationApplicationService) GetEventTypeWindows(ctx context.Context, query queries.EventTypeWindowsQuery) ([]*entities.EventTypeWindowEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Getting event type windows")
+
+	windows, err := app.organizationService.GetEventTypeWindows(ctx, app.queries, query.OrganizationID)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to get event type windows")
+		return nil, err
+	}
+
+	return windows, nil
+}
+
+func (app *organizationApplicationService) SetEventTypeWindow(ctx context.Context, cmd commands.SetEventTypeWindowCommand) (*entities.EventTypeWindowEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Setting event type window")
+
+	window, err := app.organizationService.SetEventTypeWindow(ctx, app.queries, cmd.ToDomain())
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to set event type window")
+		return nil, err
+	}
+
+	return window, nil
+}
+
+func (app *organizationApplicationService) DeleteEventTypeWindow(ctx context.Context, cmd commands.DeleteEventTypeWindowCommand) error {
+	app.logger.Info().Ctx(ctx).Msg("Deleting event type window")
+
+	err := app.organizationService.DeleteEventTypeWindow(ctx, app.queries, cmd.OrganizationID, cmd.EventType)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to delete event type window")
+		return err
+	}
+
+	return nil
+}
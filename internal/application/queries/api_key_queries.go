@@ -0,0 +1,9 @@
+package queries
+
+import (
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+)
+
+type APIKeysQuery struct {
+	Issuer *entities.APIKeyEntity
+}
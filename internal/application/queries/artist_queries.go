@@ -9,5 +9,10 @@ type ArtistByIDQuery struct {
 }
 
 type ArtistsByTitleQuery struct {
-	Title string
+	OrganizationID uuid.UUID
+	Title          string
+}
+
+type ArtistsQuery struct {
+	OrganizationID uuid.UUID
 }
@@ -8,6 +8,11 @@ type EventByIDQuery struct {
 	ID uuid.UUID
 }
 
-type CurrentEventQuery struct{}
+type CurrentEventQuery struct {
+	OrganizationID uuid.UUID
+	VenueID        *uuid.UUID
+}
 
-type EventsQuery struct{}
+type EventsQuery struct {
+	OrganizationID uuid.UUID
+}
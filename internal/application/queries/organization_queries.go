@@ -7,3 +7,23 @@ import (
 type OrganizationByIDQuery struct {
 	ID uuid.UUID
 }
+
+type OrganizationsByUserQuery struct {
+	UserID uuid.UUID
+}
+
+type OrganizationMembersQuery struct {
+	OrganizationID uuid.UUID
+}
+
+type VenueByIDQuery struct {
+	ID uuid.UUID
+}
+
+type VenuesByOrganizationQuery struct {
+	OrganizationID uuid.UUID
+}
+
+type EventTypeWindowsQuery struct {
+	OrganizationID uuid.UUID
+}
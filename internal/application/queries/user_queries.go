@@ -19,3 +19,11 @@ type UserByHandleQuery struct {
 type UserBySessionTokenQuery struct {
 	SessionToken string
 }
+
+type SessionsByUserQuery struct {
+	UserID uuid.UUID
+}
+
+type IdentitiesByUserQuery struct {
+	UserID uuid.UUID
+}
@@ -23,12 +23,19 @@ type UserApplicationService interface {
 	GetUserByEmail(ctx context.Context, query queries.UserByEmailQuery) (*entities.UserEntity, error)
 	GetUserByHandle(ctx context.Context, query queries.UserByHandleQuery) (*entities.UserEntity, error)
 	GetUserBySessionToken(ctx context.Context, query queries.UserBySessionTokenQuery) (*entities.UserEntity, error)
-	CreateUser(ctx context.Context, cmd commands.CreateNewUserCommand) (*entities.UserContextEntity, error)
+	CreateUser(ctx context.Context, cmd commands.CreateNewUserCommand) (*entities.UserEntity, error)
 	UpdateUser(ctx context.Context, cmd commands.UpdateUserCommand) (*entities.UserEntity, error)
 	RequestEmailLogin(ctx context.Context, cmd commands.RequestEmailLoginCommand) error
 	LoginWithReferenceLink(ctx context.Context, cmd commands.LoginWithReferenceLinkCommand) (*entities.UserContextEntity, error)
 	InviteUser(ctx context.Context, cmd commands.InviteUserCommand) error
 	AcceptInviteReferenceLink(ctx context.Context, cmd commands.AcceptInviteReferenceLinkCommand) (*entities.UserContextEntity, error)
+	SetUserRole(ctx context.Context, cmd commands.SetUserRoleCommand) (*entities.UserEntity, error)
+	GetActiveSessions(ctx context.Context, query queries.SessionsByUserQuery) ([]*entities.SessionEntity, error)
+	Logout(ctx context.Context, cmd commands.LogoutCommand) error
+	LogoutEverywhere(ctx context.Context, cmd commands.LogoutEverywhereCommand) error
+	RevokeSession(ctx context.Context, cmd commands.RevokeSessionCommand) error
+	StartImpersonation(ctx context.Context, cmd commands.StartImpersonationCommand) (*entities.UserContextEntity, error)
+	EndImpersonation(ctx context.Context, cmd commands.EndImpersonationCommand) error
 }
 
 type userApplicationService struct {
@@ -104,40 +111,16 @@ func (app *userApplicationService) GetUserBySessionToken(ctx context.Context, qu
 	return userContext.User, nil
 }
 
-func (app *userApplicationService) CreateUser(ctx context.Context, cmd commands.CreateNewUserCommand) (*entities.UserContextEntity, error) {
-	tx, cancel, err := postgres.CreateTransaction(ctx, app.db)
-	defer cancel()
-	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to create transaction")
-		return nil, err
-	}
-	defer tx.Rollback(ctx)
-
-	qtx := models.New(app.db).WithTx(tx)
-
+func (app *userApplicationService) CreateUser(ctx context.Context, cmd commands.CreateNewUserCommand) (*entities.UserEntity, error) {
 	app.logger.Info().Ctx(ctx).Msg("Creating new user")
 
-	userEntity := cmd.ToDomain()
-
-	createdUser, err := app.userService.CreateUser(ctx, qtx, userEntity)
+	createdUser, err := app.userService.CreateUser(ctx, app.queries, cmd.ToDomain())
 	if err != nil {
 		app.logger.Err(err).Ctx(ctx).Msg("Failed to create new user")
 		return nil, err
 	}
 
-	userSession, err := app.userService.CreateSession(ctx, qtx, createdUser)
-	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to create new session")
-		return nil, err
-	}
-
-	err = tx.Commit(ctx)
-	if err != nil {
-		app.logger.Err(err).Ctx(ctx).Msg("Failed to commit transaction")
-		return nil, err
-	}
-
-	return userSession, nil
+	return createdUser, nil
 }
 
 func (app *userApplicationService) UpdateUser(ctx context.Context, cmd commands.UpdateUserCommand) (*entities.UserEntity, error) {
@@ -153,7 +136,19 @@ func (app *userApplicationService) UpdateUser(ctx context.Context, cmd commands.
 
 	app.logger.Info().Ctx(ctx).Msg("Updating user")
 
+	currentUser, err := app.userService.GetUserByID(ctx, qtx, cmd.ID)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to get user by ID")
+		return nil, err
+	}
+
+	err = entities.CheckVersion(currentUser.Version, cmd.ExpectedVersion)
+	if err != nil {
+		return nil, err
+	}
+
 	userEntity := cmd.ToDomain()
+	userEntity.Version = currentUser.Version
 
 	updatedUser, err := app.userService.UpdateUser(ctx, qtx, userEntity)
 	if err != nil {
@@ -334,3 +329,87 @@ func (app *userApplicationService) AcceptInviteReferenceLink(ctx context.Context
 
 	return userSession, nil
 }
+
+func (app *userApplicationService) SetUserRole(ctx context.Context, cmd commands.SetUserRoleCommand) (*entities.UserEntity, error) {
+	app.logger.Info().Ctx(ctx).Str("user_id", cmd.ID.String()).Str("role", string(cmd.Role)).Msg("Setting user role")
+
+	user, err := app.userService.SetUserRole(ctx, app.queries, cmd.ID, cmd.Role)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to set user role")
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (app *userApplicationService) GetActiveSessions(ctx context.Context, query queries.SessionsByUserQuery) ([]*entities.SessionEntity, error) {
+	app.logger.Info().Ctx(ctx).Msg("Getting active sessions")
+
+	sessions, err := app.userService.GetActiveSessions(ctx, app.queries, query.UserID)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to get active sessions")
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+func (app *userApplicationService) Logout(ctx context.Context, cmd commands.LogoutCommand) error {
+	app.logger.Info().Ctx(ctx).Msg("Logging out")
+
+	err := app.userService.RevokeSession(ctx, app.queries, cmd.UserID, cmd.SessionID)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to log out")
+		return err
+	}
+
+	return nil
+}
+
+func (app *userApplicationService) LogoutEverywhere(ctx context.Context, cmd commands.LogoutEverywhereCommand) error {
+	app.logger.Info().Ctx(ctx).Msg("Logging out everywhere")
+
+	err := app.userService.RevokeAllSessions(ctx, app.queries, cmd.UserID)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to log out everywhere")
+		return err
+	}
+
+	return nil
+}
+
+func (app *userApplicationService) RevokeSession(ctx context.Context, cmd commands.RevokeSessionCommand) error {
+	app.logger.Info().Ctx(ctx).Msg("Revoking session")
+
+	err := app.userService.RevokeSession(ctx, app.queries, cmd.UserID, cmd.SessionID)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to revoke session")
+		return err
+	}
+
+	return nil
+}
+
+func (app *userApplicationService) StartImpersonation(ctx context.Context, cmd commands.StartImpersonationCommand) (*entities.UserContextEntity, error) {
+	app.logger.Warn().Ctx(ctx).Str("impersonator_id", cmd.ImpersonatorID.String()).Str("impersonated_user_id", cmd.UserID.String()).Msg("Starting impersonation")
+
+	userSession, err := app.userService.StartImpersonation(ctx, app.queries, cmd.ImpersonatorID, cmd.UserID)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to start impersonation")
+		return nil, err
+	}
+
+	return userSession, nil
+}
+
+func (app *userApplicationService) EndImpersonation(ctx context.Context, cmd commands.EndImpersonationCommand) error {
+	app.logger.Warn().Ctx(ctx).Msg("Ending impersonation")
+
+	err := app.userService.EndImpersonation(ctx, app.queries, cmd.UserContext)
+	if err != nil {
+		app.logger.Err(err).Ctx(ctx).Msg("Failed to end impersonation")
+		return err
+	}
+
+	return nil
+}
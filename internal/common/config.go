@@ -16,6 +16,7 @@ type Config struct {
 		Logging bool
 	}
 	CientURL    string
+	MessageBus  string
 	ServerToken string
 	Cors        struct {
 		TrustedOrigins []string
@@ -30,6 +31,17 @@ type Config struct {
 		SMTPUsername string
 		SMTPPassword string
 	}
+	OIDC struct {
+		RedirectURL string
+		Providers   []OIDCProviderConfig
+	}
+}
+
+type OIDCProviderConfig struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
 }
 
 func LoadConfig(cfg *Config) {
@@ -78,6 +90,18 @@ func LoadConfig(cfg *Config) {
 
 	cfg.DB.DSN = postgres_url
 
+	// Load MESSAGE_BUS, "memory" for a single instance or "postgres" to fan out
+	// across instances with LISTEN/NOTIFY
+	message_bus := os.Getenv("MESSAGE_BUS")
+	switch message_bus {
+	case "":
+		cfg.MessageBus = "memory"
+	case "memory", "postgres":
+		cfg.MessageBus = message_bus
+	default:
+		log.Fatalf("MESSAGE_BUS must be \"memory\" or \"postgres\", got %q", message_bus)
+	}
+
 	cfg.Cors.TrustedOrigins = []string{"http://localhost:3000", "https://openmicmpls.com", "https://www.openmicmpls.com", "openmicmpls.com", "test.openmicmpls.com", "https://test.openmicmpls.com"}
 
 	endpoint := os.Getenv("MINIO_ENDPOINT")
@@ -115,4 +139,36 @@ func LoadConfig(cfg *Config) {
 		log.Fatalf("SMTP_PASSWORD not available in .env")
 	}
 	cfg.Mail.SMTPPassword = smtp_password
+
+	// Load OIDC providers, all optional. Google is configured by client ID
+	// alone; any other standards-compliant issuer through OIDC_ISSUER.
+	oidc_redirect_url := os.Getenv("OIDC_REDIRECT_URL")
+	if oidc_redirect_url == "" {
+		oidc_redirect_url = cfg.CientURL + "/auth/callback"
+	}
+	cfg.OIDC.RedirectURL = oidc_redirect_url
+
+	google_client_id := os.Getenv("GOOGLE_CLIENT_ID")
+	if google_client_id != "" {
+		cfg.OIDC.Providers = append(cfg.OIDC.Providers, OIDCProviderConfig{
+			Name:         "google",
+			Issuer:       "https://accounts.google.com",
+			ClientID:     google_client_id,
+			ClientSecret: os.Getenv("GOOGLE_CLIENT_SECRET"),
+		})
+	}
+
+	oidc_issuer := os.Getenv("OIDC_ISSUER")
+	if oidc_issuer != "" {
+		oidc_provider_name := os.Getenv("OIDC_PROVIDER_NAME")
+		if oidc_provider_name == "" {
+			oidc_provider_name = "oidc"
+		}
+		cfg.OIDC.Providers = append(cfg.OIDC.Providers, OIDCProviderConfig{
+			Name:         oidc_provider_name,
+			Issuer:       oidc_issuer,
+			ClientID:     os.Getenv("OIDC_CLIENT_ID"),
+			ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+		})
+	}
 }
@@ -0,0 +1,70 @@
+package common
+
+import (
+	"sync"
+	"time"
+)
+
+// RateLimiter keeps an in-memory token bucket per key. Each bucket holds up to
+// a minute's worth of requests and refills continuously, so short bursts are
+// allowed while the average stays under the limit. Buckets are per process.
+type RateLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	now     func() time.Time
+}
+
+type bucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{buckets: make(map[string]*bucket), now: time.Now}
+}
+
+// Allow takes a token from key's bucket. When the bucket is empty it returns
+// false and how long until the next token is available.
+func (l *RateLimiter) Allow(key string, perMinute int) (bool, time.Duration) {
+	if perMinute <= 0 {
+		return true, 0
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	capacity := float64(perMinute)
+	ratePerSecond := capacity / 60
+
+	b, ok := l.buckets[key]
+	if !ok {
+		l.evict(now)
+		b = &bucket{tokens: capacity, lastSeen: now}
+		l.buckets[key] = b
+	}
+
+	b.tokens += now.Sub(b.lastSeen).Seconds() * ratePerSecond
+	if b.tokens > capacity {
+		b.tokens = capacity
+	}
+	b.lastSeen = now
+
+	if b.tokens < 1 {
+		wait := time.Duration((1 - b.tokens) / ratePerSecond * float64(time.Second))
+		return false, wait
+	}
+
+	b.tokens--
+	return true, 0
+}
+
+// evict drops buckets idle long enough to have refilled completely; they
+// behave the same as a new bucket.
+func (l *RateLimiter) evict(now time.Time) {
+	for key, b := range l.buckets {
+		if now.Sub(b.lastSeen) > time.Minute {
+			delete(l.buckets, key)
+		}
+	}
+}
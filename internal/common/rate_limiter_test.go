@@ -0,0 +1,45 @@
+package common
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter(t *testing.T) {
+	now := time.Now()
+	limiter := NewRateLimiter()
+	limiter.now = func() time.Time { return now }
+
+	t.Run("allows a minute's burst then refuses", func(t *testing.T) {
+		for i := 0; i < 60; i++ {
+			allowed, _ := limiter.Allow("key-1", 60)
+			assert.True(t, allowed)
+		}
+
+		allowed, retryAfter := limiter.Allow("key-1", 60)
+		assert.False(t, allowed)
+		assert.Equal(t, time.Second, retryAfter)
+	})
+
+	t.Run("refills over time", func(t *testing.T) {
+		now = now.Add(time.Second)
+
+		allowed, _ := limiter.Allow("key-1", 60)
+		assert.True(t, allowed)
+
+		allowed, _ = limiter.Allow("key-1", 60)
+		assert.False(t, allowed)
+	})
+
+	t.Run("keys have separate buckets", func(t *testing.T) {
+		allowed, _ := limiter.Allow("key-2", 60)
+		assert.True(t, allowed)
+	})
+
+	t.Run("no limit", func(t *testing.T) {
+		allowed, _ := limiter.Allow("key-1", 0)
+		assert.True(t, allowed)
+	})
+}
@@ -0,0 +1,23 @@
+package common
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/rs/zerolog"
+)
+
+// LogPanic logs a recovered panic value with the stack of the goroutine that
+// panicked. Call it from the deferred function that recovered.
+func LogPanic(ctx context.Context, logger *zerolog.Logger, recovered any, msg string) {
+	logger.Error().Ctx(ctx).Interface("panic", recovered).Str("stack", string(debug.Stack())).Msg(msg)
+}
+
+// RecoverPanic stops a panic and logs it. It only works when deferred
+// directly, as in defer common.RecoverPanic(ctx, logger, msg), and is meant
+// for goroutines where a panic would otherwise end the process.
+func RecoverPanic(ctx context.Context, logger *zerolog.Logger, msg string) {
+	if recovered := recover(); recovered != nil {
+		LogPanic(ctx, logger, recovered, msg)
+	}
+}
@@ -0,0 +1,29 @@
+package common
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecoverPanic(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer RecoverPanic(context.Background(), &logger, "Recovered from panic in worker")
+
+		var m map[string]int
+		m["boom"] = 1
+	}()
+	<-done
+
+	assert.Contains(t, buf.String(), "Recovered from panic in worker")
+	assert.Contains(t, buf.String(), "assignment to entry in nil map")
+	assert.Contains(t, buf.String(), "TestRecoverPanic")
+}
@@ -0,0 +1,173 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+)
+
+var (
+	ErrAPIKeyNotFound     = NewNotFoundError("api key not found")
+	ErrInvalidAPIKeyScope = NewValidationError("invalid api key scope")
+	ErrGlobalAPIKeyScope  = NewValidationError("api key scope can only be granted to keys without an organization")
+	ErrAPIKeyNotAllowed   = NewForbiddenError("api key not allowed")
+)
+
+// APIKeyScope grants a machine client a group of operations. Unlike roles,
+// scopes do not imply one another.
+type APIKeyScope string
+
+const (
+	ScopeEventsWrite APIKeyScope = "events:write"
+	ScopeKeysManage  APIKeyScope = "keys:manage"
+	// ScopeUsersManage creates accounts and assigns roles. Roles are not
+	// tied to an organization, so only global keys may hold it.
+	ScopeUsersManage APIKeyScope = "users:manage"
+)
+
+var apiKeyScopes = map[APIKeyScope]bool{
+	ScopeEventsWrite: true,
+	ScopeKeysManage:  true,
+	ScopeUsersManage: true,
+}
+
+var globalAPIKeyScopes = map[APIKeyScope]bool{
+	ScopeUsersManage: true,
+}
+
+func ParseAPIKeyScope(value string) (APIKeyScope, error) {
+	scope := APIKeyScope(value)
+	if !apiKeyScopes[scope] {
+		return "", ErrInvalidAPIKeyScope
+	}
+
+	return scope, nil
+}
+
+// DefaultAPIKeyRateLimit is the requests per minute a key gets when none is
+// given.
+const DefaultAPIKeyRateLimit = 120
+
+// APIKeyEntity is a named machine credential. Key is only known when the key
+// is created or rotated; storage keeps KeyHash. A nil OrganizationID lets the
+// key act on every organization.
+type APIKeyEntity struct {
+	ID                 uuid.UUID
+	Name               string
+	Key                string
+	KeyHash            string
+	Scopes             []APIKeyScope
+	OrganizationID     *uuid.UUID
+	RateLimitPerMinute int
+	CreatedBy          *uuid.UUID
+	LastUsedAt         *time.Time
+	RevokedAt          *time.Time
+	CreatedAt          time.Time
+}
+
+func NewAPIKeyEntity(apiKeyModel models.ApiKey) *APIKeyEntity {
+	scopes := make([]APIKeyScope, 0, len(apiKeyModel.Scopes))
+	for _, scope := range apiKeyModel.Scopes {
+		scopes = append(scopes, APIKeyScope(scope))
+	}
+
+	return &APIKeyEntity{
+		ID:                 apiKeyModel.ID,
+		Name:               apiKeyModel.KeyName,
+		KeyHash:            apiKeyModel.KeyHash,
+		Scopes:             scopes,
+		OrganizationID:     apiKeyModel.OrganizationID,
+		RateLimitPerMinute: int(apiKeyModel.RateLimitPerMinute),
+		CreatedBy:          apiKeyModel.CreatedBy,
+		LastUsedAt:         apiKeyModel.LastUsedAt,
+		RevokedAt:          apiKeyModel.RevokedAt,
+		CreatedAt:          apiKeyModel.CreatedAt,
+	}
+}
+
+// NewBootstrapAPIKeyEntity is the key configured through SERVER_TOKEN. It is
+// never stored and holds every scope, so it can issue the other keys.
+func NewBootstrapAPIKeyEntity(token string) *APIKeyEntity {
+	scopes := make([]APIKeyScope, 0, len(apiKeyScopes))
+	for scope := range apiKeyScopes {
+		scopes = append(scopes, scope)
+	}
+
+	return &APIKeyEntity{
+		ID:                 uuid.Nil,
+		Name:               "bootstrap",
+		KeyHash:            HashToken(token),
+		Scopes:             scopes,
+		RateLimitPerMinute: DefaultAPIKeyRateLimit * 5,
+	}
+}
+
+func (k *APIKeyEntity) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+func (k *APIKeyEntity) HasScope(scope APIKeyScope) bool {
+	if k == nil || k.IsRevoked() {
+		return false
+	}
+
+	for _, s := range k.Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks the scopes are known and that organization-bound keys hold
+// none of the global-only ones.
+func (k *APIKeyEntity) Validate() error {
+	if len(k.Scopes) == 0 {
+		return ErrInvalidAPIKeyScope
+	}
+	for _, scope := range k.Scopes {
+		_, err := ParseAPIKeyScope(string(scope))
+		if err != nil {
+			return err
+		}
+		if k.OrganizationID != nil && globalAPIKeyScopes[scope] {
+			return ErrGlobalAPIKeyScope
+		}
+	}
+
+	return nil
+}
+
+// CanManage reports whether k may see, rotate or revoke other. A key bound to
+// an organization only manages keys bound to the same one.
+func (k *APIKeyEntity) CanManage(other *APIKeyEntity) bool {
+	if k == nil || k.IsRevoked() {
+		return false
+	}
+
+	return k.OrganizationID == nil || (other.OrganizationID != nil && *other.OrganizationID == *k.OrganizationID)
+}
+
+// CanGrant reports whether k may issue other, which must be a key k manages
+// holding no scope k lacks.
+func (k *APIKeyEntity) CanGrant(other *APIKeyEntity) bool {
+	if !k.CanManage(other) {
+		return false
+	}
+
+	for _, scope := range other.Scopes {
+		if !k.HasScope(scope) {
+			return false
+		}
+	}
+	return true
+}
+
+func (k *APIKeyEntity) CanAccessOrganization(organizationID uuid.UUID) bool {
+	if k == nil || k.IsRevoked() {
+		return false
+	}
+
+	return k.OrganizationID == nil || *k.OrganizationID == organizationID
+}
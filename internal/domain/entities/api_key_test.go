@@ -0,0 +1,98 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyEntity(t *testing.T) {
+	organizationID := uuid.New()
+
+	t.Run("create entity from model", func(t *testing.T) {
+		apiKeyModel := models.ApiKey{
+			ID:                 uuid.New(),
+			KeyName:            "display box",
+			KeyHash:            HashToken("key"),
+			Scopes:             []string{"events:write"},
+			OrganizationID:     &organizationID,
+			RateLimitPerMinute: 60,
+		}
+
+		apiKey := NewAPIKeyEntity(apiKeyModel)
+
+		assert.Equal(t, apiKeyModel.ID, apiKey.ID)
+		assert.Equal(t, "display box", apiKey.Name)
+		assert.Equal(t, []APIKeyScope{ScopeEventsWrite}, apiKey.Scopes)
+		assert.Equal(t, 60, apiKey.RateLimitPerMinute)
+		assert.Empty(t, apiKey.Key)
+	})
+
+	t.Run("scopes are checked exactly", func(t *testing.T) {
+		apiKey := &APIKeyEntity{Scopes: []APIKeyScope{ScopeEventsWrite}}
+
+		assert.True(t, apiKey.HasScope(ScopeEventsWrite))
+		assert.False(t, apiKey.HasScope(ScopeKeysManage))
+	})
+
+	t.Run("revoked key has no scopes", func(t *testing.T) {
+		revokedAt := time.Now()
+		apiKey := &APIKeyEntity{Scopes: []APIKeyScope{ScopeEventsWrite}, RevokedAt: &revokedAt}
+
+		assert.False(t, apiKey.HasScope(ScopeEventsWrite))
+		assert.False(t, apiKey.CanAccessOrganization(organizationID))
+	})
+
+	t.Run("organization restriction", func(t *testing.T) {
+		scoped := &APIKeyEntity{OrganizationID: &organizationID}
+		global := &APIKeyEntity{}
+
+		assert.True(t, scoped.CanAccessOrganization(organizationID))
+		assert.False(t, scoped.CanAccessOrganization(uuid.New()))
+		assert.True(t, global.CanAccessOrganization(uuid.New()))
+	})
+
+	t.Run("organization keys manage and grant within their organization", func(t *testing.T) {
+		scoped := &APIKeyEntity{Scopes: []APIKeyScope{ScopeKeysManage, ScopeEventsWrite}, OrganizationID: &organizationID}
+		otherOrganizationID := uuid.New()
+
+		assert.True(t, scoped.CanManage(&APIKeyEntity{OrganizationID: &organizationID}))
+		assert.False(t, scoped.CanManage(&APIKeyEntity{OrganizationID: &otherOrganizationID}))
+		assert.False(t, scoped.CanManage(&APIKeyEntity{}))
+		assert.True(t, (&APIKeyEntity{}).CanManage(scoped))
+
+		assert.True(t, scoped.CanGrant(&APIKeyEntity{Scopes: []APIKeyScope{ScopeEventsWrite}, OrganizationID: &organizationID}))
+		assert.False(t, scoped.CanGrant(&APIKeyEntity{Scopes: []APIKeyScope{ScopeUsersManage}, OrganizationID: &organizationID}))
+		assert.False(t, scoped.CanGrant(&APIKeyEntity{Scopes: []APIKeyScope{ScopeEventsWrite}}))
+	})
+
+	t.Run("bootstrap key holds every scope", func(t *testing.T) {
+		apiKey := NewBootstrapAPIKeyEntity("server-token")
+
+		assert.True(t, apiKey.HasScope(ScopeEventsWrite))
+		assert.True(t, apiKey.HasScope(ScopeKeysManage))
+		assert.True(t, apiKey.HasScope(ScopeUsersManage))
+		assert.Equal(t, HashToken("server-token"), apiKey.KeyHash)
+		assert.Nil(t, apiKey.OrganizationID)
+	})
+
+	t.Run("parse scope", func(t *testing.T) {
+		scope, err := ParseAPIKeyScope("events:write")
+		assert.NoError(t, err)
+		assert.Equal(t, ScopeEventsWrite, scope)
+
+		_, err = ParseAPIKeyScope("everything")
+		assert.ErrorIs(t, err, ErrInvalidAPIKeyScope)
+	})
+
+	t.Run("validate", func(t *testing.T) {
+		assert.NoError(t, (&APIKeyEntity{Scopes: []APIKeyScope{ScopeUsersManage}}).Validate())
+		assert.NoError(t, (&APIKeyEntity{Scopes: []APIKeyScope{ScopeEventsWrite}, OrganizationID: &organizationID}).Validate())
+		assert.ErrorIs(t, (&APIKeyEntity{}).Validate(), ErrInvalidAPIKeyScope)
+		assert.ErrorIs(t, (&APIKeyEntity{Scopes: []APIKeyScope{"everything"}}).Validate(), ErrInvalidAPIKeyScope)
+		assert.ErrorIs(t, (&APIKeyEntity{Scopes: []APIKeyScope{ScopeUsersManage}, OrganizationID: &organizationID}).Validate(), ErrGlobalAPIKeyScope)
+	})
+}
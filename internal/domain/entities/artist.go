@@ -1,22 +1,51 @@
 package entities
 
 import (
+	"strings"
+
 	"github.com/google/uuid"
 	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
 )
 
+var (
+	ErrArtistNotFound      = NewNotFoundError("artist not found")
+	ErrArtistTitleRequired = NewValidationError("artist title is required")
+)
+
 type ArtistEntity struct {
-	ID       uuid.UUID
-	Title    string
-	SubTitle *string
-	Bio      *string
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Title          string
+	SubTitle       *string
+	Bio            *string
+	UserID         *uuid.UUID
+	Version        int32
 }
 
 func NewArtistEntity(artistModel models.Artist) *ArtistEntity {
 	return &ArtistEntity{
-		ID:       artistModel.ID,
-		Title:    artistModel.ArtistTitle,
-		SubTitle: artistModel.ArtistSubtitle,
-		Bio:      artistModel.Bio,
+		ID:             artistModel.ID,
+		OrganizationID: artistModel.OrganizationID,
+		Title:          artistModel.ArtistTitle,
+		SubTitle:       artistModel.ArtistSubtitle,
+		Bio:            artistModel.Bio,
+		UserID:         artistModel.UserID,
+		Version:        artistModel.Version,
 	}
 }
+
+// FindArtistByTitle returns the candidate whose title matches title once case
+// and spacing are ignored, or nil if none does.
+func FindArtistByTitle(candidates []*ArtistEntity, title string) *ArtistEntity {
+	for _, candidate := range candidates {
+		if normalizeArtistTitle(candidate.Title) == normalizeArtistTitle(title) {
+			return candidate
+		}
+	}
+
+	return nil
+}
+
+func normalizeArtistTitle(title string) string {
+	return strings.ToLower(strings.Join(strings.Fields(title), " "))
+}
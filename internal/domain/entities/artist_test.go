@@ -0,0 +1,29 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindArtistByTitle(t *testing.T) {
+
+	jane := &ArtistEntity{ID: uuid.New(), Title: "Jane  Doe"}
+	janet := &ArtistEntity{ID: uuid.New(), Title: "Janet Doe"}
+	candidates := []*ArtistEntity{janet, jane}
+
+	t.Run("ignores case and spacing", func(t *testing.T) {
+		assert.Equal(t, jane, FindArtistByTitle(candidates, " jane doe"))
+	})
+
+	t.Run("does not reuse a merely similar artist", func(t *testing.T) {
+		assert.Nil(t, FindArtistByTitle(candidates, "Jane Does"))
+	})
+
+	t.Run("walk-in candidates leave out the chosen artist", func(t *testing.T) {
+		walkIn := NewWalkInEntity(jane, false, candidates)
+
+		assert.Equal(t, []*ArtistEntity{janet}, walkIn.Candidates)
+	})
+}
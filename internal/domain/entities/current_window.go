@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+)
+
+var (
+	ErrInvalidCurrentWindow   = NewValidationError("current event window minutes must not be negative")
+	ErrEventTypeWindowMissing = NewNotFoundError("event type has no current event window")
+)
+
+// DefaultCurrentEventType is the only event type that counts as current
+// without a window from its venue or its organization.
+const DefaultCurrentEventType = "OPEN_MIC"
+
+// CurrentEventWindow is how long before and after its start an event counts
+// as the current one.
+type CurrentEventWindow struct {
+	MinutesBefore int32
+	MinutesAfter  int32
+}
+
+func DefaultCurrentEventWindow() CurrentEventWindow {
+	return CurrentEventWindow{
+		MinutesBefore: 30 * 60,
+		MinutesAfter:  8 * 60,
+	}
+}
+
+func (w CurrentEventWindow) Validate() error {
+	if w.MinutesBefore < 0 || w.MinutesAfter < 0 {
+		return ErrInvalidCurrentWindow
+	}
+	return nil
+}
+
+func (w CurrentEventWindow) Contains(startTime time.Time, now time.Time) bool {
+	opens := startTime.Add(-time.Duration(w.MinutesBefore) * time.Minute)
+	closes := startTime.Add(time.Duration(w.MinutesAfter) * time.Minute)
+
+	return !now.Before(opens) && !now.After(closes)
+}
+
+// EventTypeWindowEntity is an organization's current event window for one
+// event type. Venues with a window of their own use theirs instead.
+type EventTypeWindowEntity struct {
+	OrganizationID uuid.UUID
+	EventType      string
+	Window         CurrentEventWindow
+}
+
+func NewEventTypeWindowEntity(windowModel models.EventTypeWindow) *EventTypeWindowEntity {
+	return &EventTypeWindowEntity{
+		OrganizationID: windowModel.OrganizationID,
+		EventType:      windowModel.EventType,
+		Window: CurrentEventWindow{
+			MinutesBefore: windowModel.MinutesBefore,
+			MinutesAfter:  windowModel.MinutesAfter,
+		},
+	}
+}
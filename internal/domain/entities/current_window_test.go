@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurrentEventWindow(t *testing.T) {
+
+	startTime, err := time.Parse(time.RFC3339, "2025-01-01T20:00:00Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("default window runs from 30 hours before to 8 hours after", func(t *testing.T) {
+		window := DefaultCurrentEventWindow()
+
+		assert.True(t, window.Contains(startTime, startTime.Add(-30*time.Hour)))
+		assert.True(t, window.Contains(startTime, startTime.Add(8*time.Hour)))
+		assert.False(t, window.Contains(startTime, startTime.Add(-30*time.Hour-time.Minute)))
+		assert.False(t, window.Contains(startTime, startTime.Add(8*time.Hour+time.Minute)))
+	})
+
+	t.Run("a configured window replaces the default", func(t *testing.T) {
+		window := CurrentEventWindow{MinutesBefore: 60, MinutesAfter: 180}
+
+		assert.True(t, window.Contains(startTime, startTime.Add(-time.Hour)))
+		assert.False(t, window.Contains(startTime, startTime.Add(-2*time.Hour)))
+		assert.True(t, window.Contains(startTime, startTime.Add(3*time.Hour)))
+	})
+
+	t.Run("minutes must not be negative", func(t *testing.T) {
+		assert.NoError(t, CurrentEventWindow{}.Validate())
+		assert.ErrorIs(t, CurrentEventWindow{MinutesBefore: -1}.Validate(), ErrInvalidCurrentWindow)
+		assert.ErrorIs(t, CurrentEventWindow{MinutesAfter: -1}.Validate(), ErrInvalidCurrentWindow)
+	})
+
+	t.Run("events use the window they were loaded with", func(t *testing.T) {
+		event := NewEventEntity(models.Event{ID: uuid.New(), StartTime: time.Now().Add(2 * time.Hour)}, nil, nil)
+		assert.True(t, event.IsCurrent())
+
+		event.CurrentWindow = CurrentEventWindow{MinutesBefore: 60, MinutesAfter: 60}
+		assert.False(t, event.IsCurrent())
+	})
+}
@@ -0,0 +1,63 @@
+package entities
+
+import "errors"
+
+// ErrorKind classifies domain errors so the interfaces layer can pick a
+// response without knowing every sentinel.
+type ErrorKind int
+
+const (
+	KindInternal ErrorKind = iota
+	KindNotFound
+	KindConflict
+	KindValidation
+	KindForbidden
+	KindExpired
+	KindPreconditionFailed
+)
+
+// Error is a domain error of a known kind. Sentinels are compared with
+// errors.Is as before; KindOf reads the kind through any wrapping.
+type Error struct {
+	Kind    ErrorKind
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func NewNotFoundError(message string) error {
+	return &Error{Kind: KindNotFound, Message: message}
+}
+
+func NewConflictError(message string) error {
+	return &Error{Kind: KindConflict, Message: message}
+}
+
+func NewValidationError(message string) error {
+	return &Error{Kind: KindValidation, Message: message}
+}
+
+func NewForbiddenError(message string) error {
+	return &Error{Kind: KindForbidden, Message: message}
+}
+
+func NewExpiredError(message string) error {
+	return &Error{Kind: KindExpired, Message: message}
+}
+
+func NewPreconditionFailedError(message string) error {
+	return &Error{Kind: KindPreconditionFailed, Message: message}
+}
+
+// KindOf returns the kind of the first domain error in err's chain, or
+// KindInternal when there is none.
+func KindOf(err error) ErrorKind {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr.Kind
+	}
+
+	return KindInternal
+}
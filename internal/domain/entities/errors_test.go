@@ -0,0 +1,32 @@
+package entities
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKindOf(t *testing.T) {
+	t.Run("sentinel", func(t *testing.T) {
+		assert.Equal(t, KindNotFound, KindOf(ErrUserNotFound))
+		assert.Equal(t, KindConflict, KindOf(ErrEmailInUse))
+		assert.Equal(t, KindExpired, KindOf(ErrLinkExpired))
+		assert.Equal(t, KindForbidden, KindOf(ErrImpersonationNotAllowed))
+		assert.Equal(t, KindValidation, KindOf(ErrInvalidRole))
+		assert.Equal(t, KindPreconditionFailed, KindOf(ErrVersionMismatch))
+	})
+
+	t.Run("wrapped sentinel keeps its kind and identity", func(t *testing.T) {
+		err := fmt.Errorf("%w: id token has expired", ErrLoginRequestInvalid)
+
+		assert.Equal(t, KindValidation, KindOf(err))
+		assert.ErrorIs(t, err, ErrLoginRequestInvalid)
+	})
+
+	t.Run("other errors are internal", func(t *testing.T) {
+		assert.Equal(t, KindInternal, KindOf(errors.New("connection refused")))
+		assert.Equal(t, KindInternal, KindOf(nil))
+	})
+}
@@ -7,70 +7,211 @@ import (
 	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
 )
 
+var (
+	ErrEventNotFound      = NewNotFoundError("event not found")
+	ErrTimeslotNotFound   = NewNotFoundError("timeslot not found")
+	ErrInvalidSlotPolicy  = NewValidationError("invalid slot duration policy")
+	ErrSortKeyTaken       = NewConflictError("lineup position already taken")
+	ErrLineupMismatch     = NewValidationError("lineup must list every timeslot exactly once")
+	ErrDuplicateArtist    = NewConflictError("artist is already in the lineup")
+	ErrInvalidLineupItem  = NewValidationError("lineup items other than artist sets need a type, title and duration")
+	ErrInvalidMarker      = NewValidationError("time markers need a time and other markers must not have one")
+	ErrLineupEmpty        = NewValidationError("the lineup is empty")
+	ErrShowStarted        = NewConflictError("the show has already started")
+	ErrShowNotStarted     = NewConflictError("the show is not running")
+	ErrNoNextTimeslot     = NewConflictError("no timeslot follows the one playing")
+	ErrNoPreviousTimeslot = NewConflictError("no timeslot comes before the one playing")
+)
+
+// LineupItemType tells artist sets apart from the other things that take up
+// time in a show.
+type LineupItemType string
+
+const (
+	LineupItemArtist     LineupItemType = "ARTIST"
+	LineupItemBreak      LineupItemType = "BREAK"
+	LineupItemFeatureSet LineupItemType = "FEATURE_SET"
+	LineupItemHostBit    LineupItemType = "HOST_BIT"
+	LineupItemRaffle     LineupItemType = "RAFFLE"
+)
+
+func ParseLineupItemType(value string) (LineupItemType, error) {
+	switch itemType := LineupItemType(value); itemType {
+	case LineupItemArtist, LineupItemBreak, LineupItemFeatureSet, LineupItemHostBit, LineupItemRaffle:
+		return itemType, nil
+	}
+
+	return "", ErrInvalidLineupItem
+}
+
+// DefaultSongCount is how many songs a new timeslot is booked for.
+const DefaultSongCount int32 = 1
+
+// OnDeckCount is how many upcoming performers the venue screen shows.
+const OnDeckCount = 3
+
+// rebalanceSortKeyLength is how long a sort key may grow, after repeated moves
+// between the same neighbours, before the lineup's keys are rewritten evenly.
+const rebalanceSortKeyLength = 10
+
 type EventEntity struct {
-	ID        uuid.UUID
-	StartTime time.Time
-	EndTime   time.Time
-	EventType string
-	timeSlots []*TimeSlotEntity
-	markers   []*TimeMarkerEntity
+	ID                    uuid.UUID
+	VenueID               uuid.UUID
+	StartTime             time.Time
+	EndTime               time.Time
+	EventType             string
+	SlotPolicy            SlotDurationPolicy
+	AllowDuplicateArtists bool
+	CurrentWindow         CurrentEventWindow
+	ChangeSeq             int64
+	Version               int32
+	timeSlots             []*TimeSlotEntity
+	markers               []*TimeMarkerEntity
+	runLog                []*TimeSlotRunEntity
+	drift                 time.Duration
 }
 
+// TimeSlotEntity is one item of the lineup. Items other than artist sets have
+// no Artist; NameOverride holds their title and DurationOverrideMinutes their
+// length. TimeDisplay is when the timeslot went on, or is projected to go on
+// once the show is running; PlannedTime is when the lineup planned it.
 type TimeSlotEntity struct {
-	ID           uuid.UUID
-	NameOverride *string
-	SortKey      string
-	Artist       *ArtistEntity
-	SongCount    int32
-	TimeDisplay  time.Time
+	ID                      uuid.UUID
+	ItemType                LineupItemType
+	NameOverride            *string
+	SortKey                 string
+	Artist                  *ArtistEntity
+	SongCount               int32
+	DurationOverrideMinutes *int32
+	Notes                   *string
+	Duration                time.Duration
+	TimeDisplay             time.Time
+	PlannedTime             time.Time
+	StartedAt               *time.Time
+	EndedAt                 *time.Time
+	Version                 int32
+}
+
+// SlotDurationPolicy decides how much of the schedule each timeslot takes up.
+// A slot runs BaseMinutes plus MinutesPerSong for every song, unless the slot
+// carries its own override, and ChangeoverMinutes are left between slots.
+type SlotDurationPolicy struct {
+	MinutesPerSong    int32
+	BaseMinutes       int32
+	ChangeoverMinutes int32
 }
 
+// DefaultSlotDurationPolicy matches the original fixed schedule of five minutes
+// for a single song and eight minutes for two.
+func DefaultSlotDurationPolicy() SlotDurationPolicy {
+	return SlotDurationPolicy{
+		MinutesPerSong:    3,
+		BaseMinutes:       2,
+		ChangeoverMinutes: 0,
+	}
+}
+
+func (p SlotDurationPolicy) Validate() error {
+	if p.MinutesPerSong < 0 || p.BaseMinutes < 0 || p.ChangeoverMinutes < 0 {
+		return ErrInvalidSlotPolicy
+	}
+	if p.MinutesPerSong == 0 && p.BaseMinutes == 0 {
+		return ErrInvalidSlotPolicy
+	}
+	return nil
+}
+
+func (p SlotDurationPolicy) SlotDuration(songCount int32, overrideMinutes *int32) time.Duration {
+	if overrideMinutes != nil {
+		return time.Duration(*overrideMinutes) * time.Minute
+	}
+	return time.Duration(p.BaseMinutes+p.MinutesPerSong*songCount) * time.Minute
+}
+
+func (p SlotDurationPolicy) Changeover() time.Duration {
+	return time.Duration(p.ChangeoverMinutes) * time.Minute
+}
+
+// TimeMarkerType is the closed set of markers a timeslot can carry.
+type TimeMarkerType string
+
+const (
+	// TimeMarkerTime pins a clock time to its timeslot.
+	TimeMarkerTime TimeMarkerType = "TIME"
+	// TimeMarkerPlaying flags the timeslot on stage. An event has at most one.
+	TimeMarkerPlaying TimeMarkerType = "PLAYING"
+)
+
 type TimeMarkerEntity struct {
-	ID    uuid.UUID
-	Index int
-	Type  string
-	Time  string
+	ID         uuid.UUID
+	TimeSlotID uuid.UUID
+	Type       TimeMarkerType
+	Time       *time.Time
+}
+
+func (m *TimeMarkerEntity) Validate() error {
+	switch m.Type {
+	case TimeMarkerTime:
+		if m.Time == nil || m.Time.IsZero() {
+			return ErrInvalidMarker
+		}
+	case TimeMarkerPlaying:
+		if m.Time != nil {
+			return ErrInvalidMarker
+		}
+	default:
+		return ErrInvalidMarker
+	}
+
+	return nil
 }
 
 type NewEventEntitySlotsArgs struct {
 	TimeSlot models.Timeslot
-	Artist   models.Artist
+	Artist   *models.Artist
 }
 
 func NewEventEntity(eventModel models.Event, timeSlotArgs []*NewEventEntitySlotsArgs, timeMarkers []*models.TimeslotMarker) *EventEntity {
 
+	slotPolicy := SlotDurationPolicy{
+		MinutesPerSong:    eventModel.MinutesPerSong,
+		BaseMinutes:       eventModel.BaseSlotMinutes,
+		ChangeoverMinutes: eventModel.ChangeoverMinutes,
+	}
+
 	timeSlotAggregator := eventModel.StartTime
 	timeSlotEntities := make([]*TimeSlotEntity, 0)
 	for _, timeslotArg := range timeSlotArgs {
-		timeSlotEntities = append(timeSlotEntities, newTimeSlotEntity(timeslotArg.TimeSlot, timeslotArg.Artist, timeSlotAggregator))
-
-		if timeslotArg.TimeSlot.SongCount == 1 {
-			dur := time.Minute * 5
-			timeSlotAggregator = timeSlotAggregator.Add(dur)
-		} else {
-			dur := time.Minute * 8
-			timeSlotAggregator = timeSlotAggregator.Add(dur)
-		}
+		timeSlotEntity := newTimeSlotEntity(timeslotArg.TimeSlot, timeslotArg.Artist, timeSlotAggregator, slotPolicy)
+		timeSlotEntities = append(timeSlotEntities, timeSlotEntity)
+
+		timeSlotAggregator = timeSlotAggregator.Add(timeSlotEntity.Duration).Add(slotPolicy.Changeover())
 	}
 
 	timeMarkerEntities := make([]*TimeMarkerEntity, 0)
 
 	for _, timeMarker := range timeMarkers {
 		timeMarkerEntities = append(timeMarkerEntities, &TimeMarkerEntity{
-			ID:    timeMarker.ID,
-			Index: int(timeMarker.TimeslotIndex),
-			Type:  timeMarker.MarkerType,
-			Time:  timeMarker.MarkerValue,
+			ID:         timeMarker.ID,
+			TimeSlotID: timeMarker.TimeslotID,
+			Type:       TimeMarkerType(timeMarker.MarkerType),
+			Time:       timeMarker.MarkerTime,
 		})
 	}
 
 	return &EventEntity{
-		ID:        eventModel.ID,
-		StartTime: eventModel.StartTime,
-		EndTime:   eventModel.EndTime,
-		EventType: eventModel.EventType,
-		timeSlots: timeSlotEntities,
-		markers:   timeMarkerEntities,
+		ID:                    eventModel.ID,
+		VenueID:               eventModel.VenueID,
+		StartTime:             eventModel.StartTime,
+		EndTime:               eventModel.EndTime,
+		EventType:             eventModel.EventType,
+		SlotPolicy:            slotPolicy,
+		AllowDuplicateArtists: eventModel.AllowDuplicateArtists,
+		CurrentWindow:         DefaultCurrentEventWindow(),
+		ChangeSeq:             eventModel.ChangeSeq,
+		Version:               eventModel.Version,
+		timeSlots:             timeSlotEntities,
+		markers:               timeMarkerEntities,
 	}
 }
 
@@ -131,40 +272,109 @@ func (e *EventEntity) NextTimeSlotByID(id uuid.UUID) *TimeSlotEntity {
 	return timeSlot
 }
 
-func (e *EventEntity) TimeSlotMarkerByDisplay(timeDisplay string) *TimeMarkerEntity {
-	var marker *TimeMarkerEntity
-	for _, slot := range e.markers {
-		if slot.Time == timeDisplay {
-			marker = slot
-			break
-		}
+func (t *TimeSlotEntity) IsArtistSet() bool {
+	return t.ItemType == LineupItemArtist
+}
+
+// Validate checks that an item other than an artist set still has a title
+// and a duration of its own.
+func (t *TimeSlotEntity) Validate() error {
+	if t.IsArtistSet() {
+		return nil
 	}
-	if marker == nil {
+
+	if t.NameOverride == nil || *t.NameOverride == "" || t.DurationOverrideMinutes == nil || *t.DurationOverrideMinutes < 0 {
+		return ErrInvalidLineupItem
+	}
+
+	return nil
+}
+
+// CanAddArtist reports whether artistID may take another timeslot under the
+// event's duplicate appearance policy.
+func (e *EventEntity) CanAddArtist(artistID uuid.UUID) error {
+	if e.AllowDuplicateArtists {
 		return nil
 	}
 
-	return marker
+	for _, slot := range e.timeSlots {
+		if slot.Artist != nil && slot.Artist.ID == artistID {
+			return ErrDuplicateArtist
+		}
+	}
+
+	return nil
 }
 
-func (e *EventEntity) TimeSlotMarkerDupeByIndex(index int, display string) *TimeMarkerEntity {
-	var marker *TimeMarkerEntity
-	for _, slot := range e.markers {
-		if slot.Index == index && slot.Time != display {
-			marker = slot
-			break
+// OrderTimeSlots returns the event's timeslots in the order of ids, which must
+// name every timeslot exactly once.
+func (e *EventEntity) OrderTimeSlots(ids []uuid.UUID) ([]*TimeSlotEntity, error) {
+	if len(ids) != len(e.timeSlots) {
+		return nil, ErrLineupMismatch
+	}
+
+	ordered := make([]*TimeSlotEntity, 0, len(ids))
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		slot := e.TimeSlotByID(id)
+		if slot == nil || seen[id] {
+			return nil, ErrLineupMismatch
 		}
+		seen[id] = true
+		ordered = append(ordered, slot)
 	}
-	if marker == nil {
-		return nil
+
+	return ordered, nil
+}
+
+// NeedsRebalance reports whether any sort key has grown long enough that the
+// lineup's keys should be rewritten.
+func (e *EventEntity) NeedsRebalance() bool {
+	for _, slot := range e.timeSlots {
+		if len(slot.SortKey) > rebalanceSortKeyLength {
+			return true
+		}
 	}
 
-	return marker
+	return false
+}
+
+// TimeSlotIndex returns the position of the timeslot in the lineup, or -1 if
+// the event has no such timeslot.
+func (e *EventEntity) TimeSlotIndex(id uuid.UUID) int {
+	for idx, slot := range e.timeSlots {
+		if slot.ID == id {
+			return idx
+		}
+	}
+
+	return -1
+}
+
+func (e *EventEntity) TimeMarkerByTime(markerTime time.Time) *TimeMarkerEntity {
+	for _, marker := range e.markers {
+		if marker.Type == TimeMarkerTime && marker.Time != nil && marker.Time.Equal(markerTime) {
+			return marker
+		}
+	}
+
+	return nil
+}
+
+func (e *EventEntity) TimeMarkerOnSlot(timeslotID uuid.UUID, markerType TimeMarkerType) *TimeMarkerEntity {
+	for _, marker := range e.markers {
+		if marker.TimeSlotID == timeslotID && marker.Type == markerType {
+			return marker
+		}
+	}
+
+	return nil
 }
 
 func (e *EventEntity) NowPlayingTimeSlotMarker() *TimeMarkerEntity {
 	var marker *TimeMarkerEntity
 	for _, slot := range e.markers {
-		if slot.Type == "PLAYING" {
+		if slot.Type == TimeMarkerPlaying {
 			marker = slot
 			break
 		}
@@ -176,6 +386,38 @@ func (e *EventEntity) NowPlayingTimeSlotMarker() *TimeMarkerEntity {
 	return marker
 }
 
+// NowPlayingTimeSlot is the timeslot on stage, if any.
+func (e *EventEntity) NowPlayingTimeSlot() *TimeSlotEntity {
+	marker := e.NowPlayingTimeSlotMarker()
+	if marker == nil {
+		return nil
+	}
+	return e.TimeSlotByID(marker.TimeSlotID)
+}
+
+// OnDeck lists up to n artist sets that have yet to go on, in lineup order,
+// after the timeslot on stage or the last one to have run.
+func (e *EventEntity) OnDeck(n int) []*TimeSlotEntity {
+	after := -1
+	if playing := e.NowPlayingTimeSlot(); playing != nil {
+		after = e.TimeSlotIndex(playing.ID)
+	} else if len(e.runLog) > 0 {
+		after = e.TimeSlotIndex(e.runLog[len(e.runLog)-1].TimeSlotID)
+	}
+
+	onDeck := make([]*TimeSlotEntity, 0, n)
+	for _, timeslot := range e.timeSlots[after+1:] {
+		if len(onDeck) == n {
+			break
+		}
+		if timeslot.IsArtistSet() && timeslot.StartedAt == nil {
+			onDeck = append(onDeck, timeslot)
+		}
+	}
+
+	return onDeck
+}
+
 func (e *EventEntity) TimeSlotMarkerByID(id uuid.UUID) *TimeMarkerEntity {
 	var marker *TimeMarkerEntity
 	for _, slot := range e.markers {
@@ -191,20 +433,30 @@ func (e *EventEntity) TimeSlotMarkerByID(id uuid.UUID) *TimeMarkerEntity {
 	return marker
 }
 
+// IsCurrent reports whether now falls in the event's current window. Events
+// not loaded as the current one use the default window.
 func (e *EventEntity) IsCurrent() bool {
-	currentTime := time.Now()
-	eventTimeMinus := e.StartTime.Add(-30 * time.Hour)
-	eventTimePlus := e.StartTime.Add(8 * time.Hour)
-
-	return eventTimeMinus.Before(currentTime) && eventTimePlus.After(currentTime)
+	return e.CurrentWindow.Contains(e.StartTime, time.Now())
 }
 
-func newTimeSlotEntity(timeSlotModel models.Timeslot, artistModel models.Artist, slotTime time.Time) *TimeSlotEntity {
+func newTimeSlotEntity(timeSlotModel models.Timeslot, artistModel *models.Artist, slotTime time.Time, slotPolicy SlotDurationPolicy) *TimeSlotEntity {
+	var artist *ArtistEntity
+	if artistModel != nil {
+		artist = NewArtistEntity(*artistModel)
+	}
+
 	return &TimeSlotEntity{
-		ID:          timeSlotModel.ID,
-		SortKey:     timeSlotModel.SortKey,
-		SongCount:   timeSlotModel.SongCount,
-		TimeDisplay: slotTime,
-		Artist:      NewArtistEntity(artistModel),
+		ID:                      timeSlotModel.ID,
+		ItemType:                LineupItemType(timeSlotModel.ItemType),
+		NameOverride:            timeSlotModel.ArtistNameOverride,
+		SortKey:                 timeSlotModel.SortKey,
+		SongCount:               timeSlotModel.SongCount,
+		DurationOverrideMinutes: timeSlotModel.DurationOverrideMinutes,
+		Notes:                   timeSlotModel.Notes,
+		Duration:                slotPolicy.SlotDuration(timeSlotModel.SongCount, timeSlotModel.DurationOverrideMinutes),
+		TimeDisplay:             slotTime,
+		PlannedTime:             slotTime,
+		Artist:                  artist,
+		Version:                 timeSlotModel.Version,
 	}
 }
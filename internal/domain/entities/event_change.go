@@ -0,0 +1,48 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+)
+
+type EventChangeKind string
+
+const (
+	EventChangeSnapshot          EventChangeKind = "SNAPSHOT"
+	EventChangeEventUpdated      EventChangeKind = "EVENT_UPDATED"
+	EventChangeEventDeleted      EventChangeKind = "EVENT_DELETED"
+	EventChangeArtistAdded       EventChangeKind = "ARTIST_ADDED"
+	EventChangeItemAdded         EventChangeKind = "ITEM_ADDED"
+	EventChangeArtistRemoved     EventChangeKind = "ARTIST_REMOVED"
+	EventChangeSlotReordered     EventChangeKind = "SLOT_REORDERED"
+	EventChangeLineupReordered   EventChangeKind = "LINEUP_REORDERED"
+	EventChangeLineupRebalanced  EventChangeKind = "LINEUP_REBALANCED"
+	EventChangeSlotRemoved       EventChangeKind = "SLOT_REMOVED"
+	EventChangeSlotUpdated       EventChangeKind = "SLOT_UPDATED"
+	EventChangeMarkerSet         EventChangeKind = "MARKER_SET"
+	EventChangeMarkerDeleted     EventChangeKind = "MARKER_DELETED"
+	EventChangeNowPlayingChanged EventChangeKind = "NOW_PLAYING_CHANGED"
+	EventChangeShowStarted       EventChangeKind = "SHOW_STARTED"
+	EventChangeShowEnded         EventChangeKind = "SHOW_ENDED"
+	// EventChangeProjectionUpdated carries projected times that moved with the
+	// clock while a timeslot is on stage. Nothing changed, so it has no Seq.
+	EventChangeProjectionUpdated EventChangeKind = "PROJECTION_UPDATED"
+)
+
+// EventChangeEntity describes a committed change to an event's lineup. Seq
+// increases with every change to the same event. Event holds the state after
+// the change and is nil once the event is deleted.
+type EventChangeEntity struct {
+	Kind    EventChangeKind
+	EventID uuid.UUID
+	Seq     int64
+	Event   *EventEntity
+}
+
+func NewEventChangeEntity(kind EventChangeKind, seq int64, event *EventEntity) *EventChangeEntity {
+	return &EventChangeEntity{
+		Kind:    kind,
+		EventID: event.ID,
+		Seq:     seq,
+		Event:   event,
+	}
+}
@@ -0,0 +1,306 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEventEntitySchedule(t *testing.T) {
+
+	startTime, err := time.Parse(time.RFC3339, "2025-01-01T19:00:00Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newSlot := func(songCount int32, override *int32) *NewEventEntitySlotsArgs {
+		return &NewEventEntitySlotsArgs{
+			TimeSlot: models.Timeslot{
+				ID:                      uuid.New(),
+				SongCount:               songCount,
+				DurationOverrideMinutes: override,
+			},
+			Artist: &models.Artist{
+				ID:          uuid.New(),
+				ArtistTitle: "Artist",
+			},
+		}
+	}
+
+	t.Run("default policy keeps five and eight minute slots", func(t *testing.T) {
+		policy := DefaultSlotDurationPolicy()
+		eventModel := models.Event{
+			ID:                uuid.New(),
+			StartTime:         startTime,
+			MinutesPerSong:    policy.MinutesPerSong,
+			BaseSlotMinutes:   policy.BaseMinutes,
+			ChangeoverMinutes: policy.ChangeoverMinutes,
+		}
+
+		eventEntity := NewEventEntity(eventModel, []*NewEventEntitySlotsArgs{newSlot(1, nil), newSlot(2, nil), newSlot(1, nil)}, nil)
+		timeSlots := eventEntity.TimeSlots()
+
+		assert.Equal(t, eventEntity.SlotPolicy, policy)
+		assert.Equal(t, timeSlots[0].TimeDisplay, startTime)
+		assert.Equal(t, timeSlots[0].Duration, 5*time.Minute)
+		assert.Equal(t, timeSlots[1].TimeDisplay, startTime.Add(5*time.Minute))
+		assert.Equal(t, timeSlots[1].Duration, 8*time.Minute)
+		assert.Equal(t, timeSlots[2].TimeDisplay, startTime.Add(13*time.Minute))
+	})
+
+	t.Run("policy with changeover and override", func(t *testing.T) {
+		override := int32(15)
+		eventModel := models.Event{
+			ID:                uuid.New(),
+			StartTime:         startTime,
+			MinutesPerSong:    3,
+			BaseSlotMinutes:   4,
+			ChangeoverMinutes: 2,
+		}
+
+		eventEntity := NewEventEntity(eventModel, []*NewEventEntitySlotsArgs{newSlot(1, nil), newSlot(2, nil), newSlot(2, &override), newSlot(1, nil)}, nil)
+		timeSlots := eventEntity.TimeSlots()
+
+		assert.Equal(t, timeSlots[0].Duration, 7*time.Minute)
+		assert.Equal(t, timeSlots[1].TimeDisplay, startTime.Add(9*time.Minute))
+		assert.Equal(t, timeSlots[1].Duration, 10*time.Minute)
+		assert.Equal(t, timeSlots[2].TimeDisplay, startTime.Add(21*time.Minute))
+		assert.Equal(t, timeSlots[2].Duration, 15*time.Minute)
+		assert.Equal(t, timeSlots[3].TimeDisplay, startTime.Add(38*time.Minute))
+	})
+}
+
+func TestSlotDurationPolicyValidate(t *testing.T) {
+
+	t.Run("default policy is valid", func(t *testing.T) {
+		assert.NoError(t, DefaultSlotDurationPolicy().Validate())
+	})
+
+	t.Run("negative values are invalid", func(t *testing.T) {
+		policy := SlotDurationPolicy{MinutesPerSong: 3, BaseMinutes: 2, ChangeoverMinutes: -1}
+		assert.Equal(t, policy.Validate(), ErrInvalidSlotPolicy)
+	})
+
+	t.Run("zero length slots are invalid", func(t *testing.T) {
+		policy := SlotDurationPolicy{MinutesPerSong: 0, BaseMinutes: 0, ChangeoverMinutes: 2}
+		assert.Equal(t, policy.Validate(), ErrInvalidSlotPolicy)
+	})
+}
+
+func TestEventEntityLineupOrder(t *testing.T) {
+
+	newEvent := func(sortKeys ...string) *EventEntity {
+		slots := make([]*NewEventEntitySlotsArgs, 0, len(sortKeys))
+		for _, sortKey := range sortKeys {
+			slots = append(slots, &NewEventEntitySlotsArgs{
+				TimeSlot: models.Timeslot{ID: uuid.New(), SortKey: sortKey, SongCount: 1},
+				Artist:   &models.Artist{ID: uuid.New(), ArtistTitle: "Artist"},
+			})
+		}
+		return NewEventEntity(models.Event{ID: uuid.New(), MinutesPerSong: 3}, slots, nil)
+	}
+
+	t.Run("orders every timeslot", func(t *testing.T) {
+		event := newEvent("a0", "a1", "a2")
+		slots := event.TimeSlots()
+
+		ordered, err := event.OrderTimeSlots([]uuid.UUID{slots[2].ID, slots[0].ID, slots[1].ID})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*TimeSlotEntity{slots[2], slots[0], slots[1]}, ordered)
+	})
+
+	t.Run("rejects missing, unknown and repeated timeslots", func(t *testing.T) {
+		event := newEvent("a0", "a1")
+		slots := event.TimeSlots()
+
+		for _, ids := range [][]uuid.UUID{
+			{slots[0].ID},
+			{slots[0].ID, uuid.New()},
+			{slots[0].ID, slots[0].ID},
+			{slots[0].ID, slots[1].ID, uuid.New()},
+		} {
+			_, err := event.OrderTimeSlots(ids)
+			assert.ErrorIs(t, err, ErrLineupMismatch)
+		}
+	})
+
+	t.Run("rebalances once a key grows too long", func(t *testing.T) {
+		assert.False(t, newEvent("a0", "a0V", "a1").NeedsRebalance())
+		assert.True(t, newEvent("a0", "a0VVVVVVVVV", "a1").NeedsRebalance())
+	})
+}
+
+func TestEventEntityDuplicateArtists(t *testing.T) {
+
+	artist := &models.Artist{ID: uuid.New(), ArtistTitle: "Artist"}
+	nameOverride := "Artist & Friends"
+	newEvent := func(allowDuplicates bool) *EventEntity {
+		return NewEventEntity(models.Event{ID: uuid.New(), MinutesPerSong: 3, AllowDuplicateArtists: allowDuplicates}, []*NewEventEntitySlotsArgs{
+			{
+				TimeSlot: models.Timeslot{ID: uuid.New(), SortKey: "a0", SongCount: 1, ArtistNameOverride: &nameOverride},
+				Artist:   artist,
+			},
+		}, nil)
+	}
+
+	t.Run("carries the timeslot name override", func(t *testing.T) {
+		assert.Equal(t, &nameOverride, newEvent(true).TimeSlots()[0].NameOverride)
+	})
+
+	t.Run("allows a second appearance when duplicates are allowed", func(t *testing.T) {
+		assert.NoError(t, newEvent(true).CanAddArtist(artist.ID))
+	})
+
+	t.Run("forbids a second appearance when duplicates are not allowed", func(t *testing.T) {
+		event := newEvent(false)
+
+		assert.ErrorIs(t, event.CanAddArtist(artist.ID), ErrDuplicateArtist)
+		assert.NoError(t, event.CanAddArtist(uuid.New()))
+	})
+}
+
+func TestEventEntityLineupItems(t *testing.T) {
+
+	startTime, err := time.Parse(time.RFC3339, "2025-01-01T19:00:00Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	title := "Intermission"
+	breakMinutes := int32(15)
+
+	t.Run("a break takes up schedule time without an artist", func(t *testing.T) {
+		eventEntity := NewEventEntity(models.Event{ID: uuid.New(), StartTime: startTime, MinutesPerSong: 3, BaseSlotMinutes: 2}, []*NewEventEntitySlotsArgs{
+			{
+				TimeSlot: models.Timeslot{ID: uuid.New(), ItemType: string(LineupItemArtist), SongCount: 1},
+				Artist:   &models.Artist{ID: uuid.New(), ArtistTitle: "Artist"},
+			},
+			{
+				TimeSlot: models.Timeslot{ID: uuid.New(), ItemType: string(LineupItemBreak), ArtistNameOverride: &title, DurationOverrideMinutes: &breakMinutes},
+			},
+			{
+				TimeSlot: models.Timeslot{ID: uuid.New(), ItemType: string(LineupItemArtist), SongCount: 1},
+				Artist:   &models.Artist{ID: uuid.New(), ArtistTitle: "Artist"},
+			},
+		}, nil)
+		timeSlots := eventEntity.TimeSlots()
+
+		assert.Nil(t, timeSlots[1].Artist)
+		assert.Equal(t, LineupItemBreak, timeSlots[1].ItemType)
+		assert.Equal(t, startTime.Add(5*time.Minute), timeSlots[1].TimeDisplay)
+		assert.Equal(t, startTime.Add(20*time.Minute), timeSlots[2].TimeDisplay)
+	})
+
+	t.Run("items other than artist sets need a title and duration", func(t *testing.T) {
+		assert.NoError(t, (&TimeSlotEntity{ItemType: LineupItemRaffle, NameOverride: &title, DurationOverrideMinutes: &breakMinutes}).Validate())
+		assert.ErrorIs(t, (&TimeSlotEntity{ItemType: LineupItemRaffle, DurationOverrideMinutes: &breakMinutes}).Validate(), ErrInvalidLineupItem)
+		assert.ErrorIs(t, (&TimeSlotEntity{ItemType: LineupItemRaffle, NameOverride: &title}).Validate(), ErrInvalidLineupItem)
+		assert.NoError(t, (&TimeSlotEntity{ItemType: LineupItemArtist}).Validate())
+	})
+
+	t.Run("parses item types", func(t *testing.T) {
+		itemType, err := ParseLineupItemType("HOST_BIT")
+		assert.NoError(t, err)
+		assert.Equal(t, LineupItemHostBit, itemType)
+
+		_, err = ParseLineupItemType("NAP")
+		assert.ErrorIs(t, err, ErrInvalidLineupItem)
+	})
+}
+
+func TestEventEntityTimeMarkers(t *testing.T) {
+
+	markerTime, err := time.Parse(time.RFC3339, "2025-01-01T21:30:00Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first := models.Timeslot{ID: uuid.New(), SortKey: "a1", SongCount: 1}
+	second := models.Timeslot{ID: uuid.New(), SortKey: "a0", SongCount: 1}
+	eventEntity := NewEventEntity(models.Event{ID: uuid.New(), MinutesPerSong: 3}, []*NewEventEntitySlotsArgs{
+		{TimeSlot: second, Artist: &models.Artist{ID: uuid.New()}},
+		{TimeSlot: first, Artist: &models.Artist{ID: uuid.New()}},
+	}, []*models.TimeslotMarker{
+		{ID: uuid.New(), TimeslotID: first.ID, MarkerType: string(TimeMarkerTime), MarkerTime: &markerTime},
+		{ID: uuid.New(), TimeslotID: second.ID, MarkerType: string(TimeMarkerPlaying)},
+	})
+
+	t.Run("markers stay with their timeslot wherever it sits", func(t *testing.T) {
+		marker := eventEntity.TimeMarkerByTime(markerTime)
+
+		assert.NotNil(t, marker)
+		assert.Equal(t, first.ID, marker.TimeSlotID)
+		assert.Equal(t, 1, eventEntity.TimeSlotIndex(marker.TimeSlotID))
+		assert.Equal(t, second.ID, eventEntity.NowPlayingTimeSlotMarker().TimeSlotID)
+		assert.Equal(t, -1, eventEntity.TimeSlotIndex(uuid.New()))
+	})
+
+	t.Run("finds the marker of a kind on a timeslot", func(t *testing.T) {
+		assert.NotNil(t, eventEntity.TimeMarkerOnSlot(first.ID, TimeMarkerTime))
+		assert.Nil(t, eventEntity.TimeMarkerOnSlot(first.ID, TimeMarkerPlaying))
+	})
+
+	t.Run("only time markers carry a time", func(t *testing.T) {
+		assert.NoError(t, (&TimeMarkerEntity{Type: TimeMarkerTime, Time: &markerTime}).Validate())
+		assert.NoError(t, (&TimeMarkerEntity{Type: TimeMarkerPlaying}).Validate())
+		assert.ErrorIs(t, (&TimeMarkerEntity{Type: TimeMarkerTime}).Validate(), ErrInvalidMarker)
+		assert.ErrorIs(t, (&TimeMarkerEntity{Type: TimeMarkerTime, Time: &time.Time{}}).Validate(), ErrInvalidMarker)
+		assert.ErrorIs(t, (&TimeMarkerEntity{Type: TimeMarkerPlaying, Time: &markerTime}).Validate(), ErrInvalidMarker)
+		assert.ErrorIs(t, (&TimeMarkerEntity{Type: "Playing"}).Validate(), ErrInvalidMarker)
+	})
+}
+
+func TestEventEntityOnDeck(t *testing.T) {
+
+	breakTitle := "Break"
+	breakMinutes := int32(10)
+	first := models.Timeslot{ID: uuid.New(), SortKey: "a0", SongCount: 1, ItemType: string(LineupItemArtist)}
+	second := models.Timeslot{ID: uuid.New(), SortKey: "a1", ItemType: string(LineupItemBreak), ArtistNameOverride: &breakTitle, DurationOverrideMinutes: &breakMinutes}
+	third := models.Timeslot{ID: uuid.New(), SortKey: "a2", SongCount: 1, ItemType: string(LineupItemArtist)}
+	fourth := models.Timeslot{ID: uuid.New(), SortKey: "a3", SongCount: 1, ItemType: string(LineupItemArtist)}
+	newEvent := func(markers []*models.TimeslotMarker) *EventEntity {
+		return NewEventEntity(models.Event{ID: uuid.New(), MinutesPerSong: 3}, []*NewEventEntitySlotsArgs{
+			{TimeSlot: first, Artist: &models.Artist{ID: uuid.New()}},
+			{TimeSlot: second},
+			{TimeSlot: third, Artist: &models.Artist{ID: uuid.New()}},
+			{TimeSlot: fourth, Artist: &models.Artist{ID: uuid.New()}},
+		}, markers)
+	}
+	ids := func(timeslots []*TimeSlotEntity) []uuid.UUID {
+		timeslotIDs := make([]uuid.UUID, 0, len(timeslots))
+		for _, timeslot := range timeslots {
+			timeslotIDs = append(timeslotIDs, timeslot.ID)
+		}
+		return timeslotIDs
+	}
+
+	t.Run("lists artist sets from the top before the show starts", func(t *testing.T) {
+		event := newEvent(nil)
+
+		assert.Nil(t, event.NowPlayingTimeSlot())
+		assert.Equal(t, []uuid.UUID{first.ID, third.ID}, ids(event.OnDeck(2)))
+	})
+
+	t.Run("lists artist sets after the one playing", func(t *testing.T) {
+		event := newEvent([]*models.TimeslotMarker{
+			{ID: uuid.New(), TimeslotID: first.ID, MarkerType: string(TimeMarkerPlaying)},
+		})
+
+		assert.Equal(t, first.ID, event.NowPlayingTimeSlot().ID)
+		assert.Equal(t, []uuid.UUID{third.ID, fourth.ID}, ids(event.OnDeck(OnDeckCount)))
+	})
+
+	t.Run("lists artist sets after the last run once the show has ended", func(t *testing.T) {
+		event := newEvent(nil)
+		endedAt := time.Now()
+		event.ApplyRunLog([]*TimeSlotRunEntity{
+			{ID: uuid.New(), TimeSlotID: third.ID, StartedAt: endedAt.Add(-5 * time.Minute), EndedAt: &endedAt},
+		}, endedAt)
+
+		assert.Equal(t, []uuid.UUID{fourth.ID}, ids(event.OnDeck(OnDeckCount)))
+	})
+}
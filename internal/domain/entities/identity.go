@@ -0,0 +1,82 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+)
+
+var (
+	ErrIdentityNotFound         = NewNotFoundError("identity not found")
+	ErrIdentityProviderNotFound = NewNotFoundError("identity provider not found")
+	ErrIdentityEmailUnverified  = NewForbiddenError("identity email not verified")
+	ErrLoginRequestInvalid      = NewValidationError("login request invalid")
+)
+
+// IdentityEntity links a user to an account at an external OIDC provider.
+type IdentityEntity struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	Provider  string
+	Subject   string
+	Email     string
+	CreatedAt time.Time
+}
+
+func NewIdentityEntity(identityModel models.UserIdentity) *IdentityEntity {
+	return &IdentityEntity{
+		ID:        identityModel.ID,
+		UserID:    identityModel.UserID,
+		Provider:  identityModel.Provider,
+		Subject:   identityModel.Subject,
+		Email:     identityModel.Email,
+		CreatedAt: identityModel.CreatedAt,
+	}
+}
+
+// IdentityClaims are the verified ID token claims returned by a provider.
+type IdentityClaims struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	GivenName     *string
+	FamilyName    *string
+	Nonce         string
+}
+
+// LoginRequestEntity is an authorization request waiting for its callback.
+// Binding, State and AuthorizationURL are only known when the request is
+// created; storage keeps StateHash.
+type LoginRequestEntity struct {
+	Binding          string
+	State            string
+	StateHash        string
+	Provider         string
+	Nonce            string
+	CodeVerifier     string
+	AuthorizationURL string
+	ExpiresAt        time.Time
+}
+
+// LoginState derives the state sent to the provider from the binding kept by
+// the browser that started the login, so a callback only completes in that
+// browser.
+func LoginState(binding string) string {
+	return HashToken(binding)
+}
+
+func NewLoginRequestEntity(loginRequestModel models.OidcLoginRequest) *LoginRequestEntity {
+	return &LoginRequestEntity{
+		StateHash:    loginRequestModel.StateHash,
+		Provider:     loginRequestModel.Provider,
+		Nonce:        loginRequestModel.Nonce,
+		CodeVerifier: loginRequestModel.CodeVerifier,
+		ExpiresAt:    loginRequestModel.ExpiresAt,
+	}
+}
+
+func (lr *LoginRequestEntity) IsExpired() bool {
+	return lr.ExpiresAt.Before(time.Now())
+}
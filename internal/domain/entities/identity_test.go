@@ -0,0 +1,51 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdentityEntity(t *testing.T) {
+	t.Run("create entity from model", func(t *testing.T) {
+		identityModel := models.UserIdentity{
+			ID:       uuid.New(),
+			UserID:   uuid.New(),
+			Provider: "google",
+			Subject:  "1234567890",
+			Email:    "test@example.com",
+		}
+
+		identityEntity := NewIdentityEntity(identityModel)
+
+		assert.Equal(t, identityModel.ID, identityEntity.ID)
+		assert.Equal(t, identityModel.UserID, identityEntity.UserID)
+		assert.Equal(t, "google", identityEntity.Provider)
+		assert.Equal(t, "1234567890", identityEntity.Subject)
+		assert.Equal(t, "test@example.com", identityEntity.Email)
+	})
+}
+
+func TestLoginRequestEntity(t *testing.T) {
+	t.Run("not expired", func(t *testing.T) {
+		loginRequest := NewLoginRequestEntity(models.OidcLoginRequest{
+			StateHash: HashToken("state"),
+			Provider:  "google",
+			ExpiresAt: time.Now().Add(time.Minute),
+		})
+
+		assert.False(t, loginRequest.IsExpired())
+		assert.Empty(t, loginRequest.State)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		loginRequest := NewLoginRequestEntity(models.OidcLoginRequest{
+			ExpiresAt: time.Now().Add(-time.Minute),
+		})
+
+		assert.True(t, loginRequest.IsExpired())
+	})
+}
@@ -8,9 +8,9 @@ import (
 )
 
 var (
-	ErrImageNotFound           = fmt.Errorf("image not found")
-	ErrCollectionNotFound      = fmt.Errorf("image not found")
-	ErrCollectionNotAuthorized = fmt.Errorf("collection not authorized")
+	ErrImageNotFound           = NewNotFoundError("image not found")
+	ErrCollectionNotFound      = NewNotFoundError("collection not found")
+	ErrCollectionNotAuthorized = NewForbiddenError("collection not authorized")
 )
 
 type ImageEntity struct {
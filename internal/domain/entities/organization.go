@@ -0,0 +1,63 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+)
+
+var (
+	ErrOrganizationNotFound    = NewNotFoundError("organization not found")
+	ErrOrganizationHandleInUse = NewConflictError("organization handle in use")
+	ErrVenueNotFound           = NewNotFoundError("venue not found")
+)
+
+type OrganizationEntity struct {
+	ID     uuid.UUID
+	Name   string
+	Handle string
+}
+
+func NewOrganizationEntity(organizationModel models.Organization) *OrganizationEntity {
+	return &OrganizationEntity{
+		ID:     organizationModel.ID,
+		Name:   organizationModel.OrganizationName,
+		Handle: organizationModel.OrganizationHandle,
+	}
+}
+
+type VenueEntity struct {
+	ID             uuid.UUID
+	OrganizationID uuid.UUID
+	Name           string
+	Address        *string
+	CurrentWindow  *CurrentEventWindow
+}
+
+func NewVenueEntity(venueModel models.Venue) *VenueEntity {
+	return &VenueEntity{
+		ID:             venueModel.ID,
+		OrganizationID: venueModel.OrganizationID,
+		Name:           venueModel.VenueName,
+		Address:        venueModel.VenueAddress,
+		CurrentWindow:  newVenueCurrentWindow(venueModel),
+	}
+}
+
+func newVenueCurrentWindow(venueModel models.Venue) *CurrentEventWindow {
+	if venueModel.CurrentMinutesBefore == nil || venueModel.CurrentMinutesAfter == nil {
+		return nil
+	}
+
+	return &CurrentEventWindow{
+		MinutesBefore: *venueModel.CurrentMinutesBefore,
+		MinutesAfter:  *venueModel.CurrentMinutesAfter,
+	}
+}
+
+// Validate checks the venue's own current event window, if it has one.
+func (v *VenueEntity) Validate() error {
+	if v.CurrentWindow == nil {
+		return nil
+	}
+	return v.CurrentWindow.Validate()
+}
@@ -0,0 +1,69 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOrganizationEntity(t *testing.T) {
+
+	t.Run("create entity from model", func(t *testing.T) {
+		organizationID := uuid.New()
+
+		organizationModel := models.Organization{
+			ID:                 organizationID,
+			OrganizationName:   "The Basement",
+			OrganizationHandle: "basement",
+		}
+
+		organizationEntity := NewOrganizationEntity(organizationModel)
+
+		assert.Equal(t, organizationID, organizationEntity.ID)
+		assert.Equal(t, "The Basement", organizationEntity.Name)
+		assert.Equal(t, "basement", organizationEntity.Handle)
+	})
+}
+
+func TestNewVenueEntity(t *testing.T) {
+
+	t.Run("create entity from model", func(t *testing.T) {
+		venueID := uuid.New()
+		organizationID := uuid.New()
+		address := "12 Main St"
+
+		venueModel := models.Venue{
+			ID:             venueID,
+			OrganizationID: organizationID,
+			VenueName:      "Main Stage",
+			VenueAddress:   &address,
+		}
+
+		venueEntity := NewVenueEntity(venueModel)
+
+		assert.Equal(t, venueID, venueEntity.ID)
+		assert.Equal(t, organizationID, venueEntity.OrganizationID)
+		assert.Equal(t, "Main Stage", venueEntity.Name)
+		assert.Equal(t, &address, venueEntity.Address)
+		assert.Nil(t, venueEntity.CurrentWindow)
+		assert.NoError(t, venueEntity.Validate())
+	})
+
+	t.Run("venue with its own current event window", func(t *testing.T) {
+		minutesBefore, minutesAfter := int32(120), int32(240)
+
+		venueEntity := NewVenueEntity(models.Venue{
+			ID:                   uuid.New(),
+			CurrentMinutesBefore: &minutesBefore,
+			CurrentMinutesAfter:  &minutesAfter,
+		})
+
+		assert.Equal(t, &CurrentEventWindow{MinutesBefore: 120, MinutesAfter: 240}, venueEntity.CurrentWindow)
+		assert.NoError(t, venueEntity.Validate())
+
+		venueEntity.CurrentWindow.MinutesAfter = -1
+		assert.ErrorIs(t, venueEntity.Validate(), ErrInvalidCurrentWindow)
+	})
+}
@@ -1,7 +1,6 @@
 package entities
 
 import (
-	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -9,9 +8,9 @@ import (
 )
 
 var (
-	ErrLinkNotFound = errors.New("link not found")
-	ErrLinkExpired  = errors.New("link expired")
-	ErrLinkInvalid  = errors.New("link type invalid")
+	ErrLinkNotFound = NewNotFoundError("link not found")
+	ErrLinkExpired  = NewExpiredError("link expired")
+	ErrLinkInvalid  = NewValidationError("link type invalid")
 )
 
 var (
@@ -19,10 +18,12 @@ var (
 	RefLinkTypeInvite = "invite"
 )
 
+// Token is only known when the link is created; storage keeps TokenHash.
 type ReferenceLinkEntity struct {
 	ID        uuid.UUID
 	LinkID    uuid.UUID
 	Token     string
+	TokenHash string
 	Type      string
 	ExpiresAt time.Time
 }
@@ -31,7 +32,7 @@ func NewReferenceLinkEntity(refLinkModel models.ReferenceLink) *ReferenceLinkEnt
 	return &ReferenceLinkEntity{
 		ID:        refLinkModel.ID,
 		LinkID:    refLinkModel.LinkID,
-		Token:     refLinkModel.Token,
+		TokenHash: refLinkModel.TokenHash,
 		Type:      refLinkModel.LinkType,
 		ExpiresAt: refLinkModel.ExpiresAt,
 	}
@@ -23,7 +23,7 @@ func TestNewReferenceLinkEntity(t *testing.T) {
 			ID:        refLinkID,
 			LinkID:    linkID,
 			LinkType:  RefLinkTypeLogin,
-			Token:     "test-token",
+			TokenHash: "test-token",
 			ExpiresAt: expiresAt,
 		}
 
@@ -32,7 +32,7 @@ func TestNewReferenceLinkEntity(t *testing.T) {
 		assert.Equal(t, referenceLinkEntity.ID, refLinkID)
 		assert.Equal(t, referenceLinkEntity.LinkID, linkID)
 		assert.Equal(t, referenceLinkEntity.Type, RefLinkTypeLogin)
-		assert.Equal(t, referenceLinkEntity.Token, "test-token")
+		assert.Equal(t, referenceLinkEntity.TokenHash, "test-token")
 		assert.Equal(t, referenceLinkEntity.ExpiresAt, expiresAt)
 		assert.Equal(t, referenceLinkEntity.IsExpired(), true)
 	})
@@ -49,7 +49,7 @@ func TestNewReferenceLinkEntity(t *testing.T) {
 			ID:        refLinkID,
 			LinkID:    linkID,
 			LinkType:  RefLinkTypeInvite,
-			Token:     "test-token",
+			TokenHash: "test-token",
 			ExpiresAt: expiresAt,
 		}
 
@@ -58,7 +58,7 @@ func TestNewReferenceLinkEntity(t *testing.T) {
 		assert.Equal(t, referenceLinkEntity.ID, refLinkID)
 		assert.Equal(t, referenceLinkEntity.LinkID, linkID)
 		assert.Equal(t, referenceLinkEntity.Type, RefLinkTypeInvite)
-		assert.Equal(t, referenceLinkEntity.Token, "test-token")
+		assert.Equal(t, referenceLinkEntity.TokenHash, "test-token")
 		assert.Equal(t, referenceLinkEntity.ExpiresAt, expiresAt)
 	})
 
@@ -71,7 +71,7 @@ func TestNewReferenceLinkEntity(t *testing.T) {
 			ID:        refLinkID,
 			LinkID:    linkID,
 			LinkType:  RefLinkTypeLogin,
-			Token:     "test-token",
+			TokenHash: "test-token",
 			ExpiresAt: expiresAt,
 		}
 
@@ -80,7 +80,7 @@ func TestNewReferenceLinkEntity(t *testing.T) {
 		assert.Equal(t, referenceLinkEntity.ID, refLinkID)
 		assert.Equal(t, referenceLinkEntity.LinkID, linkID)
 		assert.Equal(t, referenceLinkEntity.Type, RefLinkTypeLogin)
-		assert.Equal(t, referenceLinkEntity.Token, "test-token")
+		assert.Equal(t, referenceLinkEntity.TokenHash, "test-token")
 		assert.Equal(t, referenceLinkEntity.ExpiresAt, expiresAt)
 		assert.Equal(t, referenceLinkEntity.IsExpired(), false)
 	})
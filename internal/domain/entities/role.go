@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidRole = NewValidationError("invalid role")
+	ErrForbidden   = NewForbiddenError("forbidden")
+)
+
+// Role is ordered from most to least privileged. Each role may do anything
+// the roles below it can.
+type Role string
+
+const (
+	RoleAdmin     Role = "admin"
+	RoleHost      Role = "host"
+	RolePerformer Role = "performer"
+	RoleAudience  Role = "audience"
+)
+
+var roleRank = map[Role]int{
+	RoleAdmin:     4,
+	RoleHost:      3,
+	RolePerformer: 2,
+	RoleAudience:  1,
+}
+
+func ParseRole(value string) (Role, error) {
+	role := Role(value)
+	if _, ok := roleRank[role]; !ok {
+		return "", ErrInvalidRole
+	}
+
+	return role, nil
+}
+
+// Includes reports whether r grants everything other does.
+func (r Role) Includes(other Role) bool {
+	rank, ok := roleRank[r]
+	if !ok {
+		return false
+	}
+
+	return rank >= roleRank[other]
+}
+
+func (uc *UserContextEntity) HasRole(role Role) bool {
+	if uc == nil || uc.User == nil || uc.IsExpired() {
+		return false
+	}
+
+	return uc.User.Role.Includes(role)
+}
+
+// CanEditUser allows users to edit themselves, and admins to edit anyone.
+func (uc *UserContextEntity) CanEditUser(userID uuid.UUID) bool {
+	if uc.HasRole(RoleAdmin) {
+		return true
+	}
+
+	return uc.HasRole(RoleAudience) && uc.UserID == userID
+}
+
+// CanEditArtist allows only the user linked to the artist, or an admin.
+func (uc *UserContextEntity) CanEditArtist(artist *ArtistEntity) bool {
+	if uc.HasRole(RoleAdmin) {
+		return true
+	}
+
+	return uc.HasRole(RoleAudience) && artist.UserID != nil && *artist.UserID == uc.UserID
+}
@@ -0,0 +1,78 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRole(t *testing.T) {
+
+	t.Run("parse known and unknown roles", func(t *testing.T) {
+		role, err := ParseRole("host")
+		assert.NoError(t, err)
+		assert.Equal(t, role, RoleHost)
+
+		_, err = ParseRole("owner")
+		assert.Equal(t, err, ErrInvalidRole)
+	})
+
+	t.Run("roles include less privileged roles", func(t *testing.T) {
+		assert.True(t, RoleAdmin.Includes(RoleHost))
+		assert.True(t, RoleHost.Includes(RoleHost))
+		assert.True(t, RoleHost.Includes(RoleAudience))
+		assert.False(t, RolePerformer.Includes(RoleHost))
+		assert.False(t, Role("").Includes(RoleAudience))
+	})
+}
+
+func TestUserContextPolicy(t *testing.T) {
+
+	newUserContext := func(role Role, expiresAt time.Time) *UserContextEntity {
+		userModel := models.User{
+			ID:       uuid.New(),
+			UserRole: string(role),
+		}
+		userSessionModel := models.UserSession{
+			ID:        uuid.New(),
+			UserID:    userModel.ID,
+			ExpiresAt: expiresAt,
+		}
+		return NewUserContextEntity(NewUserEntity(userModel, nil), userSessionModel)
+	}
+
+	later := time.Now().Add(time.Hour)
+
+	t.Run("expired and missing sessions have no role", func(t *testing.T) {
+		var missing *UserContextEntity
+		assert.False(t, missing.HasRole(RoleAudience))
+		assert.False(t, newUserContext(RoleAdmin, time.Now().Add(-time.Hour)).HasRole(RoleAudience))
+	})
+
+	t.Run("users edit themselves and admins edit anyone", func(t *testing.T) {
+		audience := newUserContext(RoleAudience, later)
+		host := newUserContext(RoleHost, later)
+		admin := newUserContext(RoleAdmin, later)
+
+		assert.True(t, audience.CanEditUser(audience.UserID))
+		assert.False(t, host.CanEditUser(audience.UserID))
+		assert.True(t, admin.CanEditUser(audience.UserID))
+	})
+
+	t.Run("only the linked user or an admin edits an artist", func(t *testing.T) {
+		performer := newUserContext(RolePerformer, later)
+		host := newUserContext(RoleHost, later)
+		admin := newUserContext(RoleAdmin, later)
+
+		linked := &ArtistEntity{ID: uuid.New(), UserID: &performer.UserID}
+		unlinked := &ArtistEntity{ID: uuid.New()}
+
+		assert.True(t, performer.CanEditArtist(linked))
+		assert.False(t, performer.CanEditArtist(unlinked))
+		assert.False(t, host.CanEditArtist(linked))
+		assert.True(t, admin.CanEditArtist(unlinked))
+	})
+}
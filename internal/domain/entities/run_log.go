@@ -0,0 +1,134 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+)
+
+// TimeSlotRunEntity is one entry of an event's run log: a timeslot going on
+// stage and, once it has, coming off. EndedAt is nil while it is still on.
+type TimeSlotRunEntity struct {
+	ID         uuid.UUID
+	TimeSlotID uuid.UUID
+	StartedAt  time.Time
+	EndedAt    *time.Time
+}
+
+func NewTimeSlotRunEntity(runModel models.TimeslotRun) *TimeSlotRunEntity {
+	return &TimeSlotRunEntity{
+		ID:         runModel.ID,
+		TimeSlotID: runModel.TimeslotID,
+		StartedAt:  runModel.StartedAt,
+		EndedAt:    runModel.EndedAt,
+	}
+}
+
+func (r *TimeSlotRunEntity) IsOpen() bool {
+	return r.EndedAt == nil
+}
+
+// Duration is how long the run lasted, and false while it is still open.
+func (r *TimeSlotRunEntity) Duration() (time.Duration, bool) {
+	if r.EndedAt == nil {
+		return 0, false
+	}
+	return r.EndedAt.Sub(r.StartedAt), true
+}
+
+func (e *EventEntity) RunLog() []*TimeSlotRunEntity {
+	return e.runLog
+}
+
+// OpenRun is the run of the timeslot on stage, if any.
+func (e *EventEntity) OpenRun() *TimeSlotRunEntity {
+	for _, run := range e.runLog {
+		if run.IsOpen() {
+			return run
+		}
+	}
+	return nil
+}
+
+// Drift is how far the show is running behind its plan, or ahead of it when
+// negative. It is measured at the next timeslot still to go on, or at the
+// last one to have gone on when none are left.
+func (e *EventEntity) Drift() time.Duration {
+	return e.drift
+}
+
+// ApplyRunLog takes the event's run log, ordered by start, and reprojects the
+// lineup from the real clock. Timeslots that have run show their actual start.
+// Those after the last one to go on follow it back to back: the one on stage
+// is given at least its planned duration, and nothing is projected to start
+// before now. Before the first run the plan stands.
+func (e *EventEntity) ApplyRunLog(runs []*TimeSlotRunEntity, now time.Time) {
+	e.runLog = runs
+
+	lastIndex := -1
+	var last *TimeSlotRunEntity
+	for _, run := range runs {
+		timeslot := e.TimeSlotByID(run.TimeSlotID)
+		if timeslot == nil {
+			continue
+		}
+		startedAt := run.StartedAt
+		timeslot.StartedAt = &startedAt
+		timeslot.EndedAt = run.EndedAt
+		timeslot.TimeDisplay = startedAt
+
+		lastIndex = e.TimeSlotIndex(run.TimeSlotID)
+		last = run
+	}
+
+	if last == nil {
+		e.drift = 0
+		return
+	}
+
+	changeover := e.SlotPolicy.Changeover()
+	var cursor time.Time
+	if last.EndedAt == nil {
+		cursor = latest(last.StartedAt.Add(e.timeSlots[lastIndex].Duration), now).Add(changeover)
+	} else {
+		cursor = latest(last.EndedAt.Add(changeover), now)
+	}
+
+	e.drift = e.timeSlots[lastIndex].Drift()
+	next := true
+	for _, timeslot := range e.timeSlots[lastIndex+1:] {
+		if timeslot.StartedAt != nil {
+			continue
+		}
+		timeslot.TimeDisplay = cursor
+		cursor = cursor.Add(timeslot.Duration).Add(changeover)
+
+		if next {
+			e.drift = timeslot.Drift()
+			next = false
+		}
+	}
+}
+
+// Drift is how much later than planned the timeslot went on, or is projected
+// to go on.
+func (t *TimeSlotEntity) Drift() time.Duration {
+	return t.TimeDisplay.Sub(t.PlannedTime)
+}
+
+// ActualDuration is how long the timeslot was on stage, and false until it has
+// come off.
+func (t *TimeSlotEntity) ActualDuration() (time.Duration, bool) {
+	if t.StartedAt == nil || t.EndedAt == nil {
+		return 0, false
+	}
+	return t.EndedAt.Sub(*t.StartedAt), true
+}
+
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,108 @@
+package entities
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEventEntityRunLog(t *testing.T) {
+
+	startTime, err := time.Parse(time.RFC3339, "2025-01-01T20:00:00Z")
+	if err != nil {
+		t.Fatal(err)
+	}
+	at := func(minutes int) time.Time {
+		return startTime.Add(time.Duration(minutes) * time.Minute)
+	}
+
+	first := models.Timeslot{ID: uuid.New(), SortKey: "a0", SongCount: 1}
+	second := models.Timeslot{ID: uuid.New(), SortKey: "a1", SongCount: 1}
+	third := models.Timeslot{ID: uuid.New(), SortKey: "a2", SongCount: 1}
+	newEvent := func() *EventEntity {
+		return NewEventEntity(models.Event{ID: uuid.New(), StartTime: startTime, MinutesPerSong: 3, BaseSlotMinutes: 2}, []*NewEventEntitySlotsArgs{
+			{TimeSlot: first, Artist: &models.Artist{ID: uuid.New()}},
+			{TimeSlot: second, Artist: &models.Artist{ID: uuid.New()}},
+			{TimeSlot: third, Artist: &models.Artist{ID: uuid.New()}},
+		}, nil)
+	}
+	displayMinutes := func(event *EventEntity) []time.Duration {
+		minutes := make([]time.Duration, 0, len(event.TimeSlots()))
+		for _, timeslot := range event.TimeSlots() {
+			minutes = append(minutes, timeslot.TimeDisplay.Sub(startTime)/time.Minute)
+		}
+		return minutes
+	}
+
+	t.Run("the plan stands until the show starts", func(t *testing.T) {
+		event := newEvent()
+		event.ApplyRunLog(nil, at(30))
+
+		assert.Equal(t, []time.Duration{0, 5, 10}, displayMinutes(event))
+		assert.Equal(t, time.Duration(0), event.Drift())
+		assert.Nil(t, event.OpenRun())
+	})
+
+	t.Run("a late start pushes the rest of the lineup back", func(t *testing.T) {
+		event := newEvent()
+		startedAt := at(3)
+		event.ApplyRunLog([]*TimeSlotRunEntity{
+			{ID: uuid.New(), TimeSlotID: first.ID, StartedAt: startedAt},
+		}, at(4))
+
+		assert.Equal(t, []time.Duration{3, 8, 13}, displayMinutes(event))
+		assert.Equal(t, 3*time.Minute, event.Drift())
+		assert.Equal(t, at(0), event.TimeSlots()[0].PlannedTime)
+		assert.Equal(t, first.ID, event.OpenRun().TimeSlotID)
+
+		_, ended := event.TimeSlots()[0].ActualDuration()
+		assert.False(t, ended)
+	})
+
+	t.Run("an overrun projects the next timeslot from now", func(t *testing.T) {
+		event := newEvent()
+		event.ApplyRunLog([]*TimeSlotRunEntity{
+			{ID: uuid.New(), TimeSlotID: first.ID, StartedAt: at(3)},
+		}, at(12))
+
+		assert.Equal(t, []time.Duration{3, 12, 17}, displayMinutes(event))
+		assert.Equal(t, 7*time.Minute, event.Drift())
+	})
+
+	t.Run("finished timeslots keep their actual duration", func(t *testing.T) {
+		event := newEvent()
+		endedAt := at(7)
+		event.ApplyRunLog([]*TimeSlotRunEntity{
+			{ID: uuid.New(), TimeSlotID: first.ID, StartedAt: at(0), EndedAt: &endedAt},
+		}, at(8))
+
+		assert.Equal(t, []time.Duration{0, 8, 13}, displayMinutes(event))
+		assert.Equal(t, 3*time.Minute, event.Drift())
+		assert.Nil(t, event.OpenRun())
+
+		duration, ended := event.TimeSlots()[0].ActualDuration()
+		assert.True(t, ended)
+		assert.Equal(t, 7*time.Minute, duration)
+
+		duration, ended = event.RunLog()[0].Duration()
+		assert.True(t, ended)
+		assert.Equal(t, 7*time.Minute, duration)
+	})
+
+	t.Run("drift is measured at the last timeslot once none are left", func(t *testing.T) {
+		event := newEvent()
+		firstEnded, secondEnded := at(4), at(12)
+		event.ApplyRunLog([]*TimeSlotRunEntity{
+			{ID: uuid.New(), TimeSlotID: first.ID, StartedAt: at(0), EndedAt: &firstEnded},
+			{ID: uuid.New(), TimeSlotID: second.ID, StartedAt: at(4), EndedAt: &secondEnded},
+			{ID: uuid.New(), TimeSlotID: third.ID, StartedAt: at(12)},
+		}, at(13))
+
+		assert.Equal(t, []time.Duration{0, 4, 12}, displayMinutes(event))
+		assert.Equal(t, 2*time.Minute, event.Drift())
+		assert.Equal(t, -time.Minute, event.TimeSlots()[1].Drift())
+	})
+}
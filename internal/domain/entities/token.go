@@ -0,0 +1,35 @@
+package entities
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+const secretTokenBytes = 32
+
+// NewSecretToken returns a random, URL-safe token for sessions and links.
+// Only its HashToken value should ever be stored.
+func NewSecretToken() (string, error) {
+	b := make([]byte, secretTokenBytes)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken is the hex SHA-256 of token, matching
+// encode(sha256(token), 'hex') in Postgres.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// CodeChallenge is the PKCE S256 challenge for verifier.
+func CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSecretToken(t *testing.T) {
+	t.Run("tokens are long and unique", func(t *testing.T) {
+		first, err := NewSecretToken()
+		assert.NoError(t, err)
+		second, err := NewSecretToken()
+		assert.NoError(t, err)
+
+		assert.Len(t, first, 43)
+		assert.NotEqual(t, first, second)
+	})
+}
+
+func TestHashToken(t *testing.T) {
+	t.Run("hash is stable hex sha256", func(t *testing.T) {
+		assert.Equal(t, "2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae", HashToken("foo"))
+		assert.Equal(t, HashToken("test-token"), HashToken("test-token"))
+		assert.NotEqual(t, HashToken("test-token"), "test-token")
+	})
+}
+
+func TestCodeChallenge(t *testing.T) {
+	t.Run("matches the RFC 7636 example", func(t *testing.T) {
+		verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+		assert.Equal(t, "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM", CodeChallenge(verifier))
+	})
+}
@@ -1,7 +1,6 @@
 package entities
 
 import (
-	"errors"
 	"fmt"
 
 	"github.com/google/uuid"
@@ -9,10 +8,10 @@ import (
 )
 
 var (
-	ErrUserNotFound    = errors.New("user not found")
-	ErrEmailInUse      = errors.New("email in use")
-	ErrUserHandleInUse = errors.New("handle in use")
-	ErrUserClaimed     = errors.New("user already claimed")
+	ErrUserNotFound    = NewNotFoundError("user not found")
+	ErrEmailInUse      = NewConflictError("email in use")
+	ErrUserHandleInUse = NewConflictError("handle in use")
+	ErrUserClaimed     = NewConflictError("user already claimed")
 )
 
 type UserEntity struct {
@@ -23,7 +22,9 @@ type UserEntity struct {
 	EmailVerified bool
 	Claimed       bool
 	Handle        string
+	Role          Role
 	Avatar        *ImageEntity
+	Version       int32
 }
 
 func NewUserEntity(userModel models.User, imageEntity *ImageEntity) *UserEntity {
@@ -35,7 +36,9 @@ func NewUserEntity(userModel models.User, imageEntity *ImageEntity) *UserEntity
 		EmailVerified: userModel.EmailVerified,
 		Claimed:       userModel.Claimed,
 		Handle:        userModel.UserHandle,
+		Role:          Role(userModel.UserRole),
 		Avatar:        imageEntity,
+		Version:       userModel.Version,
 	}
 }
 
@@ -7,22 +7,35 @@ import (
 	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
 )
 
+var (
+	ErrSessionNotFound         = NewNotFoundError("session not found")
+	ErrImpersonationNotAllowed = NewForbiddenError("impersonation not allowed")
+	ErrNotImpersonating        = NewConflictError("session is not impersonating")
+)
+
+// SessionToken is only set when the caller presented or was just issued the
+// token; storage keeps a hash.
 type UserContextEntity struct {
+	SessionID      uuid.UUID
 	SessionToken   string
 	UserID         uuid.UUID
 	User           *UserEntity
 	userExpired    bool
 	expiresAt      time.Time
+	lastSeenAt     time.Time
+	ipAddress      *string
 	impersonatorID *uuid.UUID
 }
 
 func NewUserContextEntity(user *UserEntity, userSessionModel models.UserSession) *UserContextEntity {
 	return &UserContextEntity{
-		SessionToken:   userSessionModel.Token,
+		SessionID:      userSessionModel.ID,
 		UserID:         user.ID,
 		User:           user,
 		userExpired:    userSessionModel.UserExpired,
 		expiresAt:      userSessionModel.ExpiresAt,
+		lastSeenAt:     userSessionModel.LastSeenAt,
+		ipAddress:      userSessionModel.IpAddress,
 		impersonatorID: userSessionModel.ImpersonatorID,
 	}
 }
@@ -34,3 +47,46 @@ func (uc *UserContextEntity) IsExpired() bool {
 func (uc *UserContextEntity) ExpiresAt() time.Time {
 	return uc.expiresAt
 }
+
+// ImpersonatorID is the admin acting as User, or nil for an ordinary session.
+func (uc *UserContextEntity) ImpersonatorID() *uuid.UUID {
+	return uc.impersonatorID
+}
+
+func (uc *UserContextEntity) IsImpersonated() bool {
+	return uc.impersonatorID != nil
+}
+
+// NeedsTouch reports whether the session's activity should be recorded again,
+// either because it was last seen more than interval ago or because it is now
+// being used from a different address.
+func (uc *UserContextEntity) NeedsTouch(ipAddress string, interval time.Duration) bool {
+	if uc.IsExpired() {
+		return false
+	}
+	if uc.ipAddress == nil || *uc.ipAddress != ipAddress {
+		return true
+	}
+	return time.Since(uc.lastSeenAt) >= interval
+}
+
+// SessionEntity describes a session for listing without exposing its token.
+type SessionEntity struct {
+	ID             uuid.UUID
+	ImpersonatorID *uuid.UUID
+	IPAddress      *string
+	CreatedAt      *time.Time
+	LastSeenAt     time.Time
+	ExpiresAt      time.Time
+}
+
+func NewSessionEntity(userSessionModel models.UserSession) *SessionEntity {
+	return &SessionEntity{
+		ID:             userSessionModel.ID,
+		ImpersonatorID: userSessionModel.ImpersonatorID,
+		IPAddress:      userSessionModel.IpAddress,
+		CreatedAt:      userSessionModel.CreatedAt,
+		LastSeenAt:     userSessionModel.LastSeenAt,
+		ExpiresAt:      userSessionModel.ExpiresAt,
+	}
+}
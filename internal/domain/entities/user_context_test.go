@@ -38,14 +38,14 @@ func TestNewUserContextEntity(t *testing.T) {
 		userSessionModel := models.UserSession{
 			ID:          sessionID,
 			UserID:      userId,
-			Token:       "test-token",
+			TokenHash:   "test-token",
 			ExpiresAt:   expireTime,
 			UserExpired: false,
 		}
 
 		userContextEntity := NewUserContextEntity(userEntity, userSessionModel)
 
-		assert.Equal(t, userContextEntity.SessionToken, "test-token")
+		assert.Empty(t, userContextEntity.SessionToken)
 		assert.Equal(t, userContextEntity.UserID, userId)
 		assert.Equal(t, userContextEntity.User, userEntity)
 		assert.Equal(t, userContextEntity.userExpired, false)
@@ -62,14 +62,14 @@ func TestNewUserContextEntity(t *testing.T) {
 		userSessionModel := models.UserSession{
 			ID:          sessionID,
 			UserID:      userId,
-			Token:       "test-token",
+			TokenHash:   "test-token",
 			ExpiresAt:   expireTime,
 			UserExpired: false,
 		}
 
 		userContextEntity := NewUserContextEntity(userEntity, userSessionModel)
 
-		assert.Equal(t, userContextEntity.SessionToken, "test-token")
+		assert.Empty(t, userContextEntity.SessionToken)
 		assert.Equal(t, userContextEntity.UserID, userId)
 		assert.Equal(t, userContextEntity.User, userEntity)
 		assert.Equal(t, userContextEntity.userExpired, false)
@@ -86,14 +86,14 @@ func TestNewUserContextEntity(t *testing.T) {
 		userSessionModel := models.UserSession{
 			ID:          sessionID,
 			UserID:      userId,
-			Token:       "test-token",
+			TokenHash:   "test-token",
 			ExpiresAt:   expireTime,
 			UserExpired: true,
 		}
 
 		userContextEntity := NewUserContextEntity(userEntity, userSessionModel)
 
-		assert.Equal(t, userContextEntity.SessionToken, "test-token")
+		assert.Empty(t, userContextEntity.SessionToken)
 		assert.Equal(t, userContextEntity.UserID, userId)
 		assert.Equal(t, userContextEntity.User, userEntity)
 		assert.Equal(t, userContextEntity.userExpired, true)
@@ -102,3 +102,46 @@ func TestNewUserContextEntity(t *testing.T) {
 		assert.Equal(t, userContextEntity.IsExpired(), true)
 	})
 }
+
+func TestUserContextNeedsTouch(t *testing.T) {
+
+	userEntity := &UserEntity{ID: uuid.New()}
+	ip := "203.0.113.7"
+
+	newContext := func(lastSeenAt time.Time, ipAddress *string, expired bool) *UserContextEntity {
+		return NewUserContextEntity(userEntity, models.UserSession{
+			ID:          uuid.New(),
+			UserID:      userEntity.ID,
+			TokenHash:   "test-token",
+			ExpiresAt:   time.Now().Add(time.Hour),
+			UserExpired: expired,
+			LastSeenAt:  lastSeenAt,
+			IpAddress:   ipAddress,
+		})
+	}
+
+	t.Run("recently seen from the same address", func(t *testing.T) {
+		userContext := newContext(time.Now(), &ip, false)
+		assert.False(t, userContext.NeedsTouch(ip, 5*time.Minute))
+	})
+
+	t.Run("seen longer ago than the interval", func(t *testing.T) {
+		userContext := newContext(time.Now().Add(-10*time.Minute), &ip, false)
+		assert.True(t, userContext.NeedsTouch(ip, 5*time.Minute))
+	})
+
+	t.Run("address not recorded yet", func(t *testing.T) {
+		userContext := newContext(time.Now(), nil, false)
+		assert.True(t, userContext.NeedsTouch(ip, 5*time.Minute))
+	})
+
+	t.Run("address changed", func(t *testing.T) {
+		userContext := newContext(time.Now(), &ip, false)
+		assert.True(t, userContext.NeedsTouch("198.51.100.1", 5*time.Minute))
+	})
+
+	t.Run("expired sessions are never extended", func(t *testing.T) {
+		userContext := newContext(time.Now().Add(-time.Hour), &ip, true)
+		assert.False(t, userContext.NeedsTouch(ip, 5*time.Minute))
+	})
+}
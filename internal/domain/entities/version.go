@@ -0,0 +1,15 @@
+package entities
+
+var (
+	ErrVersionMismatch = NewPreconditionFailedError("resource has been modified")
+)
+
+// CheckVersion compares the version a client last read with the current one.
+// A nil expected version means the client did not ask for the check.
+func CheckVersion(current int32, expected *int32) error {
+	if expected != nil && *expected != current {
+		return ErrVersionMismatch
+	}
+
+	return nil
+}
@@ -0,0 +1,23 @@
+package entities
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckVersion(t *testing.T) {
+	version := func(v int32) *int32 { return &v }
+
+	t.Run("no expected version", func(t *testing.T) {
+		assert.NoError(t, CheckVersion(3, nil))
+	})
+
+	t.Run("matching version", func(t *testing.T) {
+		assert.NoError(t, CheckVersion(3, version(3)))
+	})
+
+	t.Run("stale version", func(t *testing.T) {
+		assert.ErrorIs(t, CheckVersion(4, version(3)), ErrVersionMismatch)
+	})
+}
@@ -0,0 +1,26 @@
+package entities
+
+// WalkInEntity is the outcome of putting a walk-in on an event's lineup. The
+// candidates are other artists with similar titles, which may be the same
+// person and can be merged later.
+type WalkInEntity struct {
+	Event         *EventEntity
+	Artist        *ArtistEntity
+	ArtistCreated bool
+	Candidates    []*ArtistEntity
+}
+
+func NewWalkInEntity(artist *ArtistEntity, artistCreated bool, similarArtists []*ArtistEntity) *WalkInEntity {
+	candidates := make([]*ArtistEntity, 0, len(similarArtists))
+	for _, similarArtist := range similarArtists {
+		if similarArtist.ID != artist.ID {
+			candidates = append(candidates, similarArtist)
+		}
+	}
+
+	return &WalkInEntity{
+		Artist:        artist,
+		ArtistCreated: artistCreated,
+		Candidates:    candidates,
+	}
+}
@@ -0,0 +1,13 @@
+package external
+
+import (
+	"context"
+
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+)
+
+type IdentityProvider interface {
+	Name() string
+	AuthorizationURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error)
+	Exchange(ctx context.Context, code string, codeVerifier string) (*entities.IdentityClaims, error)
+}
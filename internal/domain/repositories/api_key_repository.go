@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+)
+
+type APIKeyRepository interface {
+	GetAPIKeyByID(ctx context.Context, querier models.Querier, apiKeyID uuid.UUID) (*entities.APIKeyEntity, error)
+	GetActiveAPIKeyByHash(ctx context.Context, querier models.Querier, keyHash string) (*entities.APIKeyEntity, error)
+	GetAPIKeys(ctx context.Context, querier models.Querier) ([]*entities.APIKeyEntity, error)
+	CreateAPIKey(ctx context.Context, querier models.Querier, apiKey *entities.APIKeyEntity) (*entities.APIKeyEntity, error)
+	RotateAPIKey(ctx context.Context, querier models.Querier, apiKeyID uuid.UUID, keyHash string) (*entities.APIKeyEntity, error)
+	RevokeAPIKey(ctx context.Context, querier models.Querier, apiKeyID uuid.UUID) (*entities.APIKeyEntity, error)
+	TouchAPIKey(ctx context.Context, querier models.Querier, apiKeyID uuid.UUID) error
+}
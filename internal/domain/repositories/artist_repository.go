@@ -10,9 +10,9 @@ import (
 
 type ArtistRepository interface {
 	GetArtistByID(ctx context.Context, querier models.Querier, id uuid.UUID) (*entities.ArtistEntity, error)
-	GetArtistsByTitle(ctx context.Context, querier models.Querier, title string) ([]*entities.ArtistEntity, error)
-	GetAllArtists(ctx context.Context, querier models.Querier) ([]*entities.ArtistEntity, error)
+	GetArtistsByTitle(ctx context.Context, querier models.Querier, organizationID uuid.UUID, title string) ([]*entities.ArtistEntity, error)
+	GetAllArtists(ctx context.Context, querier models.Querier, organizationID uuid.UUID) ([]*entities.ArtistEntity, error)
 	CreateArtist(ctx context.Context, querier models.Querier, artist *entities.ArtistEntity) (*entities.ArtistEntity, error)
 	UpdateArtist(ctx context.Context, querier models.Querier, artist *entities.ArtistEntity) (*entities.ArtistEntity, error)
-	DeleteArtist(ctx context.Context, querier models.Querier, id uuid.UUID) error
+	DeleteArtist(ctx context.Context, querier models.Querier, artist *entities.ArtistEntity) error
 }
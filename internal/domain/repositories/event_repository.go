@@ -11,14 +11,22 @@ import (
 
 type EventRepository interface {
 	GetEventByID(ctx context.Context, querier models.Querier, id uuid.UUID) (*entities.EventEntity, error)
-	GetEvents(ctx context.Context, querier models.Querier, afterDate time.Time) ([]*entities.EventEntity, error)
+	GetCurrentEvent(ctx context.Context, querier models.Querier, organizationID uuid.UUID, venueID *uuid.UUID, now time.Time) (*entities.EventEntity, error)
+	GetEvents(ctx context.Context, querier models.Querier, organizationID uuid.UUID, afterDate time.Time) ([]*entities.EventEntity, error)
+	LockEvent(ctx context.Context, querier models.Querier, id uuid.UUID) error
 	CreateEvent(ctx context.Context, querier models.Querier, event *entities.EventEntity) (*entities.EventEntity, error)
 	UpdateEvent(ctx context.Context, querier models.Querier, event *entities.EventEntity) (*entities.EventEntity, error)
-	DeleteEvent(ctx context.Context, querier models.Querier, id uuid.UUID) error
+	DeleteEvent(ctx context.Context, querier models.Querier, event *entities.EventEntity) error
+	NextChangeSeq(ctx context.Context, querier models.Querier, id uuid.UUID) (int64, error)
 	UpdateTimeSlot(ctx context.Context, querier models.Querier, timeslot *entities.TimeSlotEntity) error
-	AddArtistToEvent(ctx context.Context, querier models.Querier, eventID uuid.UUID, artistID uuid.UUID, sortKet string, artistNameOverride *string) error
+	DeleteTimeSlot(ctx context.Context, querier models.Querier, eventID uuid.UUID, timeslot *entities.TimeSlotEntity) error
+	SetTimeSlotSortKeys(ctx context.Context, querier models.Querier, eventID uuid.UUID, timeslots []*entities.TimeSlotEntity) error
+	AddArtistToEvent(ctx context.Context, querier models.Querier, eventID uuid.UUID, artistID uuid.UUID, sortKet string, artistNameOverride *string, songCount int32) error
+	AddLineupItem(ctx context.Context, querier models.Querier, eventID uuid.UUID, item *entities.TimeSlotEntity) error
 	RemoveArtistFromEvent(ctx context.Context, querier models.Querier, eventID uuid.UUID, artistID uuid.UUID) error
 	CreateTimeslotMarker(ctx context.Context, querier models.Querier, eventID uuid.UUID, markerEntity *entities.TimeMarkerEntity) error
 	UpdateTimeslotMarker(ctx context.Context, querier models.Querier, markerEntity *entities.TimeMarkerEntity) error
 	DeleteTimeslotMarker(ctx context.Context, querier models.Querier, timeslotMarkerID uuid.UUID) error
+	StartTimeSlotRun(ctx context.Context, querier models.Querier, eventID uuid.UUID, run *entities.TimeSlotRunEntity) error
+	EndTimeSlotRuns(ctx context.Context, querier models.Querier, eventID uuid.UUID, endedAt time.Time) error
 }
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+)
+
+type IdentityRepository interface {
+	GetIdentity(ctx context.Context, querier models.Querier, provider string, subject string) (*entities.IdentityEntity, error)
+	GetIdentitiesByUserID(ctx context.Context, querier models.Querier, userID uuid.UUID) ([]*entities.IdentityEntity, error)
+	CreateIdentity(ctx context.Context, querier models.Querier, identity *entities.IdentityEntity) (*entities.IdentityEntity, error)
+	CreateLoginRequest(ctx context.Context, querier models.Querier, loginRequest *entities.LoginRequestEntity) (*entities.LoginRequestEntity, error)
+	ConsumeLoginRequest(ctx context.Context, querier models.Querier, stateHash string) (*entities.LoginRequestEntity, error)
+	DeleteExpiredLoginRequests(ctx context.Context, querier models.Querier) error
+}
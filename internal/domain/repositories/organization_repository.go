@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+)
+
+type OrganizationRepository interface {
+	GetOrganizationByID(ctx context.Context, querier models.Querier, id uuid.UUID) (*entities.OrganizationEntity, error)
+	GetOrganizationByVenueID(ctx context.Context, querier models.Querier, venueID uuid.UUID) (*entities.OrganizationEntity, error)
+	GetOrganizationByEventID(ctx context.Context, querier models.Querier, eventID uuid.UUID) (*entities.OrganizationEntity, error)
+	GetOrganizationByArtistID(ctx context.Context, querier models.Querier, artistID uuid.UUID) (*entities.OrganizationEntity, error)
+	GetOrganizationsByUserID(ctx context.Context, querier models.Querier, userID uuid.UUID) ([]*entities.OrganizationEntity, error)
+	CreateOrganization(ctx context.Context, querier models.Querier, organization *entities.OrganizationEntity) (*entities.OrganizationEntity, error)
+	UpdateOrganization(ctx context.Context, querier models.Querier, organization *entities.OrganizationEntity) (*entities.OrganizationEntity, error)
+	GetOrganizationMembers(ctx context.Context, querier models.Querier, organizationID uuid.UUID) ([]*entities.UserEntity, error)
+	IsOrganizationMember(ctx context.Context, querier models.Querier, organizationID uuid.UUID, userID uuid.UUID) (bool, error)
+	AddOrganizationMember(ctx context.Context, querier models.Querier, organizationID uuid.UUID, userID uuid.UUID) error
+	RemoveOrganizationMember(ctx context.Context, querier models.Querier, organizationID uuid.UUID, userID uuid.UUID) error
+	GetVenueByID(ctx context.Context, querier models.Querier, id uuid.UUID) (*entities.VenueEntity, error)
+	GetVenuesByOrganizationID(ctx context.Context, querier models.Querier, organizationID uuid.UUID) ([]*entities.VenueEntity, error)
+	CreateVenue(ctx context.Context, querier models.Querier, venue *entities.VenueEntity) (*entities.VenueEntity, error)
+	UpdateVenue(ctx context.Context, querier models.Querier, venue *entities.VenueEntity) (*entities.VenueEntity, error)
+	DeleteVenue(ctx context.Context, querier models.Querier, id uuid.UUID) error
+	GetEventTypeWindows(ctx context.Context, querier models.Querier, organizationID uuid.UUID) ([]*entities.EventTypeWindowEntity, error)
+	SetEventTypeWindow(ctx context.Context, querier models.Querier, window *entities.EventTypeWindowEntity) (*entities.EventTypeWindowEntity, error)
+	DeleteEventTypeWindow(ctx context.Context, querier models.Querier, organizationID uuid.UUID, eventType string) error
+}
@@ -11,6 +11,6 @@ import (
 type ReferenceLinkRepository interface {
 	CreateReferenceLink(ctx context.Context, querier models.Querier, refLink *entities.ReferenceLinkEntity) (*entities.ReferenceLinkEntity, error)
 	GetReferenceLinkByID(ctx context.Context, querier models.Querier, id uuid.UUID) (*entities.ReferenceLinkEntity, error)
-	GetReferenceLinkByToken(ctx context.Context, querier models.Querier, token string) (*entities.ReferenceLinkEntity, error)
+	GetReferenceLinkByTokenHash(ctx context.Context, querier models.Querier, tokenHash string) (*entities.ReferenceLinkEntity, error)
 	DeleteReferenceLink(ctx context.Context, querier models.Querier, refLink *entities.ReferenceLinkEntity) error
 }
@@ -13,9 +13,16 @@ type UserRepository interface {
 	GetUserByID(ctx context.Context, querier models.Querier, userId uuid.UUID) (*entities.UserEntity, error)
 	GetUserByEmail(ctx context.Context, querier models.Querier, email string) (*entities.UserEntity, error)
 	GetUserByHandle(ctx context.Context, querier models.Querier, userHandle string) (*entities.UserEntity, error)
-	GetUserContextBySessionToken(ctx context.Context, querier models.Querier, sessionToken string) (*entities.UserContextEntity, error)
+	GetUserContextBySessionTokenHash(ctx context.Context, querier models.Querier, tokenHash string) (*entities.UserContextEntity, error)
 	CreateUser(ctx context.Context, querier models.Querier, user *entities.UserEntity) (*entities.UserEntity, error)
 	UpdateUser(ctx context.Context, querier models.Querier, user *entities.UserEntity) (*entities.UserEntity, error)
-	CreateSession(ctx context.Context, querier models.Querier, user *entities.UserEntity, token string, expiresAt time.Time) (*entities.UserContextEntity, error)
+	CreateSession(ctx context.Context, querier models.Querier, user *entities.UserEntity, tokenHash string, expiresAt time.Time) (*entities.UserContextEntity, error)
+	CreateImpersonationSession(ctx context.Context, querier models.Querier, user *entities.UserEntity, impersonatorID uuid.UUID, tokenHash string, expiresAt time.Time) (*entities.UserContextEntity, error)
+	CreateImpersonationAudit(ctx context.Context, querier models.Querier, impersonatorID uuid.UUID, userID uuid.UUID, sessionID uuid.UUID, method string, path string) error
 	SetAvatarImage(ctx context.Context, querier models.Querier, image *entities.ImageEntity, user *entities.UserEntity) (*entities.UserEntity, error)
+	SetUserRole(ctx context.Context, querier models.Querier, userID uuid.UUID, role entities.Role) (*entities.UserEntity, error)
+	GetActiveSessions(ctx context.Context, querier models.Querier, userID uuid.UUID) ([]*entities.SessionEntity, error)
+	TouchSession(ctx context.Context, querier models.Querier, sessionID uuid.UUID, ipAddress string, expiresAt time.Time) error
+	ExpireSession(ctx context.Context, querier models.Querier, userID uuid.UUID, sessionID uuid.UUID) error
+	ExpireAllSessions(ctx context.Context, querier models.Querier, userID uuid.UUID) error
 }
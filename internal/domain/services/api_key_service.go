@@ -0,0 +1,159 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/mcorrigan89/openmic/internal/domain/repositories"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+)
+
+type APIKeyService interface {
+	Authenticate(ctx context.Context, querier models.Querier, key string) (*entities.APIKeyEntity, error)
+	GetAPIKeys(ctx context.Context, querier models.Querier, issuer *entities.APIKeyEntity) ([]*entities.APIKeyEntity, error)
+	CreateAPIKey(ctx context.Context, querier models.Querier, issuer *entities.APIKeyEntity, apiKey *entities.APIKeyEntity) (*entities.APIKeyEntity, error)
+	RotateAPIKey(ctx context.Context, querier models.Querier, issuer *entities.APIKeyEntity, apiKeyID uuid.UUID) (*entities.APIKeyEntity, error)
+	RevokeAPIKey(ctx context.Context, querier models.Querier, issuer *entities.APIKeyEntity, apiKeyID uuid.UUID) (*entities.APIKeyEntity, error)
+}
+
+type apiKeyService struct {
+	apiKeyRepo   repositories.APIKeyRepository
+	bootstrapKey *entities.APIKeyEntity
+}
+
+// NewAPIKeyService accepts bootstrapToken as a key holding every scope so a
+// fresh deployment can create its first stored keys.
+func NewAPIKeyService(apiKeyRepo repositories.APIKeyRepository, bootstrapToken string) *apiKeyService {
+	service := &apiKeyService{apiKeyRepo: apiKeyRepo}
+	if bootstrapToken != "" {
+		service.bootstrapKey = entities.NewBootstrapAPIKeyEntity(bootstrapToken)
+	}
+
+	return service
+}
+
+func (s *apiKeyService) Authenticate(ctx context.Context, querier models.Querier, key string) (*entities.APIKeyEntity, error) {
+	if key == "" {
+		return nil, entities.ErrAPIKeyNotFound
+	}
+
+	keyHash := entities.HashToken(key)
+	if s.bootstrapKey != nil && subtle.ConstantTimeCompare([]byte(keyHash), []byte(s.bootstrapKey.KeyHash)) == 1 {
+		return s.bootstrapKey, nil
+	}
+
+	apiKey, err := s.apiKeyRepo.GetActiveAPIKeyByHash(ctx, querier, keyHash)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.apiKeyRepo.TouchAPIKey(ctx, querier, apiKey.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return apiKey, nil
+}
+
+// GetAPIKeys lists the keys issuer manages. The methods managing keys take
+// the key making the request as issuer, or nil when an admin makes it.
+func (s *apiKeyService) GetAPIKeys(ctx context.Context, querier models.Querier, issuer *entities.APIKeyEntity) ([]*entities.APIKeyEntity, error) {
+	apiKeys, err := s.apiKeyRepo.GetAPIKeys(ctx, querier)
+	if err != nil {
+		return nil, err
+	}
+	if issuer == nil {
+		return apiKeys, nil
+	}
+
+	managed := make([]*entities.APIKeyEntity, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		if issuer.CanManage(apiKey) {
+			managed = append(managed, apiKey)
+		}
+	}
+
+	return managed, nil
+}
+
+// CreateAPIKey stores a new key and returns it with its raw Key set. The raw
+// key cannot be recovered afterwards.
+func (s *apiKeyService) CreateAPIKey(ctx context.Context, querier models.Querier, issuer *entities.APIKeyEntity, apiKey *entities.APIKeyEntity) (*entities.APIKeyEntity, error) {
+	err := apiKey.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if issuer != nil && !issuer.CanGrant(apiKey) {
+		return nil, entities.ErrAPIKeyNotAllowed
+	}
+
+	if apiKey.RateLimitPerMinute <= 0 {
+		apiKey.RateLimitPerMinute = entities.DefaultAPIKeyRateLimit
+	}
+
+	key, err := entities.NewSecretToken()
+	if err != nil {
+		return nil, err
+	}
+	apiKey.KeyHash = entities.HashToken(key)
+
+	created, err := s.apiKeyRepo.CreateAPIKey(ctx, querier, apiKey)
+	if err != nil {
+		return nil, err
+	}
+	created.Key = key
+
+	return created, nil
+}
+
+// RotateAPIKey replaces the secret of a key, keeping its name, scopes and
+// limits. The old secret stops working immediately.
+func (s *apiKeyService) RotateAPIKey(ctx context.Context, querier models.Querier, issuer *entities.APIKeyEntity, apiKeyID uuid.UUID) (*entities.APIKeyEntity, error) {
+	err := s.authorizeIssuer(ctx, querier, issuer, apiKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := entities.NewSecretToken()
+	if err != nil {
+		return nil, err
+	}
+
+	rotated, err := s.apiKeyRepo.RotateAPIKey(ctx, querier, apiKeyID, entities.HashToken(key))
+	if err != nil {
+		return nil, err
+	}
+	rotated.Key = key
+
+	return rotated, nil
+}
+
+func (s *apiKeyService) RevokeAPIKey(ctx context.Context, querier models.Querier, issuer *entities.APIKeyEntity, apiKeyID uuid.UUID) (*entities.APIKeyEntity, error) {
+	err := s.authorizeIssuer(ctx, querier, issuer, apiKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.apiKeyRepo.RevokeAPIKey(ctx, querier, apiKeyID)
+}
+
+// authorizeIssuer checks issuer manages the key it is about to change.
+func (s *apiKeyService) authorizeIssuer(ctx context.Context, querier models.Querier, issuer *entities.APIKeyEntity, apiKeyID uuid.UUID) error {
+	if issuer == nil {
+		return nil
+	}
+
+	apiKey, err := s.apiKeyRepo.GetAPIKeyByID(ctx, querier, apiKeyID)
+	if err != nil {
+		return err
+	}
+
+	if !issuer.CanManage(apiKey) {
+		return entities.ErrAPIKeyNotAllowed
+	}
+
+	return nil
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+	mocks "github.com/mcorrigan89/openmic/internal/mocks/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAuthenticate(t *testing.T) {
+	mockAPIKeyRepo := new(mocks.MockAPIKeyRepository)
+	apiKeyService := NewAPIKeyService(mockAPIKeyRepo, "server-token")
+
+	ctx := context.Background()
+	querier := &models.Queries{}
+
+	t.Run("bootstrap key", func(t *testing.T) {
+		apiKey, err := apiKeyService.Authenticate(ctx, querier, "server-token")
+
+		assert.NoError(t, err)
+		assert.Equal(t, uuid.Nil, apiKey.ID)
+		assert.True(t, apiKey.HasScope(entities.ScopeKeysManage))
+		mockAPIKeyRepo.AssertNotCalled(t, "GetActiveAPIKeyByHash", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("stored key is looked up by hash", func(t *testing.T) {
+		stored := &entities.APIKeyEntity{ID: uuid.New(), Scopes: []entities.APIKeyScope{entities.ScopeEventsWrite}}
+		mockAPIKeyRepo.On("GetActiveAPIKeyByHash", ctx, querier, entities.HashToken("stored-key")).Return(stored, nil)
+		mockAPIKeyRepo.On("TouchAPIKey", ctx, querier, stored.ID).Return(nil)
+
+		apiKey, err := apiKeyService.Authenticate(ctx, querier, "stored-key")
+
+		assert.NoError(t, err)
+		assert.Equal(t, stored.ID, apiKey.ID)
+		mockAPIKeyRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		mockAPIKeyRepo.On("GetActiveAPIKeyByHash", ctx, querier, entities.HashToken("unknown-key")).Return(nil, entities.ErrAPIKeyNotFound)
+
+		apiKey, err := apiKeyService.Authenticate(ctx, querier, "unknown-key")
+
+		assert.ErrorIs(t, err, entities.ErrAPIKeyNotFound)
+		assert.Nil(t, apiKey)
+	})
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	mockAPIKeyRepo := new(mocks.MockAPIKeyRepository)
+	apiKeyService := NewAPIKeyService(mockAPIKeyRepo, "server-token")
+
+	ctx := context.Background()
+	querier := &models.Queries{}
+
+	t.Run("stores only the hash and returns the key once", func(t *testing.T) {
+		var stored *entities.APIKeyEntity
+		mockAPIKeyRepo.On("CreateAPIKey", ctx, querier, mock.MatchedBy(func(apiKey *entities.APIKeyEntity) bool {
+			stored = apiKey
+			return true
+		})).Return(&entities.APIKeyEntity{Name: "display"}, nil)
+
+		apiKey, err := apiKeyService.CreateAPIKey(ctx, querier, nil, &entities.APIKeyEntity{
+			Name:   "display",
+			Scopes: []entities.APIKeyScope{entities.ScopeEventsWrite},
+		})
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, apiKey.Key)
+		assert.Empty(t, stored.Key)
+		assert.Equal(t, entities.HashToken(apiKey.Key), stored.KeyHash)
+		assert.Equal(t, entities.DefaultAPIKeyRateLimit, stored.RateLimitPerMinute)
+	})
+
+	t.Run("unknown scope", func(t *testing.T) {
+		apiKey, err := apiKeyService.CreateAPIKey(ctx, querier, nil, &entities.APIKeyEntity{
+			Name:   "display",
+			Scopes: []entities.APIKeyScope{"events:delete"},
+		})
+
+		assert.ErrorIs(t, err, entities.ErrInvalidAPIKeyScope)
+		assert.Nil(t, apiKey)
+	})
+
+	t.Run("no scopes", func(t *testing.T) {
+		apiKey, err := apiKeyService.CreateAPIKey(ctx, querier, nil, &entities.APIKeyEntity{Name: "display"})
+
+		assert.ErrorIs(t, err, entities.ErrInvalidAPIKeyScope)
+		assert.Nil(t, apiKey)
+	})
+
+	t.Run("an organization key cannot issue a global key", func(t *testing.T) {
+		organizationID := uuid.New()
+		issuer := &entities.APIKeyEntity{Scopes: []entities.APIKeyScope{entities.ScopeKeysManage, entities.ScopeEventsWrite}, OrganizationID: &organizationID}
+
+		apiKey, err := apiKeyService.CreateAPIKey(ctx, querier, issuer, &entities.APIKeyEntity{
+			Name:   "display",
+			Scopes: []entities.APIKeyScope{entities.ScopeEventsWrite},
+		})
+
+		assert.ErrorIs(t, err, entities.ErrAPIKeyNotAllowed)
+		assert.Nil(t, apiKey)
+	})
+
+	t.Run("an issuer cannot grant scopes it lacks", func(t *testing.T) {
+		organizationID := uuid.New()
+		issuer := &entities.APIKeyEntity{Scopes: []entities.APIKeyScope{entities.ScopeKeysManage}, OrganizationID: &organizationID}
+
+		apiKey, err := apiKeyService.CreateAPIKey(ctx, querier, issuer, &entities.APIKeyEntity{
+			Name:           "display",
+			Scopes:         []entities.APIKeyScope{entities.ScopeEventsWrite},
+			OrganizationID: &organizationID,
+		})
+
+		assert.ErrorIs(t, err, entities.ErrAPIKeyNotAllowed)
+		assert.Nil(t, apiKey)
+	})
+}
+
+func TestRotateAPIKey(t *testing.T) {
+	mockAPIKeyRepo := new(mocks.MockAPIKeyRepository)
+	apiKeyService := NewAPIKeyService(mockAPIKeyRepo, "server-token")
+
+	ctx := context.Background()
+	querier := &models.Queries{}
+	apiKeyID := uuid.New()
+
+	var keyHash string
+	mockAPIKeyRepo.On("RotateAPIKey", ctx, querier, apiKeyID, mock.MatchedBy(func(hash string) bool {
+		keyHash = hash
+		return true
+	})).Return(&entities.APIKeyEntity{ID: apiKeyID}, nil)
+
+	apiKey, err := apiKeyService.RotateAPIKey(ctx, querier, nil, apiKeyID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.HashToken(apiKey.Key), keyHash)
+}
+
+func TestManageAPIKeysAsIssuer(t *testing.T) {
+	mockAPIKeyRepo := new(mocks.MockAPIKeyRepository)
+	apiKeyService := NewAPIKeyService(mockAPIKeyRepo, "server-token")
+
+	ctx := context.Background()
+	querier := &models.Queries{}
+
+	organizationID := uuid.New()
+	issuer := &entities.APIKeyEntity{ID: uuid.New(), Scopes: []entities.APIKeyScope{entities.ScopeKeysManage}, OrganizationID: &organizationID}
+	sameOrganization := &entities.APIKeyEntity{ID: uuid.New(), OrganizationID: &organizationID}
+	global := &entities.APIKeyEntity{ID: uuid.New()}
+
+	mockAPIKeyRepo.On("GetAPIKeys", ctx, querier).Return([]*entities.APIKeyEntity{issuer, sameOrganization, global}, nil)
+	mockAPIKeyRepo.On("GetAPIKeyByID", ctx, querier, sameOrganization.ID).Return(sameOrganization, nil)
+	mockAPIKeyRepo.On("GetAPIKeyByID", ctx, querier, global.ID).Return(global, nil)
+
+	t.Run("lists only keys of its organization", func(t *testing.T) {
+		apiKeys, err := apiKeyService.GetAPIKeys(ctx, querier, issuer)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []*entities.APIKeyEntity{issuer, sameOrganization}, apiKeys)
+	})
+
+	t.Run("admins list every key", func(t *testing.T) {
+		apiKeys, err := apiKeyService.GetAPIKeys(ctx, querier, nil)
+
+		assert.NoError(t, err)
+		assert.Len(t, apiKeys, 3)
+	})
+
+	t.Run("revokes a key of its organization", func(t *testing.T) {
+		mockAPIKeyRepo.On("RevokeAPIKey", ctx, querier, sameOrganization.ID).Return(sameOrganization, nil)
+
+		apiKey, err := apiKeyService.RevokeAPIKey(ctx, querier, issuer, sameOrganization.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, sameOrganization, apiKey)
+	})
+
+	t.Run("cannot rotate or revoke a global key", func(t *testing.T) {
+		apiKey, err := apiKeyService.RotateAPIKey(ctx, querier, issuer, global.ID)
+		assert.ErrorIs(t, err, entities.ErrAPIKeyNotAllowed)
+		assert.Nil(t, apiKey)
+
+		apiKey, err = apiKeyService.RevokeAPIKey(ctx, querier, issuer, global.ID)
+		assert.ErrorIs(t, err, entities.ErrAPIKeyNotAllowed)
+		assert.Nil(t, apiKey)
+
+		mockAPIKeyRepo.AssertNotCalled(t, "RotateAPIKey", ctx, querier, global.ID, mock.Anything)
+		mockAPIKeyRepo.AssertNotCalled(t, "RevokeAPIKey", ctx, querier, global.ID)
+	})
+}
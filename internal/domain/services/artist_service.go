@@ -12,11 +12,11 @@ import (
 
 type ArtistService interface {
 	GetArtistByID(ctx context.Context, querier models.Querier, artistID uuid.UUID) (*entities.ArtistEntity, error)
-	GetArtistsByTitle(ctx context.Context, querier models.Querier, title string) ([]*entities.ArtistEntity, error)
-	GetAllArtists(ctx context.Context, querier models.Querier) ([]*entities.ArtistEntity, error)
+	GetArtistsByTitle(ctx context.Context, querier models.Querier, organizationID uuid.UUID, title string) ([]*entities.ArtistEntity, error)
+	GetAllArtists(ctx context.Context, querier models.Querier, organizationID uuid.UUID) ([]*entities.ArtistEntity, error)
 	CreateArtist(ctx context.Context, querier models.Querier, artist *entities.ArtistEntity) (*entities.ArtistEntity, error)
 	UpdateArtist(ctx context.Context, querier models.Querier, artist *entities.ArtistEntity) (*entities.ArtistEntity, error)
-	DeleteArtist(ctx context.Context, querier models.Querier, artistID uuid.UUID) error
+	DeleteArtist(ctx context.Context, querier models.Querier, artist *entities.ArtistEntity) error
 }
 
 type artistService struct {
@@ -38,8 +38,8 @@ func (s *artistService) GetArtistByID(ctx context.Context, querier models.Querie
 	return artist, nil
 }
 
-func (s *artistService) GetArtistsByTitle(ctx context.Context, querier models.Querier, title string) ([]*entities.ArtistEntity, error) {
-	artists, err := s.artistRepo.GetArtistsByTitle(ctx, querier, title)
+func (s *artistService) GetArtistsByTitle(ctx context.Context, querier models.Querier, organizationID uuid.UUID, title string) ([]*entities.ArtistEntity, error) {
+	artists, err := s.artistRepo.GetArtistsByTitle(ctx, querier, organizationID, title)
 	if err != nil {
 		s.logger.Err(err).Ctx(ctx).Msg("Failed to get artist by title")
 		return nil, err
@@ -48,8 +48,8 @@ func (s *artistService) GetArtistsByTitle(ctx context.Context, querier models.Qu
 	return artists, nil
 }
 
-func (s *artistService) GetAllArtists(ctx context.Context, querier models.Querier) ([]*entities.ArtistEntity, error) {
-	artists, err := s.artistRepo.GetAllArtists(ctx, querier)
+func (s *artistService) GetAllArtists(ctx context.Context, querier models.Querier, organizationID uuid.UUID) ([]*entities.ArtistEntity, error) {
+	artists, err := s.artistRepo.GetAllArtists(ctx, querier, organizationID)
 	if err != nil {
 		s.logger.Err(err).Ctx(ctx).Msg("Failed to get all artists")
 		return nil, err
@@ -78,8 +78,8 @@ func (s *artistService) UpdateArtist(ctx context.Context, querier models.Querier
 	return updatedArtist, nil
 }
 
-func (s *artistService) DeleteArtist(ctx context.Context, querier models.Querier, artistID uuid.UUID) error {
-	err := s.artistRepo.DeleteArtist(ctx, querier, artistID)
+func (s *artistService) DeleteArtist(ctx context.Context, querier models.Querier, artist *entities.ArtistEntity) error {
+	err := s.artistRepo.DeleteArtist(ctx, querier, artist)
 	if err != nil {
 		s.logger.Err(err).Ctx(ctx).Msg("Failed to delete artist")
 		return err
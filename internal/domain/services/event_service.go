@@ -2,6 +2,7 @@ package services
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,16 +15,28 @@ import (
 
 type EventService interface {
 	GetEventByID(ctx context.Context, querier models.Querier, eventID uuid.UUID) (*entities.EventEntity, error)
-	GetEvents(ctx context.Context, querier models.Querier) ([]*entities.EventEntity, error)
+	GetCurrentEvent(ctx context.Context, querier models.Querier, organizationID uuid.UUID, venueID *uuid.UUID, now time.Time) (*entities.EventEntity, error)
+	GetEvents(ctx context.Context, querier models.Querier, organizationID uuid.UUID) ([]*entities.EventEntity, error)
+	LockEvent(ctx context.Context, querier models.Querier, eventID uuid.UUID) error
 	CreateEvent(ctx context.Context, querier models.Querier, event *entities.EventEntity) (*entities.EventEntity, error)
 	UpdateEvent(ctx context.Context, querier models.Querier, event *entities.EventEntity) (*entities.EventEntity, error)
-	DeleteEvent(ctx context.Context, querier models.Querier, eventID uuid.UUID) error
+	DeleteEvent(ctx context.Context, querier models.Querier, event *entities.EventEntity) error
+	RecordChange(ctx context.Context, querier models.Querier, kind entities.EventChangeKind, eventID uuid.UUID) (*entities.EventChangeEntity, error)
 	UpdateTimeSlot(ctx context.Context, querier models.Querier, timeslot *entities.TimeSlotEntity) error
-	AddArtistToEvent(ctx context.Context, querier models.Querier, eventID uuid.UUID, artistID uuid.UUID) error
+	DeleteTimeSlot(ctx context.Context, querier models.Querier, eventID uuid.UUID, timeslot *entities.TimeSlotEntity) error
+	ReorderTimeSlots(ctx context.Context, querier models.Querier, eventID uuid.UUID, timeslotIDs []uuid.UUID) error
+	RebalanceTimeSlots(ctx context.Context, querier models.Querier, eventID uuid.UUID) error
+	RebalanceTimeSlotsIfNeeded(ctx context.Context, querier models.Querier, eventID uuid.UUID) error
+	AddArtistToEvent(ctx context.Context, querier models.Querier, eventID uuid.UUID, artistID uuid.UUID, nameOverride *string, songCount int32) error
+	AddLineupItem(ctx context.Context, querier models.Querier, eventID uuid.UUID, item *entities.TimeSlotEntity) error
 	RemoveArtistFromEvent(ctx context.Context, querier models.Querier, eventID uuid.UUID, artistID uuid.UUID) error
-	SetTimeslotMarker(ctx context.Context, querier models.Querier, eventID uuid.UUID, index int, timeslotDisplay string) error
+	SetTimeslotMarker(ctx context.Context, querier models.Querier, eventID uuid.UUID, timeslotID uuid.UUID, markerTime time.Time) error
 	DeleteTimeslotMarker(ctx context.Context, querier models.Querier, eventID uuid.UUID, timeslotMarkerID uuid.UUID) error
-	SetNowPlaying(ctx context.Context, querier models.Querier, eventID uuid.UUID, index int) error
+	SetNowPlaying(ctx context.Context, querier models.Querier, eventID uuid.UUID, timeslotID uuid.UUID, at time.Time) error
+	StartShow(ctx context.Context, querier models.Querier, eventID uuid.UUID, at time.Time) error
+	NextTimeSlot(ctx context.Context, querier models.Querier, eventID uuid.UUID, at time.Time) error
+	PreviousTimeSlot(ctx context.Context, querier models.Querier, eventID uuid.UUID, at time.Time) error
+	EndShow(ctx context.Context, querier models.Querier, eventID uuid.UUID, at time.Time) error
 }
 
 type eventService struct {
@@ -45,11 +58,25 @@ func (s *eventService) GetEventByID(ctx context.Context, querier models.Querier,
 	return event, nil
 }
 
-func (s *eventService) GetEvents(ctx context.Context, querier models.Querier) ([]*entities.EventEntity, error) {
+// GetCurrentEvent finds the event whose current window holds now, or
+// ErrEventNotFound when there is none.
+func (s *eventService) GetCurrentEvent(ctx context.Context, querier models.Querier, organizationID uuid.UUID, venueID *uuid.UUID, now time.Time) (*entities.EventEntity, error) {
+	event, err := s.eventRepo.GetCurrentEvent(ctx, querier, organizationID, venueID, now)
+	if err != nil {
+		if !errors.Is(err, entities.ErrEventNotFound) {
+			s.logger.Err(err).Ctx(ctx).Msg("Failed to get current event")
+		}
+		return nil, err
+	}
+
+	return event, nil
+}
+
+func (s *eventService) GetEvents(ctx context.Context, querier models.Querier, organizationID uuid.UUID) ([]*entities.EventEntity, error) {
 
 	oneDayDuration := 24 * time.Hour
 	date := time.Now().Add(-oneDayDuration)
-	events, err := s.eventRepo.GetEvents(ctx, querier, date)
+	events, err := s.eventRepo.GetEvents(ctx, querier, organizationID, date)
 	if err != nil {
 		s.logger.Err(err).Ctx(ctx).Msg("Failed to get events")
 		return nil, err
@@ -58,7 +85,24 @@ func (s *eventService) GetEvents(ctx context.Context, querier models.Querier) ([
 	return events, nil
 }
 
+// LockEvent holds the event's row lock until the transaction ends, so edits to
+// its lineup are applied one at a time.
+func (s *eventService) LockEvent(ctx context.Context, querier models.Querier, eventID uuid.UUID) error {
+	err := s.eventRepo.LockEvent(ctx, querier, eventID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to lock event")
+		return err
+	}
+
+	return nil
+}
+
 func (s *eventService) CreateEvent(ctx context.Context, querier models.Querier, event *entities.EventEntity) (*entities.EventEntity, error) {
+	err := event.SlotPolicy.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	eventEntity, err := s.eventRepo.CreateEvent(ctx, querier, event)
 	if err != nil {
 		s.logger.Err(err).Ctx(ctx).Msg("Failed to create event")
@@ -69,6 +113,11 @@ func (s *eventService) CreateEvent(ctx context.Context, querier models.Querier,
 }
 
 func (s *eventService) UpdateEvent(ctx context.Context, querier models.Querier, event *entities.EventEntity) (*entities.EventEntity, error) {
+	err := event.SlotPolicy.Validate()
+	if err != nil {
+		return nil, err
+	}
+
 	eventEntity, err := s.eventRepo.UpdateEvent(ctx, querier, event)
 	if err != nil {
 		s.logger.Err(err).Ctx(ctx).Msg("Failed to update event")
@@ -78,8 +127,8 @@ func (s *eventService) UpdateEvent(ctx context.Context, querier models.Querier,
 	return eventEntity, nil
 }
 
-func (s *eventService) DeleteEvent(ctx context.Context, querier models.Querier, eventID uuid.UUID) error {
-	err := s.eventRepo.DeleteEvent(ctx, querier, eventID)
+func (s *eventService) DeleteEvent(ctx context.Context, querier models.Querier, event *entities.EventEntity) error {
+	err := s.eventRepo.DeleteEvent(ctx, querier, event)
 	if err != nil {
 		s.logger.Err(err).Ctx(ctx).Msg("Failed to delete event")
 		return err
@@ -88,8 +137,40 @@ func (s *eventService) DeleteEvent(ctx context.Context, querier models.Querier,
 	return nil
 }
 
+// RecordChange assigns the next sequence number for the event and loads its
+// current state. Call it inside the transaction that made the change so the
+// sequence matches the commit order.
+func (s *eventService) RecordChange(ctx context.Context, querier models.Querier, kind entities.EventChangeKind, eventID uuid.UUID) (*entities.EventChangeEntity, error) {
+	seq, err := s.eventRepo.NextChangeSeq(ctx, querier, eventID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to get next change sequence")
+		return nil, err
+	}
+
+	if kind == entities.EventChangeEventDeleted {
+		return &entities.EventChangeEntity{
+			Kind:    kind,
+			EventID: eventID,
+			Seq:     seq,
+		}, nil
+	}
+
+	event, err := s.eventRepo.GetEventByID(ctx, querier, eventID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
+		return nil, err
+	}
+
+	return entities.NewEventChangeEntity(kind, seq, event), nil
+}
+
 func (s *eventService) UpdateTimeSlot(ctx context.Context, querier models.Querier, timeslot *entities.TimeSlotEntity) error {
-	err := s.eventRepo.UpdateTimeSlot(ctx, querier, timeslot)
+	err := timeslot.Validate()
+	if err != nil {
+		return err
+	}
+
+	err = s.eventRepo.UpdateTimeSlot(ctx, querier, timeslot)
 	if err != nil {
 		s.logger.Err(err).Ctx(ctx).Msg("Failed to update timeslot")
 		return err
@@ -98,39 +179,169 @@ func (s *eventService) UpdateTimeSlot(ctx context.Context, querier models.Querie
 	return nil
 }
 
-func (s *eventService) AddArtistToEvent(ctx context.Context, querier models.Querier, eventID uuid.UUID, artistID uuid.UUID) error {
+func (s *eventService) DeleteTimeSlot(ctx context.Context, querier models.Querier, eventID uuid.UUID, timeslot *entities.TimeSlotEntity) error {
+	err := s.eventRepo.DeleteTimeSlot(ctx, querier, eventID, timeslot)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to delete timeslot")
+		return err
+	}
+
+	return nil
+}
+
+// ReorderTimeSlots puts the event's timeslots in the order of timeslotIDs,
+// which must list every timeslot once.
+func (s *eventService) ReorderTimeSlots(ctx context.Context, querier models.Querier, eventID uuid.UUID, timeslotIDs []uuid.UUID) error {
+	event, err := s.eventRepo.GetEventByID(ctx, querier, eventID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
+		return err
+	}
+
+	timeslots, err := event.OrderTimeSlots(timeslotIDs)
+	if err != nil {
+		return err
+	}
+
+	return s.spreadSortKeys(ctx, querier, eventID, timeslots)
+}
+
+// RebalanceTimeSlots rewrites the event's sort keys evenly, keeping the order.
+func (s *eventService) RebalanceTimeSlots(ctx context.Context, querier models.Querier, eventID uuid.UUID) error {
+	event, err := s.eventRepo.GetEventByID(ctx, querier, eventID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
+		return err
+	}
+
+	return s.spreadSortKeys(ctx, querier, eventID, event.TimeSlots())
+}
+
+// RebalanceTimeSlotsIfNeeded rebalances the event once one of its sort keys
+// has grown too long.
+func (s *eventService) RebalanceTimeSlotsIfNeeded(ctx context.Context, querier models.Querier, eventID uuid.UUID) error {
+	event, err := s.eventRepo.GetEventByID(ctx, querier, eventID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
+		return err
+	}
+
+	if !event.NeedsRebalance() {
+		return nil
+	}
+
+	s.logger.Info().Ctx(ctx).Str("event_id", eventID.String()).Msg("Rebalancing lineup sort keys")
+
+	return s.spreadSortKeys(ctx, querier, eventID, event.TimeSlots())
+}
+
+// spreadSortKeys gives timeslots evenly spaced keys in the order given.
+func (s *eventService) spreadSortKeys(ctx context.Context, querier models.Querier, eventID uuid.UUID, timeslots []*entities.TimeSlotEntity) error {
+	if len(timeslots) == 0 {
+		return nil
+	}
+
+	sortKeys, err := common.NKeysBetween("", "", uint(len(timeslots)))
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to generate sort keys")
+		return err
+	}
+
+	for i, timeslot := range timeslots {
+		timeslot.SortKey = sortKeys[i]
+	}
+
+	err = s.eventRepo.SetTimeSlotSortKeys(ctx, querier, eventID, timeslots)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to set timeslot sort keys")
+		return err
+	}
+
+	return nil
+}
+
+func (s *eventService) AddArtistToEvent(ctx context.Context, querier models.Querier, eventID uuid.UUID, artistID uuid.UUID, nameOverride *string, songCount int32) error {
+
+	event, err := s.eventRepo.GetEventByID(ctx, querier, eventID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
+		return err
+	}
+
+	err = event.CanAddArtist(artistID)
+	if err != nil {
+		return err
+	}
+
+	sortKey, err := s.appendSortKey(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	err = s.eventRepo.AddArtistToEvent(ctx, querier, eventID, artistID, sortKey, nameOverride, songCount)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to add artist to event")
+		return err
+	}
+
+	return nil
+}
+
+// AddLineupItem appends an item other than an artist set, such as a break, to
+// the end of the lineup.
+func (s *eventService) AddLineupItem(ctx context.Context, querier models.Querier, eventID uuid.UUID, item *entities.TimeSlotEntity) error {
+	if item.IsArtistSet() {
+		return entities.ErrInvalidLineupItem
+	}
+
+	err := item.Validate()
+	if err != nil {
+		return err
+	}
 
 	event, err := s.eventRepo.GetEventByID(ctx, querier, eventID)
 	if err != nil {
 		s.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
 		return err
 	}
+
+	item.SortKey, err = s.appendSortKey(ctx, event)
+	if err != nil {
+		return err
+	}
+
+	err = s.eventRepo.AddLineupItem(ctx, querier, eventID, item)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to add lineup item")
+		return err
+	}
+
+	return nil
+}
+
+// appendSortKey returns a sort key after the last item of the event's lineup.
+func (s *eventService) appendSortKey(ctx context.Context, event *entities.EventEntity) (string, error) {
 	timeSlots := event.TimeSlots()
 
 	var sortKey string
+	var err error
 
 	if len(timeSlots) > 0 {
 		lastTimeslot := timeSlots[len(timeSlots)-1]
 		sortKey, err = common.KeyBetween(lastTimeslot.SortKey, "")
 		if err != nil {
 			s.logger.Err(err).Ctx(ctx).Msg("Failed to generate sort key")
-			return err
+			return "", err
 		}
 	} else {
 		sortKey, err = common.KeyBetween("", "")
 		if err != nil {
 			s.logger.Err(err).Ctx(ctx).Msg("Failed to generate empty sort key")
-			return err
+			return "", err
 		}
 	}
 
-	err = s.eventRepo.AddArtistToEvent(ctx, querier, eventID, artistID, sortKey, nil)
-	if err != nil {
-		s.logger.Err(err).Ctx(ctx).Msg("Failed to add artist to event")
-		return err
-	}
-
-	return nil
+	return sortKey, nil
 }
 
 func (s *eventService) RemoveArtistFromEvent(ctx context.Context, querier models.Querier, eventID uuid.UUID, artistID uuid.UUID) error {
@@ -143,38 +354,57 @@ func (s *eventService) RemoveArtistFromEvent(ctx context.Context, querier models
 	return nil
 }
 
-func (s *eventService) SetTimeslotMarker(ctx context.Context, querier models.Querier, eventID uuid.UUID, index int, timeslotDisplay string) error {
+// SetTimeslotMarker pins markerTime to the timeslot. A marker already showing
+// that time moves to the timeslot, replacing the one the timeslot had.
+func (s *eventService) SetTimeslotMarker(ctx context.Context, querier models.Querier, eventID uuid.UUID, timeslotID uuid.UUID, markerTime time.Time) error {
 	event, err := s.eventRepo.GetEventByID(ctx, querier, eventID)
 	if err != nil {
 		s.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
 		return err
 	}
 
-	markerEntity := event.TimeSlotMarkerByDisplay(timeslotDisplay)
-	if markerEntity != nil {
-		markerEntity.Index = index
-		err = s.eventRepo.UpdateTimeslotMarker(ctx, querier, markerEntity)
+	if event.TimeSlotByID(timeslotID) == nil {
+		return entities.ErrTimeslotNotFound
+	}
+
+	markerEntity := event.TimeMarkerByTime(markerTime)
+	slotMarker := event.TimeMarkerOnSlot(timeslotID, entities.TimeMarkerTime)
+
+	if markerEntity == nil {
+		markerEntity = slotMarker
+	} else if slotMarker != nil && slotMarker.ID != markerEntity.ID {
+		err = s.eventRepo.DeleteTimeslotMarker(ctx, querier, slotMarker.ID)
 		if err != nil {
-			s.logger.Err(err).Ctx(ctx).Msg("Failed to update timeslot marker")
+			s.logger.Err(err).Ctx(ctx).Msg("Failed to delete timeslot marker")
 			return err
 		}
+	}
 
-		dupeMarker := event.TimeSlotMarkerDupeByIndex(index, timeslotDisplay)
+	if markerEntity != nil {
+		markerEntity.TimeSlotID = timeslotID
+		markerEntity.Time = &markerTime
+		err = markerEntity.Validate()
+		if err != nil {
+			return err
+		}
 
-		if dupeMarker != nil {
-			err = s.eventRepo.DeleteTimeslotMarker(ctx, querier, dupeMarker.ID)
-			if err != nil {
-				s.logger.Err(err).Ctx(ctx).Msg("Failed to delete timeslot marker")
-				return err
-			}
+		err = s.eventRepo.UpdateTimeslotMarker(ctx, querier, markerEntity)
+		if err != nil {
+			s.logger.Err(err).Ctx(ctx).Msg("Failed to update timeslot marker")
+			return err
 		}
 	} else {
 		newMarker := entities.TimeMarkerEntity{
-			ID:    uuid.New(),
-			Time:  timeslotDisplay,
-			Index: index,
-			Type:  "TIME",
+			ID:         uuid.New(),
+			TimeSlotID: timeslotID,
+			Type:       entities.TimeMarkerTime,
+			Time:       &markerTime,
 		}
+		err = newMarker.Validate()
+		if err != nil {
+			return err
+		}
+
 		err = s.eventRepo.CreateTimeslotMarker(ctx, querier, eventID, &newMarker)
 		if err != nil {
 			s.logger.Err(err).Ctx(ctx).Msg("Failed to create timeslot marker")
@@ -203,43 +433,167 @@ func (s *eventService) DeleteTimeslotMarker(ctx context.Context, querier models.
 	return nil
 }
 
-func (s *eventService) SetNowPlaying(ctx context.Context, querier models.Querier, eventID uuid.UUID, index int) error {
+// SetNowPlaying moves the playing marker to the timeslot, or clears it when
+// the timeslot was already playing. The run log records the change at the
+// given time.
+func (s *eventService) SetNowPlaying(ctx context.Context, querier models.Querier, eventID uuid.UUID, timeslotID uuid.UUID, at time.Time) error {
+	event, err := s.eventRepo.GetEventByID(ctx, querier, eventID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
+		return err
+	}
+
+	if event.TimeSlotByID(timeslotID) == nil {
+		return entities.ErrTimeslotNotFound
+	}
+
+	playing := event.NowPlayingTimeSlot()
+	if playing != nil && playing.ID == timeslotID {
+		return s.stopPlaying(ctx, querier, event, at)
+	}
+
+	return s.playTimeSlot(ctx, querier, event, timeslotID, at)
+}
+
+// StartShow puts the first timeslot of the lineup on stage.
+func (s *eventService) StartShow(ctx context.Context, querier models.Querier, eventID uuid.UUID, at time.Time) error {
 	event, err := s.eventRepo.GetEventByID(ctx, querier, eventID)
 	if err != nil {
 		s.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
 		return err
 	}
 
+	if event.NowPlayingTimeSlot() != nil {
+		return entities.ErrShowStarted
+	}
+
+	timeslots := event.TimeSlots()
+	if len(timeslots) == 0 {
+		return entities.ErrLineupEmpty
+	}
+
+	return s.playTimeSlot(ctx, querier, event, timeslots[0].ID, at)
+}
+
+// NextTimeSlot puts the timeslot after the one playing on stage.
+func (s *eventService) NextTimeSlot(ctx context.Context, querier models.Querier, eventID uuid.UUID, at time.Time) error {
+	event, err := s.eventRepo.GetEventByID(ctx, querier, eventID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
+		return err
+	}
+
+	playing := event.NowPlayingTimeSlot()
+	if playing == nil {
+		return entities.ErrShowNotStarted
+	}
+
+	next := event.NextTimeSlotByID(playing.ID)
+	if next == nil {
+		return entities.ErrNoNextTimeslot
+	}
+
+	return s.playTimeSlot(ctx, querier, event, next.ID, at)
+}
+
+// PreviousTimeSlot puts the timeslot before the one playing back on stage.
+func (s *eventService) PreviousTimeSlot(ctx context.Context, querier models.Querier, eventID uuid.UUID, at time.Time) error {
+	event, err := s.eventRepo.GetEventByID(ctx, querier, eventID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
+		return err
+	}
+
+	playing := event.NowPlayingTimeSlot()
+	if playing == nil {
+		return entities.ErrShowNotStarted
+	}
+
+	previous := event.PreviousTimeSlotByID(playing.ID)
+	if previous == nil {
+		return entities.ErrNoPreviousTimeslot
+	}
+
+	return s.playTimeSlot(ctx, querier, event, previous.ID, at)
+}
+
+// EndShow takes the timeslot playing off stage without putting another on.
+func (s *eventService) EndShow(ctx context.Context, querier models.Querier, eventID uuid.UUID, at time.Time) error {
+	event, err := s.eventRepo.GetEventByID(ctx, querier, eventID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
+		return err
+	}
+
+	if event.NowPlayingTimeSlot() == nil {
+		return entities.ErrShowNotStarted
+	}
+
+	return s.stopPlaying(ctx, querier, event, at)
+}
+
+// playTimeSlot ends whatever run is open, moves the playing marker to the
+// timeslot and starts its run.
+func (s *eventService) playTimeSlot(ctx context.Context, querier models.Querier, event *entities.EventEntity, timeslotID uuid.UUID, at time.Time) error {
+	err := s.eventRepo.EndTimeSlotRuns(ctx, querier, event.ID, at)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to end timeslot runs")
+		return err
+	}
+
 	markerEntity := event.NowPlayingTimeSlotMarker()
 	if markerEntity != nil {
-		if markerEntity.Index == index {
-			err := s.eventRepo.DeleteTimeslotMarker(ctx, querier, markerEntity.ID)
-			if err != nil {
-				s.logger.Err(err).Ctx(ctx).Msg("Failed to delete timeslot marker")
-				return err
-			}
-			return nil
-		} else {
-			markerEntity.Index = index
-			err = s.eventRepo.UpdateTimeslotMarker(ctx, querier, markerEntity)
-			if err != nil {
-				s.logger.Err(err).Ctx(ctx).Msg("Failed to update timeslot marker")
-				return err
-			}
+		markerEntity.TimeSlotID = timeslotID
+		err = s.eventRepo.UpdateTimeslotMarker(ctx, querier, markerEntity)
+		if err != nil {
+			s.logger.Err(err).Ctx(ctx).Msg("Failed to update timeslot marker")
+			return err
 		}
 	} else {
 		newMarker := entities.TimeMarkerEntity{
-			ID:    uuid.New(),
-			Time:  "Playing",
-			Index: index,
-			Type:  "PLAYING",
+			ID:         uuid.New(),
+			TimeSlotID: timeslotID,
+			Type:       entities.TimeMarkerPlaying,
 		}
-		err = s.eventRepo.CreateTimeslotMarker(ctx, querier, eventID, &newMarker)
+		err = s.eventRepo.CreateTimeslotMarker(ctx, querier, event.ID, &newMarker)
 		if err != nil {
 			s.logger.Err(err).Ctx(ctx).Msg("Failed to create timeslot marker")
 			return err
 		}
 	}
 
+	run := entities.TimeSlotRunEntity{
+		ID:         uuid.New(),
+		TimeSlotID: timeslotID,
+		StartedAt:  at,
+	}
+	err = s.eventRepo.StartTimeSlotRun(ctx, querier, event.ID, &run)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to start timeslot run")
+		return err
+	}
+
+	return nil
+}
+
+// stopPlaying ends the open run and clears the playing marker.
+func (s *eventService) stopPlaying(ctx context.Context, querier models.Querier, event *entities.EventEntity, at time.Time) error {
+	err := s.eventRepo.EndTimeSlotRuns(ctx, querier, event.ID, at)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to end timeslot runs")
+		return err
+	}
+
+	markerEntity := event.NowPlayingTimeSlotMarker()
+	if markerEntity == nil {
+		return nil
+	}
+
+	err = s.eventRepo.DeleteTimeslotMarker(ctx, querier, markerEntity.ID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to delete timeslot marker")
+		return err
+	}
+
 	return nil
 }
@@ -0,0 +1,224 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/mcorrigan89/openmic/internal/domain/external"
+	"github.com/mcorrigan89/openmic/internal/domain/repositories"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+	"github.com/rs/xid"
+)
+
+type IdentityService interface {
+	Providers() []string
+	StartLogin(ctx context.Context, querier models.Querier, provider string) (*entities.LoginRequestEntity, error)
+	ConsumeLoginRequest(ctx context.Context, querier models.Querier, state string, binding string) (*entities.LoginRequestEntity, error)
+	VerifyLogin(ctx context.Context, loginRequest *entities.LoginRequestEntity, code string) (*entities.IdentityClaims, error)
+	LoginWithClaims(ctx context.Context, querier models.Querier, claims *entities.IdentityClaims) (*entities.UserContextEntity, error)
+	GetIdentities(ctx context.Context, querier models.Querier, userID uuid.UUID) ([]*entities.IdentityEntity, error)
+}
+
+// loginRequestDuration is how long a user has to finish signing in at the
+// provider.
+const loginRequestDuration = 10 * time.Minute
+
+type identityService struct {
+	userService  UserService
+	identityRepo repositories.IdentityRepository
+	providers    map[string]external.IdentityProvider
+}
+
+func NewIdentityService(userService UserService, identityRepo repositories.IdentityRepository, providers ...external.IdentityProvider) *identityService {
+	providersByName := make(map[string]external.IdentityProvider, len(providers))
+	for _, provider := range providers {
+		providersByName[provider.Name()] = provider
+	}
+
+	return &identityService{userService: userService, identityRepo: identityRepo, providers: providersByName}
+}
+
+func (s *identityService) Providers() []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// StartLogin records a PKCE authorization request and returns it with the
+// provider URL to send the user to and the binding the browser must keep.
+func (s *identityService) StartLogin(ctx context.Context, querier models.Querier, providerName string) (*entities.LoginRequestEntity, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return nil, entities.ErrIdentityProviderNotFound
+	}
+
+	err := s.identityRepo.DeleteExpiredLoginRequests(ctx, querier)
+	if err != nil {
+		return nil, err
+	}
+
+	binding, err := entities.NewSecretToken()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := entities.NewSecretToken()
+	if err != nil {
+		return nil, err
+	}
+	codeVerifier, err := entities.NewSecretToken()
+	if err != nil {
+		return nil, err
+	}
+	state := entities.LoginState(binding)
+
+	loginRequest := &entities.LoginRequestEntity{
+		StateHash:    entities.HashToken(state),
+		Provider:     provider.Name(),
+		Nonce:        nonce,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    time.Now().Add(loginRequestDuration),
+	}
+	_, err = s.identityRepo.CreateLoginRequest(ctx, querier, loginRequest)
+	if err != nil {
+		return nil, err
+	}
+
+	authURL, err := provider.AuthorizationURL(ctx, state, nonce, entities.CodeChallenge(codeVerifier))
+	if err != nil {
+		return nil, err
+	}
+	loginRequest.Binding = binding
+	loginRequest.State = state
+	loginRequest.AuthorizationURL = authURL
+
+	return loginRequest, nil
+}
+
+// ConsumeLoginRequest removes the request for state so it cannot be replayed.
+// The binding must be the one handed to the browser that started the login;
+// otherwise the request is left alone.
+func (s *identityService) ConsumeLoginRequest(ctx context.Context, querier models.Querier, state string, binding string) (*entities.LoginRequestEntity, error) {
+	if subtle.ConstantTimeCompare([]byte(entities.LoginState(binding)), []byte(state)) != 1 {
+		return nil, entities.ErrLoginRequestInvalid
+	}
+
+	loginRequest, err := s.identityRepo.ConsumeLoginRequest(ctx, querier, entities.HashToken(state))
+	if err != nil {
+		return nil, err
+	}
+
+	if loginRequest.IsExpired() {
+		return nil, entities.ErrLoginRequestInvalid
+	}
+
+	return loginRequest, nil
+}
+
+// VerifyLogin redeems code at the provider and checks the ID token belongs to
+// loginRequest.
+func (s *identityService) VerifyLogin(ctx context.Context, loginRequest *entities.LoginRequestEntity, code string) (*entities.IdentityClaims, error) {
+	provider, ok := s.providers[loginRequest.Provider]
+	if !ok {
+		return nil, entities.ErrIdentityProviderNotFound
+	}
+
+	claims, err := provider.Exchange(ctx, code, loginRequest.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Nonce != loginRequest.Nonce {
+		return nil, entities.ErrLoginRequestInvalid
+	}
+	claims.Provider = provider.Name()
+
+	return claims, nil
+}
+
+// LoginWithClaims signs in the user linked to the external identity. An
+// unknown identity is linked to the user with the same verified email, who
+// is created if there is none.
+func (s *identityService) LoginWithClaims(ctx context.Context, querier models.Querier, claims *entities.IdentityClaims) (*entities.UserContextEntity, error) {
+	identity, err := s.identityRepo.GetIdentity(ctx, querier, claims.Provider, claims.Subject)
+	if err == nil {
+		userEntity, err := s.userService.GetUserByID(ctx, querier, identity.UserID)
+		if err != nil {
+			return nil, err
+		}
+
+		return s.userService.CreateSession(ctx, querier, userEntity)
+	}
+	if !errors.Is(err, entities.ErrIdentityNotFound) {
+		return nil, err
+	}
+
+	if claims.Email == "" || !claims.EmailVerified {
+		return nil, entities.ErrIdentityEmailUnverified
+	}
+
+	userEntity, err := s.userService.GetUserByEmail(ctx, querier, claims.Email)
+	switch {
+	case errors.Is(err, entities.ErrUserNotFound):
+		userEntity, err = s.userService.CreateUser(ctx, querier, &entities.UserEntity{
+			ID:            uuid.New(),
+			Email:         claims.Email,
+			EmailVerified: true,
+			GivenName:     claims.GivenName,
+			FamilyName:    claims.FamilyName,
+			Claimed:       true,
+			Handle:        xid.New().String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+	case err != nil:
+		return nil, err
+	case !userEntity.Claimed || !userEntity.EmailVerified:
+		// The provider has verified the address, which is what accepting an
+		// invite proves. Whoever opened the account never did, so their
+		// sessions end before the owner of the address is let in.
+		if !userEntity.EmailVerified {
+			err = s.userService.RevokeAllSessions(ctx, querier, userEntity.ID)
+			if err != nil {
+				return nil, err
+			}
+		}
+		userEntity.Claimed = true
+		userEntity.EmailVerified = true
+		userEntity, err = s.userService.UpdateUser(ctx, querier, userEntity)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	_, err = s.identityRepo.CreateIdentity(ctx, querier, &entities.IdentityEntity{
+		ID:       uuid.New(),
+		UserID:   userEntity.ID,
+		Provider: claims.Provider,
+		Subject:  claims.Subject,
+		Email:    claims.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.userService.CreateSession(ctx, querier, userEntity)
+}
+
+func (s *identityService) GetIdentities(ctx context.Context, querier models.Querier, userID uuid.UUID) ([]*entities.IdentityEntity, error) {
+	identities, err := s.identityRepo.GetIdentitiesByUserID(ctx, querier, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return identities, nil
+}
@@ -0,0 +1,235 @@
+package services
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+	mocks "github.com/mcorrigan89/openmic/internal/mocks/repositories"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type stubIdentityProvider struct {
+	claims *entities.IdentityClaims
+}
+
+func (p *stubIdentityProvider) Name() string {
+	return "stub"
+}
+
+func (p *stubIdentityProvider) AuthorizationURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	query := url.Values{}
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	return "https://issuer.example.com/authorize?" + query.Encode(), nil
+}
+
+func (p *stubIdentityProvider) Exchange(ctx context.Context, code string, codeVerifier string) (*entities.IdentityClaims, error) {
+	claims := *p.claims
+	return &claims, nil
+}
+
+func TestStartLogin(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockIdentityRepo := new(mocks.MockIdentityRepository)
+	userService := NewUserService(mockUserRepo, new(mocks.MockReferenceLinkRepository), new(mocks.MockImageRepository))
+	identityService := NewIdentityService(userService, mockIdentityRepo, &stubIdentityProvider{})
+
+	ctx := context.Background()
+	querier := &models.Queries{}
+
+	t.Run("stores the state hash and PKCE verifier", func(t *testing.T) {
+		var stored *entities.LoginRequestEntity
+		mockIdentityRepo.On("DeleteExpiredLoginRequests", ctx, querier).Return(nil)
+		mockIdentityRepo.On("CreateLoginRequest", ctx, querier, mock.MatchedBy(func(loginRequest *entities.LoginRequestEntity) bool {
+			stored = loginRequest
+			return true
+		})).Return(&entities.LoginRequestEntity{}, nil)
+
+		loginRequest, err := identityService.StartLogin(ctx, querier, "stub")
+		assert.NoError(t, err)
+
+		parsed, err := url.Parse(loginRequest.AuthorizationURL)
+		assert.NoError(t, err)
+		query := parsed.Query()
+
+		assert.Equal(t, entities.LoginState(loginRequest.Binding), query.Get("state"))
+		assert.Equal(t, entities.HashToken(query.Get("state")), stored.StateHash)
+		assert.Equal(t, query.Get("nonce"), stored.Nonce)
+		assert.Equal(t, entities.CodeChallenge(stored.CodeVerifier), query.Get("code_challenge"))
+		assert.Equal(t, "stub", stored.Provider)
+		mockIdentityRepo.AssertExpectations(t)
+	})
+
+	t.Run("unknown provider", func(t *testing.T) {
+		loginRequest, err := identityService.StartLogin(ctx, querier, "missing")
+
+		assert.ErrorIs(t, err, entities.ErrIdentityProviderNotFound)
+		assert.Nil(t, loginRequest)
+	})
+
+	t.Run("lists providers", func(t *testing.T) {
+		assert.Equal(t, []string{"stub"}, identityService.Providers())
+	})
+}
+
+func TestConsumeLoginRequest(t *testing.T) {
+	mockIdentityRepo := new(mocks.MockIdentityRepository)
+	userService := NewUserService(new(mocks.MockUserRepository), new(mocks.MockReferenceLinkRepository), new(mocks.MockImageRepository))
+	identityService := NewIdentityService(userService, mockIdentityRepo, &stubIdentityProvider{})
+
+	ctx := context.Background()
+	querier := &models.Queries{}
+
+	t.Run("expired request", func(t *testing.T) {
+		oldState := entities.LoginState("old-binding")
+		mockIdentityRepo.On("ConsumeLoginRequest", ctx, querier, entities.HashToken(oldState)).Return(&entities.LoginRequestEntity{
+			ExpiresAt: time.Now().Add(-time.Minute),
+		}, nil)
+
+		loginRequest, err := identityService.ConsumeLoginRequest(ctx, querier, oldState, "old-binding")
+
+		assert.ErrorIs(t, err, entities.ErrLoginRequestInvalid)
+		assert.Nil(t, loginRequest)
+	})
+
+	t.Run("state from another browser", func(t *testing.T) {
+		loginRequest, err := identityService.ConsumeLoginRequest(ctx, querier, entities.LoginState("their-binding"), "my-binding")
+
+		assert.ErrorIs(t, err, entities.ErrLoginRequestInvalid)
+		assert.Nil(t, loginRequest)
+		mockIdentityRepo.AssertNotCalled(t, "ConsumeLoginRequest", ctx, querier, entities.HashToken(entities.LoginState("their-binding")))
+	})
+}
+
+func TestVerifyLogin(t *testing.T) {
+	provider := &stubIdentityProvider{claims: &entities.IdentityClaims{Subject: "subject-1", Nonce: "nonce-1"}}
+	userService := NewUserService(new(mocks.MockUserRepository), new(mocks.MockReferenceLinkRepository), new(mocks.MockImageRepository))
+	identityService := NewIdentityService(userService, new(mocks.MockIdentityRepository), provider)
+
+	ctx := context.Background()
+
+	t.Run("matching nonce", func(t *testing.T) {
+		claims, err := identityService.VerifyLogin(ctx, &entities.LoginRequestEntity{Provider: "stub", Nonce: "nonce-1"}, "code")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "stub", claims.Provider)
+		assert.Equal(t, "subject-1", claims.Subject)
+	})
+
+	t.Run("nonce mismatch", func(t *testing.T) {
+		claims, err := identityService.VerifyLogin(ctx, &entities.LoginRequestEntity{Provider: "stub", Nonce: "nonce-2"}, "code")
+
+		assert.ErrorIs(t, err, entities.ErrLoginRequestInvalid)
+		assert.Nil(t, claims)
+	})
+}
+
+func TestLoginWithClaims(t *testing.T) {
+	ctx := context.Background()
+	querier := &models.Queries{}
+
+	newServices := func() (*mocks.MockUserRepository, *mocks.MockIdentityRepository, *identityService) {
+		mockUserRepo := new(mocks.MockUserRepository)
+		mockIdentityRepo := new(mocks.MockIdentityRepository)
+		userService := NewUserService(mockUserRepo, new(mocks.MockReferenceLinkRepository), new(mocks.MockImageRepository))
+		return mockUserRepo, mockIdentityRepo, NewIdentityService(userService, mockIdentityRepo, &stubIdentityProvider{})
+	}
+
+	t.Run("linked identity signs in its user", func(t *testing.T) {
+		mockUserRepo, mockIdentityRepo, identityService := newServices()
+		user := &entities.UserEntity{ID: uuid.New(), Email: "linked@example.com"}
+		claims := &entities.IdentityClaims{Provider: "stub", Subject: "subject-1", Email: "other@example.com"}
+
+		mockIdentityRepo.On("GetIdentity", ctx, querier, "stub", "subject-1").Return(&entities.IdentityEntity{UserID: user.ID}, nil)
+		mockUserRepo.On("GetUserByID", ctx, querier, user.ID).Return(user, nil)
+		mockUserRepo.On("CreateSession", ctx, querier, user, mock.Anything, mock.Anything).Return(entities.NewUserContextEntity(user, models.UserSession{}), nil)
+
+		userSession, err := identityService.LoginWithClaims(ctx, querier, claims)
+
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, userSession.UserID)
+		mockIdentityRepo.AssertNotCalled(t, "CreateIdentity", mock.Anything, mock.Anything, mock.Anything)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("unverified email is refused", func(t *testing.T) {
+		_, mockIdentityRepo, identityService := newServices()
+		claims := &entities.IdentityClaims{Provider: "stub", Subject: "subject-2", Email: "test@example.com"}
+
+		mockIdentityRepo.On("GetIdentity", ctx, querier, "stub", "subject-2").Return(nil, entities.ErrIdentityNotFound)
+
+		userSession, err := identityService.LoginWithClaims(ctx, querier, claims)
+
+		assert.ErrorIs(t, err, entities.ErrIdentityEmailUnverified)
+		assert.Nil(t, userSession)
+	})
+
+	t.Run("links an existing user by verified email", func(t *testing.T) {
+		mockUserRepo, mockIdentityRepo, identityService := newServices()
+		user := &entities.UserEntity{ID: uuid.New(), Email: "test@example.com", Claimed: true, EmailVerified: true}
+		claims := &entities.IdentityClaims{Provider: "stub", Subject: "subject-3", Email: "test@example.com", EmailVerified: true}
+
+		mockIdentityRepo.On("GetIdentity", ctx, querier, "stub", "subject-3").Return(nil, entities.ErrIdentityNotFound)
+		mockUserRepo.On("GetUserByEmail", ctx, querier, "test@example.com").Return(user, nil)
+		mockIdentityRepo.On("CreateIdentity", ctx, querier, mock.MatchedBy(func(identity *entities.IdentityEntity) bool {
+			return identity.UserID == user.ID && identity.Provider == "stub" && identity.Subject == "subject-3"
+		})).Return(&entities.IdentityEntity{}, nil)
+		mockUserRepo.On("CreateSession", ctx, querier, user, mock.Anything, mock.Anything).Return(entities.NewUserContextEntity(user, models.UserSession{}), nil)
+
+		userSession, err := identityService.LoginWithClaims(ctx, querier, claims)
+
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, userSession.UserID)
+		mockIdentityRepo.AssertExpectations(t)
+		mockUserRepo.AssertNotCalled(t, "CreateUser", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("linking an unverified account ends its sessions", func(t *testing.T) {
+		mockUserRepo, mockIdentityRepo, identityService := newServices()
+		user := &entities.UserEntity{ID: uuid.New(), Email: "test@example.com", Claimed: true}
+		claims := &entities.IdentityClaims{Provider: "stub", Subject: "subject-5", Email: "test@example.com", EmailVerified: true}
+
+		mockIdentityRepo.On("GetIdentity", ctx, querier, "stub", "subject-5").Return(nil, entities.ErrIdentityNotFound)
+		mockUserRepo.On("GetUserByEmail", ctx, querier, "test@example.com").Return(user, nil)
+		mockUserRepo.On("ExpireAllSessions", ctx, querier, user.ID).Return(nil)
+		mockUserRepo.On("UpdateUser", ctx, querier, mock.MatchedBy(func(updated *entities.UserEntity) bool {
+			return updated.ID == user.ID && updated.EmailVerified && updated.Claimed
+		})).Return(user, nil)
+		mockIdentityRepo.On("CreateIdentity", ctx, querier, mock.Anything).Return(&entities.IdentityEntity{}, nil)
+		mockUserRepo.On("CreateSession", ctx, querier, user, mock.Anything, mock.Anything).Return(entities.NewUserContextEntity(user, models.UserSession{}), nil)
+
+		userSession, err := identityService.LoginWithClaims(ctx, querier, claims)
+
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID, userSession.UserID)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("creates a verified user for a new email", func(t *testing.T) {
+		mockUserRepo, mockIdentityRepo, identityService := newServices()
+		givenName := "Test"
+		claims := &entities.IdentityClaims{Provider: "stub", Subject: "subject-4", Email: "new@example.com", EmailVerified: true, GivenName: &givenName}
+		created := &entities.UserEntity{ID: uuid.New(), Email: "new@example.com"}
+
+		mockIdentityRepo.On("GetIdentity", ctx, querier, "stub", "subject-4").Return(nil, entities.ErrIdentityNotFound)
+		mockUserRepo.On("GetUserByEmail", ctx, querier, "new@example.com").Return(nil, entities.ErrUserNotFound)
+		mockUserRepo.On("CreateUser", ctx, querier, mock.MatchedBy(func(user *entities.UserEntity) bool {
+			return user.Email == "new@example.com" && user.EmailVerified && user.Claimed && *user.GivenName == "Test"
+		})).Return(created, nil)
+		mockIdentityRepo.On("CreateIdentity", ctx, querier, mock.Anything).Return(&entities.IdentityEntity{}, nil)
+		mockUserRepo.On("CreateSession", ctx, querier, created, mock.Anything, mock.Anything).Return(entities.NewUserContextEntity(created, models.UserSession{}), nil)
+
+		userSession, err := identityService.LoginWithClaims(ctx, querier, claims)
+
+		assert.NoError(t, err)
+		assert.Equal(t, created.ID, userSession.UserID)
+		mockUserRepo.AssertExpectations(t)
+	})
+}
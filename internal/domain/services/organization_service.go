@@ -0,0 +1,247 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/mcorrigan89/openmic/internal/domain/repositories"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+	"github.com/rs/zerolog"
+)
+
+type OrganizationService interface {
+	GetOrganizationByID(ctx context.Context, querier models.Querier, organizationID uuid.UUID) (*entities.OrganizationEntity, error)
+	GetOrganizationByVenueID(ctx context.Context, querier models.Querier, venueID uuid.UUID) (*entities.OrganizationEntity, error)
+	GetOrganizationByEventID(ctx context.Context, querier models.Querier, eventID uuid.UUID) (*entities.OrganizationEntity, error)
+	GetOrganizationByArtistID(ctx context.Context, querier models.Querier, artistID uuid.UUID) (*entities.OrganizationEntity, error)
+	GetOrganizationsByUserID(ctx context.Context, querier models.Querier, userID uuid.UUID) ([]*entities.OrganizationEntity, error)
+	CreateOrganization(ctx context.Context, querier models.Querier, organization *entities.OrganizationEntity) (*entities.OrganizationEntity, error)
+	UpdateOrganization(ctx context.Context, querier models.Querier, organization *entities.OrganizationEntity) (*entities.OrganizationEntity, error)
+	GetOrganizationMembers(ctx context.Context, querier models.Querier, organizationID uuid.UUID) ([]*entities.UserEntity, error)
+	IsOrganizationMember(ctx context.Context, querier models.Querier, organizationID uuid.UUID, userID uuid.UUID) (bool, error)
+	AddOrganizationMember(ctx context.Context, querier models.Querier, organizationID uuid.UUID, userID uuid.UUID) error
+	RemoveOrganizationMember(ctx context.Context, querier models.Querier, organizationID uuid.UUID, userID uuid.UUID) error
+	GetVenueByID(ctx context.Context, querier models.Querier, venueID uuid.UUID) (*entities.VenueEntity, error)
+	GetVenuesByOrganizationID(ctx context.Context, querier models.Querier, organizationID uuid.UUID) ([]*entities.VenueEntity, error)
+	CreateVenue(ctx context.Context, querier models.Querier, venue *entities.VenueEntity) (*entities.VenueEntity, error)
+	UpdateVenue(ctx context.Context, querier models.Querier, venue *entities.VenueEntity) (*entities.VenueEntity, error)
+	DeleteVenue(ctx context.Context, querier models.Querier, venueID uuid.UUID) error
+	GetEventTypeWindows(ctx context.Context, querier models.Querier, organizationID uuid.UUID) ([]*entities.EventTypeWindowEntity, error)
+	SetEventTypeWindow(ctx context.Context, querier models.Querier, window *entities.EventTypeWindowEntity) (*entities.EventTypeWindowEntity, error)
+	DeleteEventTypeWindow(ctx context.Context, querier models.Querier, organizationID uuid.UUID, eventType string) error
+}
+
+type organizationService struct {
+	logger           *zerolog.Logger
+	organizationRepo repositories.OrganizationRepository
+}
+
+func NewOrganizationService(logger *zerolog.Logger, organizationRepo repositories.OrganizationRepository) *organizationService {
+	return &organizationService{logger: logger, organizationRepo: organizationRepo}
+}
+
+func (s *organizationService) GetOrganizationByID(ctx context.Context, querier models.Querier, organizationID uuid.UUID) (*entities.OrganizationEntity, error) {
+	organization, err := s.organizationRepo.GetOrganizationByID(ctx, querier, organizationID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to get organization by ID")
+		return nil, err
+	}
+
+	return organization, nil
+}
+
+func (s *organizationService) GetOrganizationByVenueID(ctx context.Context, querier models.Querier, venueID uuid.UUID) (*entities.OrganizationEntity, error) {
+	organization, err := s.organizationRepo.GetOrganizationByVenueID(ctx, querier, venueID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to get organization by venue ID")
+		return nil, err
+	}
+
+	return organization, nil
+}
+
+func (s *organizationService) GetOrganizationByEventID(ctx context.Context, querier models.Querier, eventID uuid.UUID) (*entities.OrganizationEntity, error) {
+	organization, err := s.organizationRepo.GetOrganizationByEventID(ctx, querier, eventID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to get organization by event ID")
+		return nil, err
+	}
+
+	return organization, nil
+}
+
+func (s *organizationService) GetOrganizationByArtistID(ctx context.Context, querier models.Querier, artistID uuid.UUID) (*entities.OrganizationEntity, error) {
+	organization, err := s.organizationRepo.GetOrganizationByArtistID(ctx, querier, artistID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to get organization by artist ID")
+		return nil, err
+	}
+
+	return organization, nil
+}
+
+func (s *organizationService) GetOrganizationsByUserID(ctx context.Context, querier models.Querier, userID uuid.UUID) ([]*entities.OrganizationEntity, error) {
+	organizations, err := s.organizationRepo.GetOrganizationsByUserID(ctx, querier, userID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to get organizations by user ID")
+		return nil, err
+	}
+
+	return organizations, nil
+}
+
+func (s *organizationService) CreateOrganization(ctx context.Context, querier models.Querier, organization *entities.OrganizationEntity) (*entities.OrganizationEntity, error) {
+	createdOrganization, err := s.organizationRepo.CreateOrganization(ctx, querier, organization)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to create organization")
+		return nil, err
+	}
+
+	return createdOrganization, nil
+}
+
+func (s *organizationService) UpdateOrganization(ctx context.Context, querier models.Querier, organization *entities.OrganizationEntity) (*entities.OrganizationEntity, error) {
+	updatedOrganization, err := s.organizationRepo.UpdateOrganization(ctx, querier, organization)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to update organization")
+		return nil, err
+	}
+
+	return updatedOrganization, nil
+}
+
+func (s *organizationService) GetOrganizationMembers(ctx context.Context, querier models.Querier, organizationID uuid.UUID) ([]*entities.UserEntity, error) {
+	members, err := s.organizationRepo.GetOrganizationMembers(ctx, querier, organizationID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to get organization members")
+		return nil, err
+	}
+
+	return members, nil
+}
+
+func (s *organizationService) IsOrganizationMember(ctx context.Context, querier models.Querier, organizationID uuid.UUID, userID uuid.UUID) (bool, error) {
+	isMember, err := s.organizationRepo.IsOrganizationMember(ctx, querier, organizationID, userID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to check organization membership")
+		return false, err
+	}
+
+	return isMember, nil
+}
+
+func (s *organizationService) AddOrganizationMember(ctx context.Context, querier models.Querier, organizationID uuid.UUID, userID uuid.UUID) error {
+	err := s.organizationRepo.AddOrganizationMember(ctx, querier, organizationID, userID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to add organization member")
+		return err
+	}
+
+	return nil
+}
+
+func (s *organizationService) RemoveOrganizationMember(ctx context.Context, querier models.Querier, organizationID uuid.UUID, userID uuid.UUID) error {
+	err := s.organizationRepo.RemoveOrganizationMember(ctx, querier, organizationID, userID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to remove organization member")
+		return err
+	}
+
+	return nil
+}
+
+func (s *organizationService) GetVenueByID(ctx context.Context, querier models.Querier, venueID uuid.UUID) (*entities.VenueEntity, error) {
+	venue, err := s.organizationRepo.GetVenueByID(ctx, querier, venueID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to get venue by ID")
+		return nil, err
+	}
+
+	return venue, nil
+}
+
+func (s *organizationService) GetVenuesByOrganizationID(ctx context.Context, querier models.Querier, organizationID uuid.UUID) ([]*entities.VenueEntity, error) {
+	venues, err := s.organizationRepo.GetVenuesByOrganizationID(ctx, querier, organizationID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to get venues by organization ID")
+		return nil, err
+	}
+
+	return venues, nil
+}
+
+func (s *organizationService) CreateVenue(ctx context.Context, querier models.Querier, venue *entities.VenueEntity) (*entities.VenueEntity, error) {
+	err := venue.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	createdVenue, err := s.organizationRepo.CreateVenue(ctx, querier, venue)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to create venue")
+		return nil, err
+	}
+
+	return createdVenue, nil
+}
+
+func (s *organizationService) UpdateVenue(ctx context.Context, querier models.Querier, venue *entities.VenueEntity) (*entities.VenueEntity, error) {
+	err := venue.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	updatedVenue, err := s.organizationRepo.UpdateVenue(ctx, querier, venue)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to update venue")
+		return nil, err
+	}
+
+	return updatedVenue, nil
+}
+
+func (s *organizationService) DeleteVenue(ctx context.Context, querier models.Querier, venueID uuid.UUID) error {
+	err := s.organizationRepo.DeleteVenue(ctx, querier, venueID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to delete venue")
+		return err
+	}
+
+	return nil
+}
+
+func (s *organizationService) GetEventTypeWindows(ctx context.Context, querier models.Querier, organizationID uuid.UUID) ([]*entities.EventTypeWindowEntity, error) {
+	windows, err := s.organizationRepo.GetEventTypeWindows(ctx, querier, organizationID)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to get event type windows")
+		return nil, err
+	}
+
+	return windows, nil
+}
+
+func (s *organizationService) SetEventTypeWindow(ctx context.Context, querier models.Querier, window *entities.EventTypeWindowEntity) (*entities.EventTypeWindowEntity, error) {
+	err := window.Window.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	savedWindow, err := s.organizationRepo.SetEventTypeWindow(ctx, querier, window)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to set event type window")
+		return nil, err
+	}
+
+	return savedWindow, nil
+}
+
+func (s *organizationService) DeleteEventTypeWindow(ctx context.Context, querier models.Querier, organizationID uuid.UUID, eventType string) error {
+	err := s.organizationRepo.DeleteEventTypeWindow(ctx, querier, organizationID, eventType)
+	if err != nil {
+		s.logger.Err(err).Ctx(ctx).Msg("Failed to delete event type window")
+		return err
+	}
+
+	return nil
+}
@@ -8,8 +8,6 @@ import (
 	"github.com/mcorrigan89/openmic/internal/domain/entities"
 	"github.com/mcorrigan89/openmic/internal/domain/repositories"
 	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
-
-	"github.com/rs/xid"
 )
 
 type UserService interface {
@@ -20,13 +18,30 @@ type UserService interface {
 	CreateUser(ctx context.Context, querier models.Querier, user *entities.UserEntity) (*entities.UserEntity, error)
 	UpdateUser(ctx context.Context, querier models.Querier, user *entities.UserEntity) (*entities.UserEntity, error)
 	CreateSession(ctx context.Context, querier models.Querier, user *entities.UserEntity) (*entities.UserContextEntity, error)
+	StartImpersonation(ctx context.Context, querier models.Querier, impersonatorID uuid.UUID, userID uuid.UUID) (*entities.UserContextEntity, error)
+	EndImpersonation(ctx context.Context, querier models.Querier, userContext *entities.UserContextEntity) error
+	AuditImpersonatedRequest(ctx context.Context, querier models.Querier, userContext *entities.UserContextEntity, method string, path string) error
 	CreateLoginLink(ctx context.Context, querier models.Querier, email string) (*entities.ReferenceLinkEntity, error)
 	CreateInviteLink(ctx context.Context, querier models.Querier, userEntity *entities.UserEntity) (*entities.ReferenceLinkEntity, error)
 	LoginWithLink(ctx context.Context, querier models.Querier, token string) (*entities.UserContextEntity, error)
 	AcceptInviteLink(ctx context.Context, querier models.Querier, token string) (*entities.UserContextEntity, error)
 	SetAvatarImage(ctx context.Context, querier models.Querier, image *entities.ImageEntity, user *entities.UserEntity) (*entities.UserEntity, error)
+	SetUserRole(ctx context.Context, querier models.Querier, userID uuid.UUID, role entities.Role) (*entities.UserEntity, error)
+	GetActiveSessions(ctx context.Context, querier models.Querier, userID uuid.UUID) ([]*entities.SessionEntity, error)
+	TouchSession(ctx context.Context, querier models.Querier, userContext *entities.UserContextEntity, ipAddress string) error
+	RevokeSession(ctx context.Context, querier models.Querier, userID uuid.UUID, sessionID uuid.UUID) error
+	RevokeAllSessions(ctx context.Context, querier models.Querier, userID uuid.UUID) error
 }
 
+const (
+	// sessionDuration is how long a session lasts after it was last used.
+	sessionDuration = 30 * 24 * time.Hour
+	// sessionTouchInterval limits how often activity on a session is written.
+	sessionTouchInterval = 5 * time.Minute
+	// impersonationDuration is fixed; impersonation sessions do not slide.
+	impersonationDuration = time.Hour
+)
+
 type userService struct {
 	userRepo    repositories.UserRepository
 	refLinkRepo repositories.ReferenceLinkRepository
@@ -65,10 +80,11 @@ func (s *userService) GetUserByHandle(ctx context.Context, querier models.Querie
 }
 
 func (s *userService) GetUserContextBySessionToken(ctx context.Context, querier models.Querier, sessionToken string) (*entities.UserContextEntity, error) {
-	user, err := s.userRepo.GetUserContextBySessionToken(ctx, querier, sessionToken)
+	user, err := s.userRepo.GetUserContextBySessionTokenHash(ctx, querier, entities.HashToken(sessionToken))
 	if err != nil {
 		return nil, err
 	}
+	user.SessionToken = sessionToken
 
 	return user, nil
 }
@@ -93,27 +109,86 @@ func (s *userService) UpdateUser(ctx context.Context, querier models.Querier, us
 
 func (s *userService) CreateSession(ctx context.Context, querier models.Querier, user *entities.UserEntity) (*entities.UserContextEntity, error) {
 
-	token := xid.New().String()
-	expiresAt := time.Now().Add(time.Hour * 24 * 30)
+	token, err := entities.NewSecretToken()
+	if err != nil {
+		return nil, err
+	}
+	expiresAt := time.Now().Add(sessionDuration)
+
+	userSession, err := s.userRepo.CreateSession(ctx, querier, user, entities.HashToken(token), expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	userSession.SessionToken = token
+
+	return userSession, nil
+}
+
+// StartImpersonation opens a short session in which impersonatorID acts as
+// the user. Admins cannot be impersonated, nor can anyone impersonate
+// themselves.
+func (s *userService) StartImpersonation(ctx context.Context, querier models.Querier, impersonatorID uuid.UUID, userID uuid.UUID) (*entities.UserContextEntity, error) {
+	if impersonatorID == userID {
+		return nil, entities.ErrImpersonationNotAllowed
+	}
+
+	userEntity, err := s.userRepo.GetUserByID(ctx, querier, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if userEntity.Role == entities.RoleAdmin {
+		return nil, entities.ErrImpersonationNotAllowed
+	}
 
-	userSession, err := s.userRepo.CreateSession(ctx, querier, user, token, expiresAt)
+	token, err := entities.NewSecretToken()
 	if err != nil {
 		return nil, err
 	}
+	expiresAt := time.Now().Add(impersonationDuration)
+
+	userSession, err := s.userRepo.CreateImpersonationSession(ctx, querier, userEntity, impersonatorID, entities.HashToken(token), expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	userSession.SessionToken = token
 
 	return userSession, nil
 }
 
+func (s *userService) EndImpersonation(ctx context.Context, querier models.Querier, userContext *entities.UserContextEntity) error {
+	if !userContext.IsImpersonated() {
+		return entities.ErrNotImpersonating
+	}
+
+	return s.userRepo.ExpireSession(ctx, querier, userContext.UserID, userContext.SessionID)
+}
+
+// AuditImpersonatedRequest records a request made through an impersonation
+// session under both the impersonator and the impersonated user.
+func (s *userService) AuditImpersonatedRequest(ctx context.Context, querier models.Querier, userContext *entities.UserContextEntity, method string, path string) error {
+	if !userContext.IsImpersonated() {
+		return entities.ErrNotImpersonating
+	}
+
+	return s.userRepo.CreateImpersonationAudit(ctx, querier, *userContext.ImpersonatorID(), userContext.UserID, userContext.SessionID, method, path)
+}
+
 func (s *userService) CreateLoginLink(ctx context.Context, querier models.Querier, email string) (*entities.ReferenceLinkEntity, error) {
 	userEntity, err := s.userRepo.GetUserByEmail(ctx, querier, email)
 	if err != nil {
 		return nil, err
 	}
 
+	token, err := entities.NewSecretToken()
+	if err != nil {
+		return nil, err
+	}
+
 	newLinkEntity := entities.ReferenceLinkEntity{
 		ID:        uuid.New(),
 		LinkID:    userEntity.ID,
-		Token:     xid.New().String(),
+		TokenHash: entities.HashToken(token),
 		Type:      entities.RefLinkTypeLogin,
 		ExpiresAt: time.Now().Add(time.Minute * 30),
 	}
@@ -122,15 +197,21 @@ func (s *userService) CreateLoginLink(ctx context.Context, querier models.Querie
 	if err != nil {
 		return nil, err
 	}
+	loginLinkEntity.Token = token
 
 	return loginLinkEntity, nil
 }
 
 func (s *userService) CreateInviteLink(ctx context.Context, querier models.Querier, userEntity *entities.UserEntity) (*entities.ReferenceLinkEntity, error) {
+	token, err := entities.NewSecretToken()
+	if err != nil {
+		return nil, err
+	}
+
 	newLinkEntity := entities.ReferenceLinkEntity{
 		ID:        uuid.New(),
 		LinkID:    userEntity.ID,
-		Token:     xid.New().String(),
+		TokenHash: entities.HashToken(token),
 		Type:      entities.RefLinkTypeInvite,
 		ExpiresAt: time.Now().Add(time.Hour * 24 * 30),
 	}
@@ -139,12 +220,13 @@ func (s *userService) CreateInviteLink(ctx context.Context, querier models.Queri
 	if err != nil {
 		return nil, err
 	}
+	loginLinkEntity.Token = token
 
 	return loginLinkEntity, nil
 }
 
 func (s *userService) LoginWithLink(ctx context.Context, querier models.Querier, token string) (*entities.UserContextEntity, error) {
-	refLinkEntity, err := s.refLinkRepo.GetReferenceLinkByToken(ctx, querier, token)
+	refLinkEntity, err := s.refLinkRepo.GetReferenceLinkByTokenHash(ctx, querier, entities.HashToken(token))
 	if err != nil {
 		return nil, err
 	}
@@ -176,7 +258,7 @@ func (s *userService) LoginWithLink(ctx context.Context, querier models.Querier,
 }
 
 func (s *userService) AcceptInviteLink(ctx context.Context, querier models.Querier, token string) (*entities.UserContextEntity, error) {
-	refLinkEntity, err := s.refLinkRepo.GetReferenceLinkByToken(ctx, querier, token)
+	refLinkEntity, err := s.refLinkRepo.GetReferenceLinkByTokenHash(ctx, querier, entities.HashToken(token))
 	if err != nil {
 		return nil, err
 	}
@@ -223,3 +305,49 @@ func (s *userService) SetAvatarImage(ctx context.Context, querier models.Querier
 
 	return userEntity, nil
 }
+
+func (s *userService) SetUserRole(ctx context.Context, querier models.Querier, userID uuid.UUID, role entities.Role) (*entities.UserEntity, error) {
+	role, err := entities.ParseRole(string(role))
+	if err != nil {
+		return nil, err
+	}
+
+	userEntity, err := s.userRepo.SetUserRole(ctx, querier, userID, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return userEntity, nil
+}
+
+func (s *userService) GetActiveSessions(ctx context.Context, querier models.Querier, userID uuid.UUID) ([]*entities.SessionEntity, error) {
+	sessions, err := s.userRepo.GetActiveSessions(ctx, querier, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return sessions, nil
+}
+
+// TouchSession records activity on the session and slides its expiry forward,
+// at most once every sessionTouchInterval per address.
+func (s *userService) TouchSession(ctx context.Context, querier models.Querier, userContext *entities.UserContextEntity, ipAddress string) error {
+	if !userContext.NeedsTouch(ipAddress, sessionTouchInterval) {
+		return nil
+	}
+
+	expiresAt := time.Now().Add(sessionDuration)
+	if userContext.IsImpersonated() {
+		expiresAt = userContext.ExpiresAt()
+	}
+
+	return s.userRepo.TouchSession(ctx, querier, userContext.SessionID, ipAddress, expiresAt)
+}
+
+func (s *userService) RevokeSession(ctx context.Context, querier models.Querier, userID uuid.UUID, sessionID uuid.UUID) error {
+	return s.userRepo.ExpireSession(ctx, querier, userID, sessionID)
+}
+
+func (s *userService) RevokeAllSessions(ctx context.Context, querier models.Querier, userID uuid.UUID) error {
+	return s.userRepo.ExpireAllSessions(ctx, querier, userID)
+}
@@ -3,12 +3,14 @@ package services
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/mcorrigan89/openmic/internal/domain/entities"
 	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
 	mocks "github.com/mcorrigan89/openmic/internal/mocks/repositories"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 // Test cases
@@ -156,7 +158,7 @@ func TestGetUserByToken(t *testing.T) {
 			SessionToken: token,
 		}
 
-		mockUserRepo.On("GetUserContextBySessionToken", ctx, querier, token).Return(expectedUserContext, nil)
+		mockUserRepo.On("GetUserContextBySessionTokenHash", ctx, querier, entities.HashToken(token)).Return(expectedUserContext, nil)
 
 		userContext, err := userService.GetUserContextBySessionToken(ctx, querier, token)
 
@@ -168,7 +170,7 @@ func TestGetUserByToken(t *testing.T) {
 	t.Run("user by handle not found", func(t *testing.T) {
 
 		token := "yyyyyyy"
-		mockUserRepo.On("GetUserContextBySessionToken", ctx, querier, token).Return(nil, entities.ErrUserNotFound)
+		mockUserRepo.On("GetUserContextBySessionTokenHash", ctx, querier, entities.HashToken(token)).Return(nil, entities.ErrUserNotFound)
 
 		userContext, err := userService.GetUserContextBySessionToken(ctx, querier, token)
 
@@ -179,3 +181,212 @@ func TestGetUserByToken(t *testing.T) {
 	})
 
 }
+
+func TestCreateSession(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockRefLinkRepo := new(mocks.MockReferenceLinkRepository)
+	mockImageRepo := new(mocks.MockImageRepository)
+	userService := NewUserService(mockUserRepo, mockRefLinkRepo, mockImageRepo)
+
+	ctx := context.Background()
+	querier := &models.Queries{}
+
+	t.Run("stores only the token hash", func(t *testing.T) {
+		user := &entities.UserEntity{ID: uuid.New()}
+		var storedHash string
+
+		mockUserRepo.On("CreateSession", ctx, querier, user, mock.MatchedBy(func(tokenHash string) bool {
+			storedHash = tokenHash
+			return true
+		}), mock.Anything).Return(entities.NewUserContextEntity(user, models.UserSession{ID: uuid.New()}), nil)
+
+		userSession, err := userService.CreateSession(ctx, querier, user)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, userSession.SessionToken)
+		assert.NotEqual(t, userSession.SessionToken, storedHash)
+		assert.Equal(t, entities.HashToken(userSession.SessionToken), storedHash)
+		mockUserRepo.AssertExpectations(t)
+	})
+}
+
+func TestTouchSession(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockRefLinkRepo := new(mocks.MockReferenceLinkRepository)
+	mockImageRepo := new(mocks.MockImageRepository)
+	userService := NewUserService(mockUserRepo, mockRefLinkRepo, mockImageRepo)
+
+	ctx := context.Background()
+	querier := &models.Queries{}
+	ip := "203.0.113.7"
+	userEntity := &entities.UserEntity{ID: uuid.New()}
+
+	t.Run("extends a stale session", func(t *testing.T) {
+		sessionID := uuid.New()
+		userContext := entities.NewUserContextEntity(userEntity, models.UserSession{
+			ID:         sessionID,
+			ExpiresAt:  time.Now().Add(time.Hour),
+			LastSeenAt: time.Now().Add(-time.Hour),
+			IpAddress:  &ip,
+		})
+
+		mockUserRepo.On("TouchSession", ctx, querier, sessionID, ip, mock.MatchedBy(func(expiresAt time.Time) bool {
+			return expiresAt.After(time.Now().Add(sessionDuration - time.Minute))
+		})).Return(nil).Once()
+
+		err := userService.TouchSession(ctx, querier, userContext, ip)
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("skips a session seen moments ago", func(t *testing.T) {
+		sessionID := uuid.New()
+		userContext := entities.NewUserContextEntity(userEntity, models.UserSession{
+			ID:         sessionID,
+			ExpiresAt:  time.Now().Add(time.Hour),
+			LastSeenAt: time.Now(),
+			IpAddress:  &ip,
+		})
+
+		err := userService.TouchSession(ctx, querier, userContext, ip)
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertNotCalled(t, "TouchSession", ctx, querier, sessionID, ip, mock.Anything)
+	})
+}
+
+func TestRevokeSession(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockRefLinkRepo := new(mocks.MockReferenceLinkRepository)
+	mockImageRepo := new(mocks.MockImageRepository)
+	userService := NewUserService(mockUserRepo, mockRefLinkRepo, mockImageRepo)
+
+	ctx := context.Background()
+	querier := &models.Queries{}
+	userID := uuid.New()
+
+	t.Run("revokes the user's session", func(t *testing.T) {
+		sessionID := uuid.New()
+		mockUserRepo.On("ExpireSession", ctx, querier, userID, sessionID).Return(nil)
+
+		err := userService.RevokeSession(ctx, querier, userID, sessionID)
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("session of another user", func(t *testing.T) {
+		sessionID := uuid.New()
+		mockUserRepo.On("ExpireSession", ctx, querier, userID, sessionID).Return(entities.ErrSessionNotFound)
+
+		err := userService.RevokeSession(ctx, querier, userID, sessionID)
+
+		assert.ErrorIs(t, err, entities.ErrSessionNotFound)
+	})
+}
+
+func TestStartImpersonation(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockRefLinkRepo := new(mocks.MockReferenceLinkRepository)
+	mockImageRepo := new(mocks.MockImageRepository)
+	userService := NewUserService(mockUserRepo, mockRefLinkRepo, mockImageRepo)
+
+	ctx := context.Background()
+	querier := &models.Queries{}
+	adminID := uuid.New()
+
+	t.Run("impersonate a host", func(t *testing.T) {
+		host := &entities.UserEntity{ID: uuid.New(), Role: entities.RoleHost}
+		expectedSession := entities.NewUserContextEntity(host, models.UserSession{ID: uuid.New(), ImpersonatorID: &adminID})
+
+		mockUserRepo.On("GetUserByID", ctx, querier, host.ID).Return(host, nil)
+		mockUserRepo.On("CreateImpersonationSession", ctx, querier, host, adminID, mock.Anything, mock.MatchedBy(func(expiresAt time.Time) bool {
+			return expiresAt.Before(time.Now().Add(impersonationDuration + time.Minute))
+		})).Return(expectedSession, nil)
+
+		userSession, err := userService.StartImpersonation(ctx, querier, adminID, host.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, expectedSession, userSession)
+		assert.True(t, userSession.IsImpersonated())
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("cannot impersonate an admin", func(t *testing.T) {
+		otherAdmin := &entities.UserEntity{ID: uuid.New(), Role: entities.RoleAdmin}
+		mockUserRepo.On("GetUserByID", ctx, querier, otherAdmin.ID).Return(otherAdmin, nil)
+
+		userSession, err := userService.StartImpersonation(ctx, querier, adminID, otherAdmin.ID)
+
+		assert.ErrorIs(t, err, entities.ErrImpersonationNotAllowed)
+		assert.Nil(t, userSession)
+	})
+
+	t.Run("cannot impersonate yourself", func(t *testing.T) {
+		userSession, err := userService.StartImpersonation(ctx, querier, adminID, adminID)
+
+		assert.ErrorIs(t, err, entities.ErrImpersonationNotAllowed)
+		assert.Nil(t, userSession)
+	})
+}
+
+func TestEndImpersonation(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockRefLinkRepo := new(mocks.MockReferenceLinkRepository)
+	mockImageRepo := new(mocks.MockImageRepository)
+	userService := NewUserService(mockUserRepo, mockRefLinkRepo, mockImageRepo)
+
+	ctx := context.Background()
+	querier := &models.Queries{}
+	user := &entities.UserEntity{ID: uuid.New()}
+
+	t.Run("ends an impersonation session", func(t *testing.T) {
+		adminID := uuid.New()
+		userContext := entities.NewUserContextEntity(user, models.UserSession{ID: uuid.New(), ImpersonatorID: &adminID})
+		mockUserRepo.On("ExpireSession", ctx, querier, user.ID, userContext.SessionID).Return(nil)
+
+		err := userService.EndImpersonation(ctx, querier, userContext)
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("ordinary sessions are left alone", func(t *testing.T) {
+		userContext := entities.NewUserContextEntity(user, models.UserSession{ID: uuid.New()})
+
+		err := userService.EndImpersonation(ctx, querier, userContext)
+
+		assert.ErrorIs(t, err, entities.ErrNotImpersonating)
+	})
+}
+
+func TestAuditImpersonatedRequest(t *testing.T) {
+	mockUserRepo := new(mocks.MockUserRepository)
+	mockRefLinkRepo := new(mocks.MockReferenceLinkRepository)
+	mockImageRepo := new(mocks.MockImageRepository)
+	userService := NewUserService(mockUserRepo, mockRefLinkRepo, mockImageRepo)
+
+	ctx := context.Background()
+	querier := &models.Queries{}
+	user := &entities.UserEntity{ID: uuid.New()}
+
+	t.Run("records both identities", func(t *testing.T) {
+		adminID := uuid.New()
+		userContext := entities.NewUserContextEntity(user, models.UserSession{ID: uuid.New(), ImpersonatorID: &adminID})
+		mockUserRepo.On("CreateImpersonationAudit", ctx, querier, adminID, user.ID, userContext.SessionID, "PUT", "/user/"+user.ID.String()).Return(nil)
+
+		err := userService.AuditImpersonatedRequest(ctx, querier, userContext, "PUT", "/user/"+user.ID.String())
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("ordinary sessions are not audited", func(t *testing.T) {
+		userContext := entities.NewUserContextEntity(user, models.UserSession{ID: uuid.New()})
+
+		err := userService.AuditImpersonatedRequest(ctx, querier, userContext, "PUT", "/user/"+user.ID.String())
+
+		assert.ErrorIs(t, err, entities.ErrNotImpersonating)
+	})
+}
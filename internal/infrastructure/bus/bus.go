@@ -0,0 +1,18 @@
+package bus
+
+// Bus fans messages out to the subscribers of a topic. MessageBus delivers
+// within a single process; PostgresBus also reaches every other instance
+// sharing the database.
+type Bus[T any] interface {
+	Subscribe(topic string) (<-chan T, string, error)
+	Unsubscribe(topic string, subscriberID string) error
+	Publish(topic string, message T)
+	Replay(topic string, afterSeq int64) ([]T, bool)
+	Done() <-chan struct{}
+	Close()
+}
+
+var (
+	_ Bus[any] = (*MessageBus[any])(nil)
+	_ Bus[any] = (*PostgresBus[any])(nil)
+)
@@ -0,0 +1,240 @@
+package bus
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/mcorrigan89/openmic/internal/common"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres"
+	"github.com/rs/zerolog"
+)
+
+const listenRetryInterval = 5 * time.Second
+
+// Codec converts messages to and from NOTIFY payloads. Payloads are limited
+// to a few kilobytes, so a codec should send a reference to the data rather
+// than the data itself and load it again in Decode.
+type Codec[T any] interface {
+	Encode(message T) (seq int64, data json.RawMessage, err error)
+	Decode(ctx context.Context, topic string, seq int64, data json.RawMessage) (T, error)
+}
+
+type notification struct {
+	Topic string          `json:"topic"`
+	Seq   int64           `json:"seq"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// PostgresBus delivers messages to local subscribers and to every other
+// instance listening on the same channel. Each message carries its topic and
+// a sequence number that increases per topic; a message is delivered at most
+// once per instance, including the echo of its own notifications.
+type PostgresBus[T any] struct {
+	local   *MessageBus[T]
+	db      *pgxpool.Pool
+	logger  *zerolog.Logger
+	channel string
+	codec   Codec[T]
+
+	mu   sync.Mutex
+	seen map[string]int64
+
+	cancel  context.CancelFunc
+	stopped chan struct{}
+}
+
+func newPostgresBus[T any](local *MessageBus[T], db *pgxpool.Pool, logger *zerolog.Logger, channel string, codec Codec[T]) *PostgresBus[T] {
+	return &PostgresBus[T]{
+		local:   local,
+		db:      db,
+		logger:  logger,
+		channel: channel,
+		codec:   codec,
+		seen:    make(map[string]int64),
+		stopped: make(chan struct{}),
+	}
+}
+
+// NewPostgresBus delivers to subscribers through local, which also decides
+// whether messages are kept for Replay. It starts listening on channel before
+// returning so that no notification sent after it returns is missed.
+func NewPostgresBus[T any](local *MessageBus[T], db *pgxpool.Pool, logger *zerolog.Logger, channel string, codec Codec[T]) (*PostgresBus[T], error) {
+	bus := newPostgresBus(local, db, logger, channel, codec)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	bus.cancel = cancel
+
+	conn, err := bus.listen(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go bus.run(ctx, conn)
+
+	return bus, nil
+}
+
+func (bus *PostgresBus[T]) Subscribe(topic string) (<-chan T, string, error) {
+	return bus.local.Subscribe(topic)
+}
+
+func (bus *PostgresBus[T]) Unsubscribe(topic string, subscriberID string) error {
+	return bus.local.Unsubscribe(topic, subscriberID)
+}
+
+func (bus *PostgresBus[T]) Replay(topic string, afterSeq int64) ([]T, bool) {
+	return bus.local.Replay(topic, afterSeq)
+}
+
+func (bus *PostgresBus[T]) Done() <-chan struct{} {
+	return bus.local.Done()
+}
+
+func (bus *PostgresBus[T]) Publish(topic string, message T) {
+	seq, data, err := bus.codec.Encode(message)
+	if err != nil {
+		bus.logger.Err(err).Str("topic", topic).Msg("Failed to encode message")
+		return
+	}
+
+	if !bus.markSeen(topic, seq) {
+		return
+	}
+
+	bus.local.Publish(topic, message)
+
+	payload, err := json.Marshal(notification{Topic: topic, Seq: seq, Data: data})
+	if err != nil {
+		bus.logger.Err(err).Str("topic", topic).Msg("Failed to marshal notification")
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), postgres.DefaultTimeout)
+	defer cancel()
+
+	_, err = bus.db.Exec(ctx, "SELECT pg_notify($1, $2)", bus.channel, string(payload))
+	if err != nil {
+		bus.logger.Err(err).Str("topic", topic).Int64("seq", seq).Msg("Failed to notify")
+	}
+}
+
+func (bus *PostgresBus[T]) Close() {
+	if bus.cancel != nil {
+		bus.cancel()
+		<-bus.stopped
+	}
+
+	bus.local.Close()
+}
+
+// markSeen records seq for topic and reports whether it is newer than any
+// message already delivered. Messages without a sequence are always delivered.
+func (bus *PostgresBus[T]) markSeen(topic string, seq int64) bool {
+	if seq <= 0 {
+		return true
+	}
+
+	bus.mu.Lock()
+	defer bus.mu.Unlock()
+
+	if seq <= bus.seen[topic] {
+		return false
+	}
+
+	bus.seen[topic] = seq
+	return true
+}
+
+func (bus *PostgresBus[T]) listen(ctx context.Context) (*pgxpool.Conn, error) {
+	conn, err := bus.db.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = conn.Exec(ctx, "LISTEN "+pgx.Identifier{bus.channel}.Sanitize())
+	if err != nil {
+		conn.Release()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+// run holds a dedicated connection for as long as the bus is open, and
+// reconnects if it is lost. Notifications sent while reconnecting are lost;
+// clients recover them from a fresh snapshot.
+func (bus *PostgresBus[T]) run(ctx context.Context, conn *pgxpool.Conn) {
+	defer close(bus.stopped)
+
+	for {
+		err := bus.wait(ctx, conn)
+		// The connection may still be listening, so it must not go back to the pool.
+		conn.Hijack().Close(context.Background())
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		bus.logger.Err(err).Str("channel", bus.channel).Msg("Lost notification listener, reconnecting")
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(listenRetryInterval):
+			}
+
+			conn, err = bus.listen(ctx)
+			if err == nil {
+				break
+			}
+			bus.logger.Err(err).Str("channel", bus.channel).Msg("Failed to listen for notifications")
+		}
+	}
+}
+
+func (bus *PostgresBus[T]) wait(ctx context.Context, conn *pgxpool.Conn) error {
+	for {
+		n, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		bus.receive(ctx, n.Payload)
+	}
+}
+
+func (bus *PostgresBus[T]) receive(ctx context.Context, payload string) {
+	// One bad notification must not stop the listener.
+	defer common.RecoverPanic(ctx, bus.logger, "Recovered from panic handling notification")
+
+	var n notification
+	err := json.Unmarshal([]byte(payload), &n)
+	if err != nil {
+		bus.logger.Err(err).Str("channel", bus.channel).Msg("Failed to unmarshal notification")
+		return
+	}
+
+	if !bus.markSeen(n.Topic, n.Seq) {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	message, err := bus.codec.Decode(ctx, n.Topic, n.Seq, n.Data)
+	if err != nil {
+		if !errors.Is(err, context.Canceled) {
+			bus.logger.Err(err).Str("topic", n.Topic).Int64("seq", n.Seq).Msg("Failed to decode notification")
+		}
+		return
+	}
+
+	bus.local.Publish(n.Topic, message)
+}
@@ -0,0 +1,132 @@
+package bus
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+)
+
+type testMessage struct {
+	Seq  int64  `json:"seq"`
+	Text string `json:"text"`
+}
+
+type testCodec struct{}
+
+func (testCodec) Encode(message testMessage) (int64, json.RawMessage, error) {
+	data, err := json.Marshal(message)
+	return message.Seq, data, err
+}
+
+func (testCodec) Decode(ctx context.Context, topic string, seq int64, data json.RawMessage) (testMessage, error) {
+	var message testMessage
+	err := json.Unmarshal(data, &message)
+	return message, err
+}
+
+func testNotification(t *testing.T, topic string, message testMessage) string {
+	data, err := json.Marshal(message)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := json.Marshal(notification{Topic: topic, Seq: message.Seq, Data: data})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(payload)
+}
+
+func receiveWithin(t *testing.T, ch <-chan testMessage) (testMessage, bool) {
+	t.Helper()
+	select {
+	case message := <-ch:
+		return message, true
+	case <-time.After(2 * time.Second):
+		return testMessage{}, false
+	}
+}
+
+func TestPostgresBusDeduplicates(t *testing.T) {
+	logger := zerolog.Nop()
+
+	t.Run("drops repeated and stale notifications", func(t *testing.T) {
+		bus := newPostgresBus(NewMessageBus[testMessage](), nil, &logger, "test", testCodec{})
+		defer bus.Close()
+
+		ch, _, err := bus.Subscribe("event-1")
+		assert.NoError(t, err)
+
+		bus.receive(context.Background(), testNotification(t, "event-1", testMessage{Seq: 2, Text: "second"}))
+		bus.receive(context.Background(), testNotification(t, "event-1", testMessage{Seq: 2, Text: "second"}))
+		bus.receive(context.Background(), testNotification(t, "event-1", testMessage{Seq: 1, Text: "first"}))
+
+		assert.Equal(t, testMessage{Seq: 2, Text: "second"}, <-ch)
+		assert.Len(t, ch, 0)
+	})
+
+	t.Run("sequences are tracked per topic", func(t *testing.T) {
+		bus := newPostgresBus(NewMessageBus[testMessage](), nil, &logger, "test", testCodec{})
+		defer bus.Close()
+
+		first, _, err := bus.Subscribe("event-1")
+		assert.NoError(t, err)
+		second, _, err := bus.Subscribe("event-2")
+		assert.NoError(t, err)
+
+		bus.receive(context.Background(), testNotification(t, "event-1", testMessage{Seq: 5}))
+		bus.receive(context.Background(), testNotification(t, "event-2", testMessage{Seq: 1}))
+
+		assert.Len(t, first, 1)
+		assert.Len(t, second, 1)
+	})
+}
+
+func TestPostgresBus(t *testing.T) {
+	dsn := os.Getenv("TEST_DATABASE_URL")
+	if dsn == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := pgxpool.New(context.Background(), dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	logger := zerolog.Nop()
+	channel := "bus_test_" + time.Now().Format("150405")
+
+	t.Run("fans out to other instances exactly once", func(t *testing.T) {
+		first, err := NewPostgresBus(NewMessageBus[testMessage](), db, &logger, channel, testCodec{})
+		assert.NoError(t, err)
+		defer first.Close()
+		second, err := NewPostgresBus(NewMessageBus[testMessage](), db, &logger, channel, testCodec{})
+		assert.NoError(t, err)
+		defer second.Close()
+
+		local, _, err := first.Subscribe("event-1")
+		assert.NoError(t, err)
+		remote, _, err := second.Subscribe("event-1")
+		assert.NoError(t, err)
+
+		first.Publish("event-1", testMessage{Seq: 1, Text: "hello"})
+
+		message, ok := receiveWithin(t, local)
+		assert.True(t, ok)
+		assert.Equal(t, "hello", message.Text)
+
+		message, ok = receiveWithin(t, remote)
+		assert.True(t, ok)
+		assert.Equal(t, "hello", message.Text)
+
+		// Give the echo of the first instance's own notification time to arrive.
+		_, ok = receiveWithin(t, local)
+		assert.False(t, ok)
+	})
+}
@@ -1,20 +1,33 @@
 package bus
 
 import (
+	"errors"
 	"fmt"
 	"sync"
-	"time"
+
+	"github.com/google/uuid"
 )
 
+var ErrBusClosed = errors.New("message bus closed")
+
+const subscriberBufferSize = 16
+
 type MessageBus[T any] struct {
-	subscribers   map[string]chan T
+	topics        map[string]map[string]chan T
 	mu            sync.RWMutex
 	closeNotifier chan struct{}
+	closeOnce     sync.Once
+	closed        bool
+
+	// history keeps the most recent messages per topic for Replay.
+	history     map[string][]T
+	historySize int
+	sequence    func(T) int64
 }
 
 func NewMessageBus[T any]() *MessageBus[T] {
 	p := &MessageBus[T]{
-		subscribers:   make(map[string]chan T),
+		topics:        make(map[string]map[string]chan T),
 		mu:            sync.RWMutex{},
 		closeNotifier: make(chan struct{}),
 	}
@@ -22,37 +35,69 @@ func NewMessageBus[T any]() *MessageBus[T] {
 	return p
 }
 
-func (bus *MessageBus[T]) Subscribe() (<-chan T, string, error) {
+// NewReplayMessageBus keeps the last historySize messages of each topic so
+// that reconnecting subscribers can catch up. Sequence numbers must increase
+// by one with each message on a topic.
+func NewReplayMessageBus[T any](historySize int, sequence func(T) int64) *MessageBus[T] {
+	p := NewMessageBus[T]()
+	p.history = make(map[string][]T)
+	p.historySize = historySize
+	p.sequence = sequence
+
+	return p
+}
+
+func (bus *MessageBus[T]) Subscribe(topic string) (<-chan T, string, error) {
 	bus.mu.Lock()
 	defer bus.mu.Unlock()
 
-	subscriberID := fmt.Sprintf("%d", time.Now().UnixNano())
+	if bus.closed {
+		return nil, "", ErrBusClosed
+	}
+
+	subscriberID := uuid.NewString()
 
-	ch := make(chan T)
-	bus.subscribers[subscriberID] = ch
+	subscribers, exists := bus.topics[topic]
+	if !exists {
+		subscribers = make(map[string]chan T)
+		bus.topics[topic] = subscribers
+	}
+
+	ch := make(chan T, subscriberBufferSize)
+	subscribers[subscriberID] = ch
 
 	return ch, subscriberID, nil
 }
 
-func (bus *MessageBus[T]) Unsubscribe(subscriberID string) error {
+func (bus *MessageBus[T]) Unsubscribe(topic string, subscriberID string) error {
 	bus.mu.Lock()
 	defer bus.mu.Unlock()
 
-	ch, exists := bus.subscribers[subscriberID]
+	subscribers := bus.topics[topic]
+	ch, exists := subscribers[subscriberID]
 	if !exists {
 		return fmt.Errorf("subscriber with ID %s does not exist", subscriberID)
 	}
 
 	close(ch)
-	delete(bus.subscribers, subscriberID)
+	delete(subscribers, subscriberID)
+	if len(subscribers) == 0 {
+		delete(bus.topics, topic)
+	}
 	return nil
 }
 
-func (bus *MessageBus[T]) Publish(message T) {
-	bus.mu.Lock()
-	defer bus.mu.Unlock()
+func (bus *MessageBus[T]) Publish(topic string, message T) {
+	if bus.sequence != nil {
+		bus.mu.Lock()
+		bus.record(topic, message)
+		bus.mu.Unlock()
+	}
+
+	bus.mu.RLock()
+	defer bus.mu.RUnlock()
 
-	for _, ch := range bus.subscribers {
+	for _, ch := range bus.topics[topic] {
 		select {
 		case ch <- message:
 		default:
@@ -60,17 +105,73 @@ func (bus *MessageBus[T]) Publish(message T) {
 	}
 }
 
-func (bus *MessageBus[T]) Close() {
-	// Signal that we're closing
-	close(bus.closeNotifier)
+// record appends message to the topic's history. A gap in the sequence
+// starts the history over, since the missing messages cannot be replayed.
+func (bus *MessageBus[T]) record(topic string, message T) {
+	history := bus.history[topic]
+	if len(history) > 0 && bus.sequence(history[len(history)-1])+1 != bus.sequence(message) {
+		history = nil
+	}
 
-	// Close all subscriber channels
-	bus.mu.Lock()
-	defer bus.mu.Unlock()
+	history = append(history, message)
+	if len(history) > bus.historySize {
+		history = history[len(history)-bus.historySize:]
+	}
+
+	bus.history[topic] = history
+}
 
-	for _, ch := range bus.subscribers {
-		close(ch)
+// Replay returns the messages on topic with a sequence after afterSeq, in
+// order. It reports false when some of them are no longer held, in which case
+// the caller has to start over from the current state.
+func (bus *MessageBus[T]) Replay(topic string, afterSeq int64) ([]T, bool) {
+	if bus.sequence == nil {
+		return nil, false
 	}
 
-	bus.subscribers = make(map[string]chan T)
+	bus.mu.RLock()
+	defer bus.mu.RUnlock()
+
+	history := bus.history[topic]
+	if len(history) == 0 || bus.sequence(history[0]) > afterSeq+1 {
+		return nil, false
+	}
+
+	messages := make([]T, 0)
+	for _, message := range history {
+		if bus.sequence(message) > afterSeq {
+			messages = append(messages, message)
+		}
+	}
+
+	return messages, true
+}
+
+// Done is closed once the bus shuts down so long-lived subscribers can end
+// their streams.
+func (bus *MessageBus[T]) Done() <-chan struct{} {
+	return bus.closeNotifier
+}
+
+func (bus *MessageBus[T]) Close() {
+	bus.closeOnce.Do(func() {
+		// Signal that we're closing
+		close(bus.closeNotifier)
+
+		// Close all subscriber channels
+		bus.mu.Lock()
+		defer bus.mu.Unlock()
+
+		bus.closed = true
+		for _, subscribers := range bus.topics {
+			for _, ch := range subscribers {
+				close(ch)
+			}
+		}
+
+		bus.topics = make(map[string]map[string]chan T)
+		if bus.history != nil {
+			bus.history = make(map[string][]T)
+		}
+	})
 }
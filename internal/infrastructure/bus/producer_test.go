@@ -0,0 +1,139 @@
+package bus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageBus(t *testing.T) {
+
+	t.Run("publishes only to subscribers of the topic", func(t *testing.T) {
+		bus := NewMessageBus[string]()
+		defer bus.Close()
+
+		first, _, err := bus.Subscribe("event-1")
+		assert.NoError(t, err)
+		second, _, err := bus.Subscribe("event-2")
+		assert.NoError(t, err)
+
+		bus.Publish("event-1", "hello")
+
+		assert.Equal(t, "hello", <-first)
+		assert.Len(t, second, 0)
+	})
+
+	t.Run("subscriber ids are unique", func(t *testing.T) {
+		bus := NewMessageBus[string]()
+		defer bus.Close()
+
+		ids := make(map[string]bool)
+		for i := 0; i < 1000; i++ {
+			_, id, err := bus.Subscribe("event")
+			assert.NoError(t, err)
+			assert.False(t, ids[id])
+			ids[id] = true
+		}
+	})
+
+	t.Run("unsubscribe closes the channel", func(t *testing.T) {
+		bus := NewMessageBus[string]()
+		defer bus.Close()
+
+		ch, id, err := bus.Subscribe("event")
+		assert.NoError(t, err)
+
+		assert.NoError(t, bus.Unsubscribe("event", id))
+		_, ok := <-ch
+		assert.False(t, ok)
+		assert.Error(t, bus.Unsubscribe("event", id))
+	})
+
+	t.Run("close ends subscriptions and rejects new ones", func(t *testing.T) {
+		bus := NewMessageBus[string]()
+
+		ch, id, err := bus.Subscribe("event")
+		assert.NoError(t, err)
+
+		bus.Close()
+		bus.Close()
+
+		_, ok := <-ch
+		assert.False(t, ok)
+		_, ok = <-bus.Done()
+		assert.False(t, ok)
+		assert.Error(t, bus.Unsubscribe("event", id))
+
+		_, _, err = bus.Subscribe("event")
+		assert.ErrorIs(t, err, ErrBusClosed)
+	})
+}
+
+func TestMessageBusReplay(t *testing.T) {
+
+	newBus := func(size int) *MessageBus[int64] {
+		return NewReplayMessageBus(size, func(seq int64) int64 { return seq })
+	}
+
+	t.Run("replays messages after the given sequence", func(t *testing.T) {
+		bus := newBus(10)
+		defer bus.Close()
+
+		for seq := int64(1); seq <= 5; seq++ {
+			bus.Publish("event", seq)
+		}
+
+		messages, ok := bus.Replay("event", 3)
+		assert.True(t, ok)
+		assert.Equal(t, []int64{4, 5}, messages)
+
+		messages, ok = bus.Replay("event", 5)
+		assert.True(t, ok)
+		assert.Empty(t, messages)
+	})
+
+	t.Run("reports messages that are no longer held", func(t *testing.T) {
+		bus := newBus(3)
+		defer bus.Close()
+
+		for seq := int64(1); seq <= 5; seq++ {
+			bus.Publish("event", seq)
+		}
+
+		_, ok := bus.Replay("event", 1)
+		assert.False(t, ok)
+
+		messages, ok := bus.Replay("event", 2)
+		assert.True(t, ok)
+		assert.Equal(t, []int64{3, 4, 5}, messages)
+
+		_, ok = bus.Replay("other", 0)
+		assert.False(t, ok)
+	})
+
+	t.Run("a gap in the sequence starts the history over", func(t *testing.T) {
+		bus := newBus(10)
+		defer bus.Close()
+
+		bus.Publish("event", 1)
+		bus.Publish("event", 2)
+		bus.Publish("event", 4)
+
+		_, ok := bus.Replay("event", 2)
+		assert.False(t, ok)
+
+		messages, ok := bus.Replay("event", 3)
+		assert.True(t, ok)
+		assert.Equal(t, []int64{4}, messages)
+	})
+
+	t.Run("buses without history cannot replay", func(t *testing.T) {
+		bus := NewMessageBus[int64]()
+		defer bus.Close()
+
+		bus.Publish("event", 1)
+
+		_, ok := bus.Replay("event", 0)
+		assert.False(t, ok)
+	})
+}
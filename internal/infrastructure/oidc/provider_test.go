@@ -0,0 +1,195 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubIssuer is a minimal OpenID provider that issues an ID token for the
+// code "good-code" when the matching PKCE verifier is presented.
+type stubIssuer struct {
+	server   *httptest.Server
+	key      *rsa.PrivateKey
+	claims   map[string]any
+	verifier string
+}
+
+func newStubIssuer(t *testing.T) *stubIssuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	issuer := &stubIssuer{key: key}
+	mux := http.NewServeMux()
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 issuer.server.URL,
+			"authorization_endpoint": issuer.server.URL + "/authorize",
+			"token_endpoint":         issuer.server.URL + "/token",
+			"jwks_uri":               issuer.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"keys": []map[string]string{{
+				"kty": "RSA",
+				"kid": "test-key",
+				"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+				"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+			}},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("code") != "good-code" || r.Form.Get("code_verifier") != issuer.verifier {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"id_token": issuer.sign(t, issuer.claims)})
+	})
+
+	return issuer
+}
+
+func (s *stubIssuer) sign(t *testing.T, claims map[string]any) string {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "kid": "test-key", "typ": "JWT"})
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func (s *stubIssuer) validClaims() map[string]any {
+	return map[string]any{
+		"iss":            s.server.URL,
+		"sub":            "subject-1",
+		"aud":            "client-id",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          "nonce-1",
+		"email":          "test@example.com",
+		"email_verified": true,
+		"given_name":     "Test",
+	}
+}
+
+func TestProvider(t *testing.T) {
+	ctx := context.Background()
+	issuer := newStubIssuer(t)
+	issuer.verifier = "verifier-1"
+
+	provider := NewProvider(Config{
+		Name:        "stub",
+		Issuer:      issuer.server.URL,
+		ClientID:    "client-id",
+		RedirectURL: "http://localhost:3000/auth/callback",
+	})
+
+	t.Run("authorization url carries state, nonce and PKCE challenge", func(t *testing.T) {
+		authURL, err := provider.AuthorizationURL(ctx, "state-1", "nonce-1", entities.CodeChallenge("verifier-1"))
+		require.NoError(t, err)
+
+		parsed, err := url.Parse(authURL)
+		require.NoError(t, err)
+		assert.Equal(t, "/authorize", parsed.Path)
+
+		query := parsed.Query()
+		assert.Equal(t, "code", query.Get("response_type"))
+		assert.Equal(t, "client-id", query.Get("client_id"))
+		assert.Equal(t, "state-1", query.Get("state"))
+		assert.Equal(t, "nonce-1", query.Get("nonce"))
+		assert.Equal(t, entities.CodeChallenge("verifier-1"), query.Get("code_challenge"))
+		assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	})
+
+	t.Run("exchange returns verified claims", func(t *testing.T) {
+		issuer.claims = issuer.validClaims()
+
+		claims, err := provider.Exchange(ctx, "good-code", "verifier-1")
+		require.NoError(t, err)
+
+		assert.Equal(t, "stub", claims.Provider)
+		assert.Equal(t, "subject-1", claims.Subject)
+		assert.Equal(t, "test@example.com", claims.Email)
+		assert.True(t, claims.EmailVerified)
+		assert.Equal(t, "nonce-1", claims.Nonce)
+		assert.Equal(t, "Test", *claims.GivenName)
+		assert.Nil(t, claims.FamilyName)
+	})
+
+	t.Run("wrong verifier is rejected", func(t *testing.T) {
+		issuer.claims = issuer.validClaims()
+
+		claims, err := provider.Exchange(ctx, "good-code", "other-verifier")
+
+		assert.ErrorIs(t, err, entities.ErrLoginRequestInvalid)
+		assert.Nil(t, claims)
+	})
+
+	t.Run("token for another client is rejected", func(t *testing.T) {
+		issuer.claims = issuer.validClaims()
+		issuer.claims["aud"] = []string{"other-client"}
+
+		claims, err := provider.Exchange(ctx, "good-code", "verifier-1")
+
+		assert.ErrorIs(t, err, entities.ErrLoginRequestInvalid)
+		assert.Nil(t, claims)
+	})
+
+	t.Run("expired token is rejected", func(t *testing.T) {
+		issuer.claims = issuer.validClaims()
+		issuer.claims["exp"] = time.Now().Add(-time.Hour).Unix()
+
+		claims, err := provider.Exchange(ctx, "good-code", "verifier-1")
+
+		assert.ErrorIs(t, err, entities.ErrLoginRequestInvalid)
+		assert.Nil(t, claims)
+	})
+
+	t.Run("token from another issuer is rejected", func(t *testing.T) {
+		issuer.claims = issuer.validClaims()
+		issuer.claims["iss"] = "https://attacker.example.com"
+
+		claims, err := provider.Exchange(ctx, "good-code", "verifier-1")
+
+		assert.ErrorIs(t, err, entities.ErrLoginRequestInvalid)
+		assert.Nil(t, claims)
+	})
+
+	t.Run("token signed with another key is rejected", func(t *testing.T) {
+		otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+		require.NoError(t, err)
+		token := (&stubIssuer{key: otherKey}).sign(t, issuer.validClaims())
+
+		discovery, err := provider.getDiscovery(ctx)
+		require.NoError(t, err)
+		claims, err := provider.verifyIDToken(ctx, discovery, token)
+
+		assert.ErrorIs(t, err, entities.ErrLoginRequestInvalid)
+		assert.Nil(t, claims)
+	})
+}
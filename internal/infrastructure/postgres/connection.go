@@ -68,7 +68,7 @@ func CreateTransaction(ctx context.Context, db *pgxpool.Pool) (pgx.Tx, context.C
 	tx, err := db.Begin(ctx)
 	if err != nil {
 		cancel()
-		return nil, nil, err
+		return nil, cancel, err
 	}
 
 	return tx, cancel, nil
@@ -0,0 +1,234 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: api_key.sql
+
+package models
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const createAPIKey = `-- name: CreateAPIKey :one
+INSERT INTO api_key (id, key_name, key_hash, scopes, organization_id, rate_limit_per_minute, created_by)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4::text[],
+    $5,
+    $6,
+    $7
+) RETURNING id, key_name, key_hash, scopes, organization_id, rate_limit_per_minute, created_by, last_used_at, revoked_at, created_at, updated_at, version
+`
+
+type CreateAPIKeyParams struct {
+	ID                 uuid.UUID  `json:"id"`
+	KeyName            string     `json:"key_name"`
+	KeyHash            string     `json:"key_hash"`
+	Scopes             []string   `json:"scopes"`
+	OrganizationID     *uuid.UUID `json:"organization_id"`
+	RateLimitPerMinute int32      `json:"rate_limit_per_minute"`
+	CreatedBy          *uuid.UUID `json:"created_by"`
+}
+
+func (q *Queries) CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error) {
+	row := q.db.QueryRow(ctx, createAPIKey,
+		arg.ID,
+		arg.KeyName,
+		arg.KeyHash,
+		arg.Scopes,
+		arg.OrganizationID,
+		arg.RateLimitPerMinute,
+		arg.CreatedBy,
+	)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.KeyName,
+		&i.KeyHash,
+		&i.Scopes,
+		&i.OrganizationID,
+		&i.RateLimitPerMinute,
+		&i.CreatedBy,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const getAPIKeyByID = `-- name: GetAPIKeyByID :one
+SELECT api_key.id, api_key.key_name, api_key.key_hash, api_key.scopes, api_key.organization_id, api_key.rate_limit_per_minute, api_key.created_by, api_key.last_used_at, api_key.revoked_at, api_key.created_at, api_key.updated_at, api_key.version FROM api_key
+WHERE api_key.id = $1
+`
+
+type GetAPIKeyByIDRow struct {
+	ApiKey ApiKey `json:"api_key"`
+}
+
+func (q *Queries) GetAPIKeyByID(ctx context.Context, id uuid.UUID) (GetAPIKeyByIDRow, error) {
+	row := q.db.QueryRow(ctx, getAPIKeyByID, id)
+	var i GetAPIKeyByIDRow
+	err := row.Scan(
+		&i.ApiKey.ID,
+		&i.ApiKey.KeyName,
+		&i.ApiKey.KeyHash,
+		&i.ApiKey.Scopes,
+		&i.ApiKey.OrganizationID,
+		&i.ApiKey.RateLimitPerMinute,
+		&i.ApiKey.CreatedBy,
+		&i.ApiKey.LastUsedAt,
+		&i.ApiKey.RevokedAt,
+		&i.ApiKey.CreatedAt,
+		&i.ApiKey.UpdatedAt,
+		&i.ApiKey.Version,
+	)
+	return i, err
+}
+
+const getAPIKeys = `-- name: GetAPIKeys :many
+SELECT api_key.id, api_key.key_name, api_key.key_hash, api_key.scopes, api_key.organization_id, api_key.rate_limit_per_minute, api_key.created_by, api_key.last_used_at, api_key.revoked_at, api_key.created_at, api_key.updated_at, api_key.version FROM api_key
+ORDER BY api_key.created_at
+`
+
+type GetAPIKeysRow struct {
+	ApiKey ApiKey `json:"api_key"`
+}
+
+func (q *Queries) GetAPIKeys(ctx context.Context) ([]GetAPIKeysRow, error) {
+	rows, err := q.db.Query(ctx, getAPIKeys)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetAPIKeysRow{}
+	for rows.Next() {
+		var i GetAPIKeysRow
+		if err := rows.Scan(
+			&i.ApiKey.ID,
+			&i.ApiKey.KeyName,
+			&i.ApiKey.KeyHash,
+			&i.ApiKey.Scopes,
+			&i.ApiKey.OrganizationID,
+			&i.ApiKey.RateLimitPerMinute,
+			&i.ApiKey.CreatedBy,
+			&i.ApiKey.LastUsedAt,
+			&i.ApiKey.RevokedAt,
+			&i.ApiKey.CreatedAt,
+			&i.ApiKey.UpdatedAt,
+			&i.ApiKey.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getActiveAPIKeyByHash = `-- name: GetActiveAPIKeyByHash :one
+SELECT api_key.id, api_key.key_name, api_key.key_hash, api_key.scopes, api_key.organization_id, api_key.rate_limit_per_minute, api_key.created_by, api_key.last_used_at, api_key.revoked_at, api_key.created_at, api_key.updated_at, api_key.version FROM api_key
+WHERE api_key.key_hash = $1 AND api_key.revoked_at IS NULL
+`
+
+type GetActiveAPIKeyByHashRow struct {
+	ApiKey ApiKey `json:"api_key"`
+}
+
+func (q *Queries) GetActiveAPIKeyByHash(ctx context.Context, keyHash string) (GetActiveAPIKeyByHashRow, error) {
+	row := q.db.QueryRow(ctx, getActiveAPIKeyByHash, keyHash)
+	var i GetActiveAPIKeyByHashRow
+	err := row.Scan(
+		&i.ApiKey.ID,
+		&i.ApiKey.KeyName,
+		&i.ApiKey.KeyHash,
+		&i.ApiKey.Scopes,
+		&i.ApiKey.OrganizationID,
+		&i.ApiKey.RateLimitPerMinute,
+		&i.ApiKey.CreatedBy,
+		&i.ApiKey.LastUsedAt,
+		&i.ApiKey.RevokedAt,
+		&i.ApiKey.CreatedAt,
+		&i.ApiKey.UpdatedAt,
+		&i.ApiKey.Version,
+	)
+	return i, err
+}
+
+const revokeAPIKey = `-- name: RevokeAPIKey :one
+UPDATE api_key SET
+    revoked_at = now(),
+    updated_at = now(),
+    version = version + 1
+WHERE api_key.id = $1 AND api_key.revoked_at IS NULL RETURNING id, key_name, key_hash, scopes, organization_id, rate_limit_per_minute, created_by, last_used_at, revoked_at, created_at, updated_at, version
+`
+
+func (q *Queries) RevokeAPIKey(ctx context.Context, id uuid.UUID) (ApiKey, error) {
+	row := q.db.QueryRow(ctx, revokeAPIKey, id)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.KeyName,
+		&i.KeyHash,
+		&i.Scopes,
+		&i.OrganizationID,
+		&i.RateLimitPerMinute,
+		&i.CreatedBy,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const rotateAPIKey = `-- name: RotateAPIKey :one
+UPDATE api_key SET
+    key_hash = $1,
+    updated_at = now(),
+    version = version + 1
+WHERE api_key.id = $2 AND api_key.revoked_at IS NULL RETURNING id, key_name, key_hash, scopes, organization_id, rate_limit_per_minute, created_by, last_used_at, revoked_at, created_at, updated_at, version
+`
+
+type RotateAPIKeyParams struct {
+	KeyHash string    `json:"key_hash"`
+	ID      uuid.UUID `json:"id"`
+}
+
+func (q *Queries) RotateAPIKey(ctx context.Context, arg RotateAPIKeyParams) (ApiKey, error) {
+	row := q.db.QueryRow(ctx, rotateAPIKey, arg.KeyHash, arg.ID)
+	var i ApiKey
+	err := row.Scan(
+		&i.ID,
+		&i.KeyName,
+		&i.KeyHash,
+		&i.Scopes,
+		&i.OrganizationID,
+		&i.RateLimitPerMinute,
+		&i.CreatedBy,
+		&i.LastUsedAt,
+		&i.RevokedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const touchAPIKey = `-- name: TouchAPIKey :exec
+UPDATE api_key SET last_used_at = now()
+WHERE api_key.id = $1 AND (api_key.last_used_at IS NULL OR api_key.last_used_at < now() - interval '1 minute')
+`
+
+func (q *Queries) TouchAPIKey(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, touchAPIKey, id)
+	return err
+}
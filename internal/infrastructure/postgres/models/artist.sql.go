@@ -12,8 +12,8 @@ import (
 )
 
 const createArtist = `-- name: CreateArtist :one
-INSERT INTO artist (id, artist_title, artist_subtitle, bio, avatar_id)
-VALUES ($1, $2, $3, $4, $5) RETURNING id, artist_title, artist_subtitle, bio, avatar_id, user_id, created_at, updated_at, version
+INSERT INTO artist (id, artist_title, artist_subtitle, bio, avatar_id, user_id, organization_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id, artist_title, artist_subtitle, bio, avatar_id, user_id, created_at, updated_at, version, organization_id
 `
 
 type CreateArtistParams struct {
@@ -22,6 +22,8 @@ type CreateArtistParams struct {
 	ArtistSubtitle *string    `json:"artist_subtitle"`
 	Bio            *string    `json:"bio"`
 	AvatarID       *uuid.UUID `json:"avatar_id"`
+	UserID         *uuid.UUID `json:"user_id"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
 }
 
 func (q *Queries) CreateArtist(ctx context.Context, arg CreateArtistParams) (Artist, error) {
@@ -31,6 +33,8 @@ func (q *Queries) CreateArtist(ctx context.Context, arg CreateArtistParams) (Art
 		arg.ArtistSubtitle,
 		arg.Bio,
 		arg.AvatarID,
+		arg.UserID,
+		arg.OrganizationID,
 	)
 	var i Artist
 	err := row.Scan(
@@ -43,22 +47,32 @@ func (q *Queries) CreateArtist(ctx context.Context, arg CreateArtistParams) (Art
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Version,
+		&i.OrganizationID,
 	)
 	return i, err
 }
 
-const deleteArtist = `-- name: DeleteArtist :exec
+const deleteArtist = `-- name: DeleteArtist :execrows
 DELETE FROM artist
-WHERE id = $1
+WHERE id = $1 AND version = $2
 `
 
-func (q *Queries) DeleteArtist(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, deleteArtist, id)
-	return err
+type DeleteArtistParams struct {
+	ID      uuid.UUID `json:"id"`
+	Version int32     `json:"version"`
+}
+
+func (q *Queries) DeleteArtist(ctx context.Context, arg DeleteArtistParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteArtist, arg.ID, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const getAllArtists = `-- name: GetAllArtists :many
-SELECT artist.id, artist.artist_title, artist.artist_subtitle, artist.bio, artist.avatar_id, artist.user_id, artist.created_at, artist.updated_at, artist.version FROM artist
+SELECT artist.id, artist.artist_title, artist.artist_subtitle, artist.bio, artist.avatar_id, artist.user_id, artist.created_at, artist.updated_at, artist.version, artist.organization_id FROM artist
+WHERE artist.organization_id = $1
 ORDER BY artist.artist_title ASC
 `
 
@@ -66,8 +80,8 @@ type GetAllArtistsRow struct {
 	Artist Artist `json:"artist"`
 }
 
-func (q *Queries) GetAllArtists(ctx context.Context) ([]GetAllArtistsRow, error) {
-	rows, err := q.db.Query(ctx, getAllArtists)
+func (q *Queries) GetAllArtists(ctx context.Context, organizationID uuid.UUID) ([]GetAllArtistsRow, error) {
+	rows, err := q.db.Query(ctx, getAllArtists, organizationID)
 	if err != nil {
 		return nil, err
 	}
@@ -85,6 +99,7 @@ func (q *Queries) GetAllArtists(ctx context.Context) ([]GetAllArtistsRow, error)
 			&i.Artist.CreatedAt,
 			&i.Artist.UpdatedAt,
 			&i.Artist.Version,
+			&i.Artist.OrganizationID,
 		); err != nil {
 			return nil, err
 		}
@@ -97,7 +112,7 @@ func (q *Queries) GetAllArtists(ctx context.Context) ([]GetAllArtistsRow, error)
 }
 
 const getArtistByID = `-- name: GetArtistByID :one
-SELECT artist.id, artist.artist_title, artist.artist_subtitle, artist.bio, artist.avatar_id, artist.user_id, artist.created_at, artist.updated_at, artist.version FROM artist
+SELECT artist.id, artist.artist_title, artist.artist_subtitle, artist.bio, artist.avatar_id, artist.user_id, artist.created_at, artist.updated_at, artist.version, artist.organization_id FROM artist
 WHERE artist.id = $1
 `
 
@@ -118,19 +133,21 @@ func (q *Queries) GetArtistByID(ctx context.Context, id uuid.UUID) (GetArtistByI
 		&i.Artist.CreatedAt,
 		&i.Artist.UpdatedAt,
 		&i.Artist.Version,
+		&i.Artist.OrganizationID,
 	)
 	return i, err
 }
 
 const getArtistsByTitle = `-- name: GetArtistsByTitle :many
-SELECT artist.id, artist.artist_title, artist.artist_subtitle, artist.bio, artist.avatar_id, artist.user_id, artist.created_at, artist.updated_at, artist.version FROM artist
-WHERE similarity(artist.artist_title, $1) > $2
-ORDER BY similarity(artist.artist_title, $1) DESC
+SELECT artist.id, artist.artist_title, artist.artist_subtitle, artist.bio, artist.avatar_id, artist.user_id, artist.created_at, artist.updated_at, artist.version, artist.organization_id FROM artist
+WHERE artist.organization_id = $1 AND similarity(artist.artist_title, $2) > $3
+ORDER BY similarity(artist.artist_title, $2) DESC
 `
 
 type GetArtistsByTitleParams struct {
-	Title         string  `json:"title"`
-	MinSimilarity float32 `json:"min_similarity"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+	Title          string    `json:"title"`
+	MinSimilarity  float32   `json:"min_similarity"`
 }
 
 type GetArtistsByTitleRow struct {
@@ -138,7 +155,7 @@ type GetArtistsByTitleRow struct {
 }
 
 func (q *Queries) GetArtistsByTitle(ctx context.Context, arg GetArtistsByTitleParams) ([]GetArtistsByTitleRow, error) {
-	rows, err := q.db.Query(ctx, getArtistsByTitle, arg.Title, arg.MinSimilarity)
+	rows, err := q.db.Query(ctx, getArtistsByTitle, arg.OrganizationID, arg.Title, arg.MinSimilarity)
 	if err != nil {
 		return nil, err
 	}
@@ -156,6 +173,7 @@ func (q *Queries) GetArtistsByTitle(ctx context.Context, arg GetArtistsByTitlePa
 			&i.Artist.CreatedAt,
 			&i.Artist.UpdatedAt,
 			&i.Artist.Version,
+			&i.Artist.OrganizationID,
 		); err != nil {
 			return nil, err
 		}
@@ -169,8 +187,8 @@ func (q *Queries) GetArtistsByTitle(ctx context.Context, arg GetArtistsByTitlePa
 
 const updateArtist = `-- name: UpdateArtist :one
 UPDATE artist
-SET artist_title = $2, artist_subtitle = $3, bio = $4, avatar_id = $5
-WHERE id = $1 RETURNING id, artist_title, artist_subtitle, bio, avatar_id, user_id, created_at, updated_at, version
+SET artist_title = $2, artist_subtitle = $3, bio = $4, avatar_id = $5, version = version + 1
+WHERE id = $1 AND version = $6 RETURNING id, artist_title, artist_subtitle, bio, avatar_id, user_id, created_at, updated_at, version, organization_id
 `
 
 type UpdateArtistParams struct {
@@ -179,6 +197,7 @@ type UpdateArtistParams struct {
 	ArtistSubtitle *string    `json:"artist_subtitle"`
 	Bio            *string    `json:"bio"`
 	AvatarID       *uuid.UUID `json:"avatar_id"`
+	Version        int32      `json:"version"`
 }
 
 func (q *Queries) UpdateArtist(ctx context.Context, arg UpdateArtistParams) (Artist, error) {
@@ -188,6 +207,7 @@ func (q *Queries) UpdateArtist(ctx context.Context, arg UpdateArtistParams) (Art
 		arg.ArtistSubtitle,
 		arg.Bio,
 		arg.AvatarID,
+		arg.Version,
 	)
 	var i Artist
 	err := row.Scan(
@@ -200,6 +220,7 @@ func (q *Queries) UpdateArtist(ctx context.Context, arg UpdateArtistParams) (Art
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Version,
+		&i.OrganizationID,
 	)
 	return i, err
 }
@@ -12,48 +12,95 @@ import (
 	"github.com/google/uuid"
 )
 
-const addArtistToEvent = `-- name: AddArtistToEvent :exec
-INSERT INTO timeslot (id, event_id, artist_id, artist_name_override, sort_key)
-VALUES ($1, $2, $3, $4, $5)
+const addArtistToEvent = `-- name: AddArtistToEvent :execrows
+INSERT INTO timeslot (id, event_id, artist_id, artist_name_override, sort_key, song_count)
+SELECT $1, event.id, artist.id, $2, $3, $4
+FROM event
+JOIN venue ON venue.id = event.venue_id
+JOIN artist ON artist.organization_id = venue.organization_id
+WHERE event.id = $5 AND artist.id = $6
 `
 
 type AddArtistToEventParams struct {
 	ID                 uuid.UUID `json:"id"`
-	EventID            uuid.UUID `json:"event_id"`
-	ArtistID           uuid.UUID `json:"artist_id"`
 	ArtistNameOverride *string   `json:"artist_name_override"`
 	SortKey            string    `json:"sort_key"`
+	SongCount          int32     `json:"song_count"`
+	EventID            uuid.UUID `json:"event_id"`
+	ArtistID           uuid.UUID `json:"artist_id"`
 }
 
-func (q *Queries) AddArtistToEvent(ctx context.Context, arg AddArtistToEventParams) error {
-	_, err := q.db.Exec(ctx, addArtistToEvent,
+func (q *Queries) AddArtistToEvent(ctx context.Context, arg AddArtistToEventParams) (int64, error) {
+	result, err := q.db.Exec(ctx, addArtistToEvent,
 		arg.ID,
+		arg.ArtistNameOverride,
+		arg.SortKey,
+		arg.SongCount,
 		arg.EventID,
 		arg.ArtistID,
-		arg.ArtistNameOverride,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const addLineupItem = `-- name: AddLineupItem :exec
+INSERT INTO timeslot (id, event_id, item_type, artist_name_override, sort_key, song_count, duration_override_minutes, notes)
+VALUES ($1, $2, $3, $4::text, $5, 0, $6::integer, $7)
+`
+
+type AddLineupItemParams struct {
+	ID              uuid.UUID `json:"id"`
+	EventID         uuid.UUID `json:"event_id"`
+	ItemType        string    `json:"item_type"`
+	Title           string    `json:"title"`
+	SortKey         string    `json:"sort_key"`
+	DurationMinutes int32     `json:"duration_minutes"`
+	Notes           *string   `json:"notes"`
+}
+
+func (q *Queries) AddLineupItem(ctx context.Context, arg AddLineupItemParams) error {
+	_, err := q.db.Exec(ctx, addLineupItem,
+		arg.ID,
+		arg.EventID,
+		arg.ItemType,
+		arg.Title,
 		arg.SortKey,
+		arg.DurationMinutes,
+		arg.Notes,
 	)
 	return err
 }
 
 const createEvent = `-- name: CreateEvent :one
-INSERT INTO event (id, event_type, start_time, end_time)
-VALUES ($1, $2, $3, $4) RETURNING id, event_type, start_time, end_time, created_at, updated_at, version
+INSERT INTO event (id, venue_id, event_type, start_time, end_time, minutes_per_song, base_slot_minutes, changeover_minutes, allow_duplicate_artists)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id, event_type, start_time, end_time, created_at, updated_at, version, minutes_per_song, base_slot_minutes, changeover_minutes, change_seq, venue_id, allow_duplicate_artists
 `
 
 type CreateEventParams struct {
-	ID        uuid.UUID `json:"id"`
-	EventType string    `json:"event_type"`
-	StartTime time.Time `json:"start_time"`
-	EndTime   time.Time `json:"end_time"`
+	ID                    uuid.UUID `json:"id"`
+	VenueID               uuid.UUID `json:"venue_id"`
+	EventType             string    `json:"event_type"`
+	StartTime             time.Time `json:"start_time"`
+	EndTime               time.Time `json:"end_time"`
+	MinutesPerSong        int32     `json:"minutes_per_song"`
+	BaseSlotMinutes       int32     `json:"base_slot_minutes"`
+	ChangeoverMinutes     int32     `json:"changeover_minutes"`
+	AllowDuplicateArtists bool      `json:"allow_duplicate_artists"`
 }
 
 func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) (Event, error) {
 	row := q.db.QueryRow(ctx, createEvent,
 		arg.ID,
+		arg.VenueID,
 		arg.EventType,
 		arg.StartTime,
 		arg.EndTime,
+		arg.MinutesPerSong,
+		arg.BaseSlotMinutes,
+		arg.ChangeoverMinutes,
+		arg.AllowDuplicateArtists,
 	)
 	var i Event
 	err := row.Scan(
@@ -64,50 +111,83 @@ func (q *Queries) CreateEvent(ctx context.Context, arg CreateEventParams) (Event
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Version,
+		&i.MinutesPerSong,
+		&i.BaseSlotMinutes,
+		&i.ChangeoverMinutes,
+		&i.ChangeSeq,
+		&i.VenueID,
+		&i.AllowDuplicateArtists,
 	)
 	return i, err
 }
 
 const createTimeslotMarker = `-- name: CreateTimeslotMarker :one
-INSERT INTO timeslot_marker (id, event_id, marker_type, marker_value, timeslot_index)
-VALUES ($1, $2, $3, $4, $5) RETURNING id, event_id, timeslot_index, marker_type, marker_value
+INSERT INTO timeslot_marker (id, event_id, timeslot_id, marker_type, marker_time)
+VALUES ($1, $2, $3, $4, $5) RETURNING id, event_id, marker_type, timeslot_id, marker_time
 `
 
 type CreateTimeslotMarkerParams struct {
-	ID            uuid.UUID `json:"id"`
-	EventID       uuid.UUID `json:"event_id"`
-	MarkerType    string    `json:"marker_type"`
-	MarkerValue   string    `json:"marker_value"`
-	TimeslotIndex int32     `json:"timeslot_index"`
+	ID         uuid.UUID  `json:"id"`
+	EventID    uuid.UUID  `json:"event_id"`
+	TimeslotID uuid.UUID  `json:"timeslot_id"`
+	MarkerType string     `json:"marker_type"`
+	MarkerTime *time.Time `json:"marker_time"`
 }
 
 func (q *Queries) CreateTimeslotMarker(ctx context.Context, arg CreateTimeslotMarkerParams) (TimeslotMarker, error) {
 	row := q.db.QueryRow(ctx, createTimeslotMarker,
 		arg.ID,
 		arg.EventID,
+		arg.TimeslotID,
 		arg.MarkerType,
-		arg.MarkerValue,
-		arg.TimeslotIndex,
+		arg.MarkerTime,
 	)
 	var i TimeslotMarker
 	err := row.Scan(
 		&i.ID,
 		&i.EventID,
-		&i.TimeslotIndex,
 		&i.MarkerType,
-		&i.MarkerValue,
+		&i.TimeslotID,
+		&i.MarkerTime,
 	)
 	return i, err
 }
 
-const deleteEvent = `-- name: DeleteEvent :exec
+const deleteEvent = `-- name: DeleteEvent :execrows
 DELETE FROM event
-WHERE id = $1
+WHERE id = $1 AND version = $2
 `
 
-func (q *Queries) DeleteEvent(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, deleteEvent, id)
-	return err
+type DeleteEventParams struct {
+	ID      uuid.UUID `json:"id"`
+	Version int32     `json:"version"`
+}
+
+func (q *Queries) DeleteEvent(ctx context.Context, arg DeleteEventParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteEvent, arg.ID, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteTimeSlot = `-- name: DeleteTimeSlot :execrows
+DELETE FROM timeslot
+WHERE id = $1 AND event_id = $2 AND version = $3
+`
+
+type DeleteTimeSlotParams struct {
+	ID      uuid.UUID `json:"id"`
+	EventID uuid.UUID `json:"event_id"`
+	Version int32     `json:"version"`
+}
+
+func (q *Queries) DeleteTimeSlot(ctx context.Context, arg DeleteTimeSlotParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTimeSlot, arg.ID, arg.EventID, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
 const deleteTimeslotMarker = `-- name: DeleteTimeslotMarker :exec
@@ -120,21 +200,43 @@ func (q *Queries) DeleteTimeslotMarker(ctx context.Context, id uuid.UUID) error
 	return err
 }
 
+const endTimeSlotRuns = `-- name: EndTimeSlotRuns :exec
+UPDATE timeslot_run
+SET ended_at = $1
+WHERE event_id = $2 AND ended_at IS NULL
+`
+
+type EndTimeSlotRunsParams struct {
+	EndedAt *time.Time `json:"ended_at"`
+	EventID uuid.UUID  `json:"event_id"`
+}
+
+func (q *Queries) EndTimeSlotRuns(ctx context.Context, arg EndTimeSlotRunsParams) error {
+	_, err := q.db.Exec(ctx, endTimeSlotRuns, arg.EndedAt, arg.EventID)
+	return err
+}
+
 const getAllEvents = `-- name: GetAllEvents :many
-SELECT event.id, event.event_type, event.start_time, event.end_time, event.created_at, event.updated_at, event.version, COALESCE(json_agg(timeslot_marker.*) FILTER (WHERE timeslot_marker.event_id IS NOT NULL), '[]')::json as markers FROM event
+SELECT event.id, event.event_type, event.start_time, event.end_time, event.created_at, event.updated_at, event.version, event.minutes_per_song, event.base_slot_minutes, event.changeover_minutes, event.change_seq, event.venue_id, event.allow_duplicate_artists, COALESCE(json_agg(timeslot_marker.*) FILTER (WHERE timeslot_marker.event_id IS NOT NULL), '[]')::json as markers FROM event
+JOIN venue ON event.venue_id = venue.id
 LEFT JOIN timeslot_marker ON event.id = timeslot_marker.event_id
-WHERE event.start_time >= $1
+WHERE venue.organization_id = $1 AND event.start_time >= $2
 GROUP BY event.id
 ORDER BY event.start_time ASC
 `
 
+type GetAllEventsParams struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+	StartTime      time.Time `json:"start_time"`
+}
+
 type GetAllEventsRow struct {
 	Event   Event  `json:"event"`
 	Markers []byte `json:"markers"`
 }
 
-func (q *Queries) GetAllEvents(ctx context.Context, startTime time.Time) ([]GetAllEventsRow, error) {
-	rows, err := q.db.Query(ctx, getAllEvents, startTime)
+func (q *Queries) GetAllEvents(ctx context.Context, arg GetAllEventsParams) ([]GetAllEventsRow, error) {
+	rows, err := q.db.Query(ctx, getAllEvents, arg.OrganizationID, arg.StartTime)
 	if err != nil {
 		return nil, err
 	}
@@ -150,6 +252,12 @@ func (q *Queries) GetAllEvents(ctx context.Context, startTime time.Time) ([]GetA
 			&i.Event.CreatedAt,
 			&i.Event.UpdatedAt,
 			&i.Event.Version,
+			&i.Event.MinutesPerSong,
+			&i.Event.BaseSlotMinutes,
+			&i.Event.ChangeoverMinutes,
+			&i.Event.ChangeSeq,
+			&i.Event.VenueID,
+			&i.Event.AllowDuplicateArtists,
 			&i.Markers,
 		); err != nil {
 			return nil, err
@@ -162,8 +270,76 @@ func (q *Queries) GetAllEvents(ctx context.Context, startTime time.Time) ([]GetA
 	return items, nil
 }
 
+const getCurrentEvent = `-- name: GetCurrentEvent :one
+SELECT event.id, event.event_type, event.start_time, event.end_time, event.created_at, event.updated_at, event.version, event.minutes_per_song, event.base_slot_minutes, event.changeover_minutes, event.change_seq, event.venue_id, event.allow_duplicate_artists, COALESCE(json_agg(timeslot_marker.*) FILTER (WHERE timeslot_marker.event_id IS NOT NULL), '[]')::json as markers,
+  COALESCE(venue.current_minutes_before, event_type_window.minutes_before, $1::integer)::integer AS minutes_before,
+  COALESCE(venue.current_minutes_after, event_type_window.minutes_after, $2::integer)::integer AS minutes_after
+FROM event
+JOIN venue ON event.venue_id = venue.id
+LEFT JOIN event_type_window ON event_type_window.organization_id = venue.organization_id AND event_type_window.event_type = event.event_type
+LEFT JOIN timeslot_marker ON event.id = timeslot_marker.event_id
+WHERE venue.organization_id = $3
+  AND ($4::uuid IS NULL OR venue.id = $4::uuid)
+  AND (venue.current_minutes_before IS NOT NULL OR event_type_window.event_type IS NOT NULL OR event.event_type = $5::text)
+  AND $6::timestamptz >= event.start_time - make_interval(mins => COALESCE(venue.current_minutes_before, event_type_window.minutes_before, $1::integer))
+  AND $6::timestamptz <= event.start_time + make_interval(mins => COALESCE(venue.current_minutes_after, event_type_window.minutes_after, $2::integer))
+GROUP BY event.id, venue.id, event_type_window.organization_id, event_type_window.event_type
+ORDER BY EXISTS (SELECT 1 FROM timeslot_run WHERE timeslot_run.event_id = event.id AND timeslot_run.ended_at IS NULL) DESC,
+  abs(extract(epoch FROM event.start_time - $6::timestamptz)) ASC
+LIMIT 1
+`
+
+type GetCurrentEventParams struct {
+	DefaultMinutesBefore int32      `json:"default_minutes_before"`
+	DefaultMinutesAfter  int32      `json:"default_minutes_after"`
+	OrganizationID       uuid.UUID  `json:"organization_id"`
+	VenueID              *uuid.UUID `json:"venue_id"`
+	DefaultEventType     string     `json:"default_event_type"`
+	Now                  time.Time  `json:"now"`
+}
+
+type GetCurrentEventRow struct {
+	Event         Event  `json:"event"`
+	Markers       []byte `json:"markers"`
+	MinutesBefore int32  `json:"minutes_before"`
+	MinutesAfter  int32  `json:"minutes_after"`
+}
+
+// The current event is the one whose window holds now. A show with a timeslot
+// on stage wins over the others, then the one starting closest to now.
+func (q *Queries) GetCurrentEvent(ctx context.Context, arg GetCurrentEventParams) (GetCurrentEventRow, error) {
+	row := q.db.QueryRow(ctx, getCurrentEvent,
+		arg.DefaultMinutesBefore,
+		arg.DefaultMinutesAfter,
+		arg.OrganizationID,
+		arg.VenueID,
+		arg.DefaultEventType,
+		arg.Now,
+	)
+	var i GetCurrentEventRow
+	err := row.Scan(
+		&i.Event.ID,
+		&i.Event.EventType,
+		&i.Event.StartTime,
+		&i.Event.EndTime,
+		&i.Event.CreatedAt,
+		&i.Event.UpdatedAt,
+		&i.Event.Version,
+		&i.Event.MinutesPerSong,
+		&i.Event.BaseSlotMinutes,
+		&i.Event.ChangeoverMinutes,
+		&i.Event.ChangeSeq,
+		&i.Event.VenueID,
+		&i.Event.AllowDuplicateArtists,
+		&i.Markers,
+		&i.MinutesBefore,
+		&i.MinutesAfter,
+	)
+	return i, err
+}
+
 const getEventByID = `-- name: GetEventByID :one
-SELECT event.id, event.event_type, event.start_time, event.end_time, event.created_at, event.updated_at, event.version, COALESCE(json_agg(timeslot_marker.*) FILTER (WHERE timeslot_marker.event_id IS NOT NULL), '[]')::json as markers FROM event
+SELECT event.id, event.event_type, event.start_time, event.end_time, event.created_at, event.updated_at, event.version, event.minutes_per_song, event.base_slot_minutes, event.changeover_minutes, event.change_seq, event.venue_id, event.allow_duplicate_artists, COALESCE(json_agg(timeslot_marker.*) FILTER (WHERE timeslot_marker.event_id IS NOT NULL), '[]')::json as markers FROM event
 LEFT JOIN timeslot_marker ON event.id = timeslot_marker.event_id
 WHERE event.id = $1
 GROUP BY event.id
@@ -185,19 +361,50 @@ func (q *Queries) GetEventByID(ctx context.Context, id uuid.UUID) (GetEventByIDR
 		&i.Event.CreatedAt,
 		&i.Event.UpdatedAt,
 		&i.Event.Version,
+		&i.Event.MinutesPerSong,
+		&i.Event.BaseSlotMinutes,
+		&i.Event.ChangeoverMinutes,
+		&i.Event.ChangeSeq,
+		&i.Event.VenueID,
+		&i.Event.AllowDuplicateArtists,
 		&i.Markers,
 	)
 	return i, err
 }
 
+const lockEvent = `-- name: LockEvent :one
+SELECT id FROM event
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockEvent(ctx context.Context, id uuid.UUID) (uuid.UUID, error) {
+	row := q.db.QueryRow(ctx, lockEvent, id)
+	err := row.Scan(&id)
+	return id, err
+}
+
+const nextEventChangeSeq = `-- name: NextEventChangeSeq :one
+UPDATE event
+SET change_seq = change_seq + 1
+WHERE id = $1 RETURNING change_seq
+`
+
+func (q *Queries) NextEventChangeSeq(ctx context.Context, id uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, nextEventChangeSeq, id)
+	var change_seq int64
+	err := row.Scan(&change_seq)
+	return change_seq, err
+}
+
 const removeArtistFromEvent = `-- name: RemoveArtistFromEvent :exec
 DELETE FROM timeslot
 WHERE event_id = $1 AND artist_id = $2
 `
 
 type RemoveArtistFromEventParams struct {
-	EventID  uuid.UUID `json:"event_id"`
-	ArtistID uuid.UUID `json:"artist_id"`
+	EventID  uuid.UUID  `json:"event_id"`
+	ArtistID *uuid.UUID `json:"artist_id"`
 }
 
 func (q *Queries) RemoveArtistFromEvent(ctx context.Context, arg RemoveArtistFromEventParams) error {
@@ -205,16 +412,99 @@ func (q *Queries) RemoveArtistFromEvent(ctx context.Context, arg RemoveArtistFro
 	return err
 }
 
+const setTimeslotSortKeys = `-- name: SetTimeslotSortKeys :execrows
+UPDATE timeslot
+SET sort_key = new_order.sort_key, version = timeslot.version + 1
+FROM (SELECT unnest($2::uuid[]) AS id, unnest($3::text[]) AS sort_key) AS new_order
+WHERE timeslot.id = new_order.id AND timeslot.event_id = $1
+`
+
+type SetTimeslotSortKeysParams struct {
+	EventID  uuid.UUID   `json:"event_id"`
+	Ids      []uuid.UUID `json:"ids"`
+	SortKeys []string    `json:"sort_keys"`
+}
+
+func (q *Queries) SetTimeslotSortKeys(ctx context.Context, arg SetTimeslotSortKeysParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setTimeslotSortKeys, arg.EventID, arg.Ids, arg.SortKeys)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const startTimeSlotRun = `-- name: StartTimeSlotRun :one
+INSERT INTO timeslot_run (id, event_id, timeslot_id, started_at)
+VALUES ($1, $2, $3, $4) RETURNING id, event_id, timeslot_id, started_at, ended_at
+`
+
+type StartTimeSlotRunParams struct {
+	ID         uuid.UUID `json:"id"`
+	EventID    uuid.UUID `json:"event_id"`
+	TimeslotID uuid.UUID `json:"timeslot_id"`
+	StartedAt  time.Time `json:"started_at"`
+}
+
+func (q *Queries) StartTimeSlotRun(ctx context.Context, arg StartTimeSlotRunParams) (TimeslotRun, error) {
+	row := q.db.QueryRow(ctx, startTimeSlotRun,
+		arg.ID,
+		arg.EventID,
+		arg.TimeslotID,
+		arg.StartedAt,
+	)
+	var i TimeslotRun
+	err := row.Scan(
+		&i.ID,
+		&i.EventID,
+		&i.TimeslotID,
+		&i.StartedAt,
+		&i.EndedAt,
+	)
+	return i, err
+}
+
+const timeSlotRunsByEventID = `-- name: TimeSlotRunsByEventID :many
+SELECT id, event_id, timeslot_id, started_at, ended_at FROM timeslot_run
+WHERE event_id = $1
+ORDER BY started_at, id
+`
+
+func (q *Queries) TimeSlotRunsByEventID(ctx context.Context, eventID uuid.UUID) ([]TimeslotRun, error) {
+	rows, err := q.db.Query(ctx, timeSlotRunsByEventID, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []TimeslotRun{}
+	for rows.Next() {
+		var i TimeslotRun
+		if err := rows.Scan(
+			&i.ID,
+			&i.EventID,
+			&i.TimeslotID,
+			&i.StartedAt,
+			&i.EndedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const timeSlotsByEventID = `-- name: TimeSlotsByEventID :many
-SELECT timeslot.id, timeslot.event_id, timeslot.artist_id, timeslot.artist_name_override, timeslot.song_count, timeslot.sort_key, timeslot.created_at, timeslot.updated_at, timeslot.version, artist.id, artist.artist_title, artist.artist_subtitle, artist.bio, artist.avatar_id, artist.user_id, artist.created_at, artist.updated_at, artist.version FROM timeslot
-JOIN artist ON timeslot.artist_id = artist.id
+SELECT timeslot.id, timeslot.event_id, timeslot.artist_id, timeslot.artist_name_override, timeslot.song_count, timeslot.sort_key, timeslot.created_at, timeslot.updated_at, timeslot.version, timeslot.duration_override_minutes, timeslot.notes, timeslot.item_type, to_json(artist.*) AS artist FROM timeslot
+LEFT JOIN artist ON timeslot.artist_id = artist.id
 WHERE timeslot.event_id = $1
 ORDER BY timeslot.sort_key ASC
 `
 
 type TimeSlotsByEventIDRow struct {
 	Timeslot Timeslot `json:"timeslot"`
-	Artist   Artist   `json:"artist"`
+	Artist   []byte   `json:"artist"`
 }
 
 func (q *Queries) TimeSlotsByEventID(ctx context.Context, eventID uuid.UUID) ([]TimeSlotsByEventIDRow, error) {
@@ -236,15 +526,10 @@ func (q *Queries) TimeSlotsByEventID(ctx context.Context, eventID uuid.UUID) ([]
 			&i.Timeslot.CreatedAt,
 			&i.Timeslot.UpdatedAt,
 			&i.Timeslot.Version,
-			&i.Artist.ID,
-			&i.Artist.ArtistTitle,
-			&i.Artist.ArtistSubtitle,
-			&i.Artist.Bio,
-			&i.Artist.AvatarID,
-			&i.Artist.UserID,
-			&i.Artist.CreatedAt,
-			&i.Artist.UpdatedAt,
-			&i.Artist.Version,
+			&i.Timeslot.DurationOverrideMinutes,
+			&i.Timeslot.Notes,
+			&i.Timeslot.ItemType,
+			&i.Artist,
 		); err != nil {
 			return nil, err
 		}
@@ -258,15 +543,22 @@ func (q *Queries) TimeSlotsByEventID(ctx context.Context, eventID uuid.UUID) ([]
 
 const updateEvent = `-- name: UpdateEvent :one
 UPDATE event
-SET event_type = $1, start_time = $2, end_time = $3
-WHERE id = $4 RETURNING id, event_type, start_time, end_time, created_at, updated_at, version
+SET event_type = $1, start_time = $2, end_time = $3,
+    minutes_per_song = $4, base_slot_minutes = $5, changeover_minutes = $6,
+    allow_duplicate_artists = $7, version = version + 1
+WHERE id = $8 AND version = $9 RETURNING id, event_type, start_time, end_time, created_at, updated_at, version, minutes_per_song, base_slot_minutes, changeover_minutes, change_seq, venue_id, allow_duplicate_artists
 `
 
 type UpdateEventParams struct {
-	EventType string    `json:"event_type"`
-	StartTime time.Time `json:"start_time"`
-	EndTime   time.Time `json:"end_time"`
-	ID        uuid.UUID `json:"id"`
+	EventType             string    `json:"event_type"`
+	StartTime             time.Time `json:"start_time"`
+	EndTime               time.Time `json:"end_time"`
+	MinutesPerSong        int32     `json:"minutes_per_song"`
+	BaseSlotMinutes       int32     `json:"base_slot_minutes"`
+	ChangeoverMinutes     int32     `json:"changeover_minutes"`
+	AllowDuplicateArtists bool      `json:"allow_duplicate_artists"`
+	ID                    uuid.UUID `json:"id"`
+	Version               int32     `json:"version"`
 }
 
 func (q *Queries) UpdateEvent(ctx context.Context, arg UpdateEventParams) (Event, error) {
@@ -274,7 +566,12 @@ func (q *Queries) UpdateEvent(ctx context.Context, arg UpdateEventParams) (Event
 		arg.EventType,
 		arg.StartTime,
 		arg.EndTime,
+		arg.MinutesPerSong,
+		arg.BaseSlotMinutes,
+		arg.ChangeoverMinutes,
+		arg.AllowDuplicateArtists,
 		arg.ID,
+		arg.Version,
 	)
 	var i Event
 	err := row.Scan(
@@ -285,85 +582,76 @@ func (q *Queries) UpdateEvent(ctx context.Context, arg UpdateEventParams) (Event
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Version,
+		&i.MinutesPerSong,
+		&i.BaseSlotMinutes,
+		&i.ChangeoverMinutes,
+		&i.ChangeSeq,
+		&i.VenueID,
+		&i.AllowDuplicateArtists,
 	)
 	return i, err
 }
 
-const updateTimeSlot = `-- name: UpdateTimeSlot :many
+const updateTimeSlot = `-- name: UpdateTimeSlot :execrows
 UPDATE timeslot
-SET artist_name_override = $1, sort_key = $2, song_count = $3
-WHERE id = $4 RETURNING id, event_id, artist_id, artist_name_override, song_count, sort_key, created_at, updated_at, version
+SET artist_name_override = $1, sort_key = $2, song_count = $3, duration_override_minutes = $4,
+    notes = $5, version = version + 1
+WHERE id = $6 AND version = $7
 `
 
 type UpdateTimeSlotParams struct {
-	ArtistNameOverride *string   `json:"artist_name_override"`
-	SortKey            string    `json:"sort_key"`
-	SongCount          int32     `json:"song_count"`
-	ID                 uuid.UUID `json:"id"`
+	ArtistNameOverride      *string   `json:"artist_name_override"`
+	SortKey                 string    `json:"sort_key"`
+	SongCount               int32     `json:"song_count"`
+	DurationOverrideMinutes *int32    `json:"duration_override_minutes"`
+	Notes                   *string   `json:"notes"`
+	ID                      uuid.UUID `json:"id"`
+	Version                 int32     `json:"version"`
 }
 
-func (q *Queries) UpdateTimeSlot(ctx context.Context, arg UpdateTimeSlotParams) ([]Timeslot, error) {
-	rows, err := q.db.Query(ctx, updateTimeSlot,
+func (q *Queries) UpdateTimeSlot(ctx context.Context, arg UpdateTimeSlotParams) (int64, error) {
+	result, err := q.db.Exec(ctx, updateTimeSlot,
 		arg.ArtistNameOverride,
 		arg.SortKey,
 		arg.SongCount,
+		arg.DurationOverrideMinutes,
+		arg.Notes,
 		arg.ID,
+		arg.Version,
 	)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	defer rows.Close()
-	items := []Timeslot{}
-	for rows.Next() {
-		var i Timeslot
-		if err := rows.Scan(
-			&i.ID,
-			&i.EventID,
-			&i.ArtistID,
-			&i.ArtistNameOverride,
-			&i.SongCount,
-			&i.SortKey,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.Version,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+	return result.RowsAffected(), nil
 }
 
 const updateTimeslotMarker = `-- name: UpdateTimeslotMarker :one
 UPDATE timeslot_marker
-SET marker_type = $1, marker_value = $2, timeslot_index = $3
-WHERE id = $4 RETURNING id, event_id, timeslot_index, marker_type, marker_value
+SET timeslot_id = $1, marker_type = $2, marker_time = $3
+WHERE id = $4 RETURNING id, event_id, marker_type, timeslot_id, marker_time
 `
 
 type UpdateTimeslotMarkerParams struct {
-	MarkerType    string    `json:"marker_type"`
-	MarkerValue   string    `json:"marker_value"`
-	TimeslotIndex int32     `json:"timeslot_index"`
-	ID            uuid.UUID `json:"id"`
+	TimeslotID uuid.UUID  `json:"timeslot_id"`
+	MarkerType string     `json:"marker_type"`
+	MarkerTime *time.Time `json:"marker_time"`
+	ID         uuid.UUID  `json:"id"`
 }
 
 func (q *Queries) UpdateTimeslotMarker(ctx context.Context, arg UpdateTimeslotMarkerParams) (TimeslotMarker, error) {
 	row := q.db.QueryRow(ctx, updateTimeslotMarker,
+		arg.TimeslotID,
 		arg.MarkerType,
-		arg.MarkerValue,
-		arg.TimeslotIndex,
+		arg.MarkerTime,
 		arg.ID,
 	)
 	var i TimeslotMarker
 	err := row.Scan(
 		&i.ID,
 		&i.EventID,
-		&i.TimeslotIndex,
 		&i.MarkerType,
-		&i.MarkerValue,
+		&i.TimeslotID,
+		&i.MarkerTime,
 	)
 	return i, err
 }
@@ -0,0 +1,177 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: identity.sql
+
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const consumeOIDCLoginRequest = `-- name: ConsumeOIDCLoginRequest :one
+DELETE FROM oidc_login_request WHERE oidc_login_request.state_hash = $1 RETURNING state_hash, provider, nonce, code_verifier, expires_at, created_at
+`
+
+func (q *Queries) ConsumeOIDCLoginRequest(ctx context.Context, stateHash string) (OidcLoginRequest, error) {
+	row := q.db.QueryRow(ctx, consumeOIDCLoginRequest, stateHash)
+	var i OidcLoginRequest
+	err := row.Scan(
+		&i.StateHash,
+		&i.Provider,
+		&i.Nonce,
+		&i.CodeVerifier,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createOIDCLoginRequest = `-- name: CreateOIDCLoginRequest :one
+INSERT INTO oidc_login_request (state_hash, provider, nonce, code_verifier, expires_at)
+VALUES ($1, $2, $3, $4, $5) RETURNING state_hash, provider, nonce, code_verifier, expires_at, created_at
+`
+
+type CreateOIDCLoginRequestParams struct {
+	StateHash    string    `json:"state_hash"`
+	Provider     string    `json:"provider"`
+	Nonce        string    `json:"nonce"`
+	CodeVerifier string    `json:"code_verifier"`
+	ExpiresAt    time.Time `json:"expires_at"`
+}
+
+func (q *Queries) CreateOIDCLoginRequest(ctx context.Context, arg CreateOIDCLoginRequestParams) (OidcLoginRequest, error) {
+	row := q.db.QueryRow(ctx, createOIDCLoginRequest,
+		arg.StateHash,
+		arg.Provider,
+		arg.Nonce,
+		arg.CodeVerifier,
+		arg.ExpiresAt,
+	)
+	var i OidcLoginRequest
+	err := row.Scan(
+		&i.StateHash,
+		&i.Provider,
+		&i.Nonce,
+		&i.CodeVerifier,
+		&i.ExpiresAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const createUserIdentity = `-- name: CreateUserIdentity :one
+INSERT INTO user_identity (id, user_id, provider, subject, email)
+VALUES ($1, $2, $3, $4, $5) RETURNING id, user_id, provider, subject, email, created_at, updated_at, version
+`
+
+type CreateUserIdentityParams struct {
+	ID       uuid.UUID `json:"id"`
+	UserID   uuid.UUID `json:"user_id"`
+	Provider string    `json:"provider"`
+	Subject  string    `json:"subject"`
+	Email    string    `json:"email"`
+}
+
+func (q *Queries) CreateUserIdentity(ctx context.Context, arg CreateUserIdentityParams) (UserIdentity, error) {
+	row := q.db.QueryRow(ctx, createUserIdentity,
+		arg.ID,
+		arg.UserID,
+		arg.Provider,
+		arg.Subject,
+		arg.Email,
+	)
+	var i UserIdentity
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Provider,
+		&i.Subject,
+		&i.Email,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const deleteExpiredOIDCLoginRequests = `-- name: DeleteExpiredOIDCLoginRequests :exec
+DELETE FROM oidc_login_request WHERE oidc_login_request.expires_at < now()
+`
+
+func (q *Queries) DeleteExpiredOIDCLoginRequests(ctx context.Context) error {
+	_, err := q.db.Exec(ctx, deleteExpiredOIDCLoginRequests)
+	return err
+}
+
+const getUserIdentitiesByUserID = `-- name: GetUserIdentitiesByUserID :many
+SELECT user_identity.id, user_identity.user_id, user_identity.provider, user_identity.subject, user_identity.email, user_identity.created_at, user_identity.updated_at, user_identity.version FROM user_identity
+WHERE user_identity.user_id = $1
+ORDER BY user_identity.created_at
+`
+
+type GetUserIdentitiesByUserIDRow struct {
+	UserIdentity UserIdentity `json:"user_identity"`
+}
+
+func (q *Queries) GetUserIdentitiesByUserID(ctx context.Context, userID uuid.UUID) ([]GetUserIdentitiesByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, getUserIdentitiesByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetUserIdentitiesByUserIDRow{}
+	for rows.Next() {
+		var i GetUserIdentitiesByUserIDRow
+		if err := rows.Scan(
+			&i.UserIdentity.ID,
+			&i.UserIdentity.UserID,
+			&i.UserIdentity.Provider,
+			&i.UserIdentity.Subject,
+			&i.UserIdentity.Email,
+			&i.UserIdentity.CreatedAt,
+			&i.UserIdentity.UpdatedAt,
+			&i.UserIdentity.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserIdentity = `-- name: GetUserIdentity :one
+SELECT user_identity.id, user_identity.user_id, user_identity.provider, user_identity.subject, user_identity.email, user_identity.created_at, user_identity.updated_at, user_identity.version FROM user_identity
+WHERE user_identity.provider = $1 AND user_identity.subject = $2
+`
+
+type GetUserIdentityParams struct {
+	Provider string `json:"provider"`
+	Subject  string `json:"subject"`
+}
+
+type GetUserIdentityRow struct {
+	UserIdentity UserIdentity `json:"user_identity"`
+}
+
+func (q *Queries) GetUserIdentity(ctx context.Context, arg GetUserIdentityParams) (GetUserIdentityRow, error) {
+	row := q.db.QueryRow(ctx, getUserIdentity, arg.Provider, arg.Subject)
+	var i GetUserIdentityRow
+	err := row.Scan(
+		&i.UserIdentity.ID,
+		&i.UserIdentity.UserID,
+		&i.UserIdentity.Provider,
+		&i.UserIdentity.Subject,
+		&i.UserIdentity.Email,
+		&i.UserIdentity.CreatedAt,
+		&i.UserIdentity.UpdatedAt,
+		&i.UserIdentity.Version,
+	)
+	return i, err
+}
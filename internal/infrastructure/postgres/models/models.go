@@ -10,6 +10,21 @@ import (
 	"github.com/google/uuid"
 )
 
+type ApiKey struct {
+	ID                 uuid.UUID  `json:"id"`
+	KeyName            string     `json:"key_name"`
+	KeyHash            string     `json:"key_hash"`
+	Scopes             []string   `json:"scopes"`
+	OrganizationID     *uuid.UUID `json:"organization_id"`
+	RateLimitPerMinute int32      `json:"rate_limit_per_minute"`
+	CreatedBy          *uuid.UUID `json:"created_by"`
+	LastUsedAt         *time.Time `json:"last_used_at"`
+	RevokedAt          *time.Time `json:"revoked_at"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          *time.Time `json:"updated_at"`
+	Version            int32      `json:"version"`
+}
+
 type Artist struct {
 	ID             uuid.UUID  `json:"id"`
 	ArtistTitle    string     `json:"artist_title"`
@@ -20,16 +35,30 @@ type Artist struct {
 	CreatedAt      *time.Time `json:"created_at"`
 	UpdatedAt      *time.Time `json:"updated_at"`
 	Version        int32      `json:"version"`
+	OrganizationID uuid.UUID  `json:"organization_id"`
 }
 
 type Event struct {
-	ID        uuid.UUID  `json:"id"`
-	EventType string     `json:"event_type"`
-	StartTime time.Time  `json:"start_time"`
-	EndTime   time.Time  `json:"end_time"`
-	CreatedAt *time.Time `json:"created_at"`
-	UpdatedAt *time.Time `json:"updated_at"`
-	Version   int32      `json:"version"`
+	ID                    uuid.UUID  `json:"id"`
+	EventType             string     `json:"event_type"`
+	StartTime             time.Time  `json:"start_time"`
+	EndTime               time.Time  `json:"end_time"`
+	CreatedAt             *time.Time `json:"created_at"`
+	UpdatedAt             *time.Time `json:"updated_at"`
+	Version               int32      `json:"version"`
+	MinutesPerSong        int32      `json:"minutes_per_song"`
+	BaseSlotMinutes       int32      `json:"base_slot_minutes"`
+	ChangeoverMinutes     int32      `json:"changeover_minutes"`
+	ChangeSeq             int64      `json:"change_seq"`
+	VenueID               uuid.UUID  `json:"venue_id"`
+	AllowDuplicateArtists bool       `json:"allow_duplicate_artists"`
+}
+
+type EventTypeWindow struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+	EventType      string    `json:"event_type"`
+	MinutesBefore  int32     `json:"minutes_before"`
+	MinutesAfter   int32     `json:"minutes_after"`
 }
 
 type Image struct {
@@ -44,11 +73,45 @@ type Image struct {
 	Version    int32      `json:"version"`
 }
 
+type ImpersonationAudit struct {
+	ID             uuid.UUID `json:"id"`
+	ImpersonatorID uuid.UUID `json:"impersonator_id"`
+	UserID         uuid.UUID `json:"user_id"`
+	SessionID      uuid.UUID `json:"session_id"`
+	RequestMethod  string    `json:"request_method"`
+	RequestPath    string    `json:"request_path"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+type OidcLoginRequest struct {
+	StateHash    string    `json:"state_hash"`
+	Provider     string    `json:"provider"`
+	Nonce        string    `json:"nonce"`
+	CodeVerifier string    `json:"code_verifier"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+type Organization struct {
+	ID                 uuid.UUID  `json:"id"`
+	OrganizationName   string     `json:"organization_name"`
+	OrganizationHandle string     `json:"organization_handle"`
+	CreatedAt          *time.Time `json:"created_at"`
+	UpdatedAt          *time.Time `json:"updated_at"`
+	Version            int32      `json:"version"`
+}
+
+type OrganizationMember struct {
+	OrganizationID uuid.UUID  `json:"organization_id"`
+	UserID         uuid.UUID  `json:"user_id"`
+	CreatedAt      *time.Time `json:"created_at"`
+}
+
 type ReferenceLink struct {
 	ID        uuid.UUID  `json:"id"`
 	LinkID    uuid.UUID  `json:"link_id"`
 	LinkType  string     `json:"link_type"`
-	Token     string     `json:"token"`
+	TokenHash string     `json:"token_hash"`
 	ExpiresAt time.Time  `json:"expires_at"`
 	CreatedAt *time.Time `json:"created_at"`
 	UpdatedAt *time.Time `json:"updated_at"`
@@ -61,23 +124,34 @@ type SchemaMigration struct {
 }
 
 type Timeslot struct {
-	ID                 uuid.UUID  `json:"id"`
-	EventID            uuid.UUID  `json:"event_id"`
-	ArtistID           uuid.UUID  `json:"artist_id"`
-	ArtistNameOverride *string    `json:"artist_name_override"`
-	SongCount          int32      `json:"song_count"`
-	SortKey            string     `json:"sort_key"`
-	CreatedAt          *time.Time `json:"created_at"`
-	UpdatedAt          *time.Time `json:"updated_at"`
-	Version            int32      `json:"version"`
+	ID                      uuid.UUID  `json:"id"`
+	EventID                 uuid.UUID  `json:"event_id"`
+	ArtistID                *uuid.UUID `json:"artist_id"`
+	ArtistNameOverride      *string    `json:"artist_name_override"`
+	SongCount               int32      `json:"song_count"`
+	SortKey                 string     `json:"sort_key"`
+	CreatedAt               *time.Time `json:"created_at"`
+	UpdatedAt               *time.Time `json:"updated_at"`
+	Version                 int32      `json:"version"`
+	DurationOverrideMinutes *int32     `json:"duration_override_minutes"`
+	Notes                   *string    `json:"notes"`
+	ItemType                string     `json:"item_type"`
 }
 
 type TimeslotMarker struct {
-	ID            uuid.UUID `json:"id"`
-	EventID       uuid.UUID `json:"event_id"`
-	TimeslotIndex int32     `json:"timeslot_index"`
-	MarkerType    string    `json:"marker_type"`
-	MarkerValue   string    `json:"marker_value"`
+	ID         uuid.UUID  `json:"id"`
+	EventID    uuid.UUID  `json:"event_id"`
+	MarkerType string     `json:"marker_type"`
+	TimeslotID uuid.UUID  `json:"timeslot_id"`
+	MarkerTime *time.Time `json:"marker_time"`
+}
+
+type TimeslotRun struct {
+	ID         uuid.UUID  `json:"id"`
+	EventID    uuid.UUID  `json:"event_id"`
+	TimeslotID uuid.UUID  `json:"timeslot_id"`
+	StartedAt  time.Time  `json:"started_at"`
+	EndedAt    *time.Time `json:"ended_at"`
 }
 
 type User struct {
@@ -92,16 +166,42 @@ type User struct {
 	CreatedAt     *time.Time `json:"created_at"`
 	UpdatedAt     *time.Time `json:"updated_at"`
 	Version       int32      `json:"version"`
+	UserRole      string     `json:"user_role"`
+}
+
+type UserIdentity struct {
+	ID        uuid.UUID  `json:"id"`
+	UserID    uuid.UUID  `json:"user_id"`
+	Provider  string     `json:"provider"`
+	Subject   string     `json:"subject"`
+	Email     string     `json:"email"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at"`
+	Version   int32      `json:"version"`
 }
 
 type UserSession struct {
 	ID             uuid.UUID  `json:"id"`
 	UserID         uuid.UUID  `json:"user_id"`
 	ImpersonatorID *uuid.UUID `json:"impersonator_id"`
-	Token          string     `json:"token"`
+	TokenHash      string     `json:"token_hash"`
 	ExpiresAt      time.Time  `json:"expires_at"`
 	UserExpired    bool       `json:"user_expired"`
 	CreatedAt      *time.Time `json:"created_at"`
 	UpdatedAt      *time.Time `json:"updated_at"`
 	Version        int32      `json:"version"`
+	LastSeenAt     time.Time  `json:"last_seen_at"`
+	IpAddress      *string    `json:"ip_address"`
+}
+
+type Venue struct {
+	ID                   uuid.UUID  `json:"id"`
+	OrganizationID       uuid.UUID  `json:"organization_id"`
+	VenueName            string     `json:"venue_name"`
+	VenueAddress         *string    `json:"venue_address"`
+	CreatedAt            *time.Time `json:"created_at"`
+	UpdatedAt            *time.Time `json:"updated_at"`
+	Version              int32      `json:"version"`
+	CurrentMinutesBefore *int32     `json:"current_minutes_before"`
+	CurrentMinutesAfter  *int32     `json:"current_minutes_after"`
 }
@@ -0,0 +1,523 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: organization.sql
+
+package models
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const addOrganizationMember = `-- name: AddOrganizationMember :exec
+INSERT INTO organization_member (organization_id, user_id)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING
+`
+
+type AddOrganizationMemberParams struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+	UserID         uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) AddOrganizationMember(ctx context.Context, arg AddOrganizationMemberParams) error {
+	_, err := q.db.Exec(ctx, addOrganizationMember, arg.OrganizationID, arg.UserID)
+	return err
+}
+
+const createOrganization = `-- name: CreateOrganization :one
+INSERT INTO organization (id, organization_name, organization_handle)
+VALUES ($1, $2, $3) RETURNING id, organization_name, organization_handle, created_at, updated_at, version
+`
+
+type CreateOrganizationParams struct {
+	ID                 uuid.UUID `json:"id"`
+	OrganizationName   string    `json:"organization_name"`
+	OrganizationHandle string    `json:"organization_handle"`
+}
+
+func (q *Queries) CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (Organization, error) {
+	row := q.db.QueryRow(ctx, createOrganization, arg.ID, arg.OrganizationName, arg.OrganizationHandle)
+	var i Organization
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationName,
+		&i.OrganizationHandle,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const createVenue = `-- name: CreateVenue :one
+INSERT INTO venue (id, organization_id, venue_name, venue_address, current_minutes_before, current_minutes_after)
+VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, organization_id, venue_name, venue_address, created_at, updated_at, version, current_minutes_before, current_minutes_after
+`
+
+type CreateVenueParams struct {
+	ID                   uuid.UUID `json:"id"`
+	OrganizationID       uuid.UUID `json:"organization_id"`
+	VenueName            string    `json:"venue_name"`
+	VenueAddress         *string   `json:"venue_address"`
+	CurrentMinutesBefore *int32    `json:"current_minutes_before"`
+	CurrentMinutesAfter  *int32    `json:"current_minutes_after"`
+}
+
+func (q *Queries) CreateVenue(ctx context.Context, arg CreateVenueParams) (Venue, error) {
+	row := q.db.QueryRow(ctx, createVenue,
+		arg.ID,
+		arg.OrganizationID,
+		arg.VenueName,
+		arg.VenueAddress,
+		arg.CurrentMinutesBefore,
+		arg.CurrentMinutesAfter,
+	)
+	var i Venue
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.VenueName,
+		&i.VenueAddress,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.CurrentMinutesBefore,
+		&i.CurrentMinutesAfter,
+	)
+	return i, err
+}
+
+const deleteEventTypeWindow = `-- name: DeleteEventTypeWindow :execrows
+DELETE FROM event_type_window
+WHERE organization_id = $1 AND event_type = $2
+`
+
+type DeleteEventTypeWindowParams struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+	EventType      string    `json:"event_type"`
+}
+
+func (q *Queries) DeleteEventTypeWindow(ctx context.Context, arg DeleteEventTypeWindowParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteEventTypeWindow, arg.OrganizationID, arg.EventType)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteVenue = `-- name: DeleteVenue :exec
+DELETE FROM venue
+WHERE id = $1
+`
+
+func (q *Queries) DeleteVenue(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.Exec(ctx, deleteVenue, id)
+	return err
+}
+
+const getEventTypeWindows = `-- name: GetEventTypeWindows :many
+SELECT organization_id, event_type, minutes_before, minutes_after FROM event_type_window
+WHERE organization_id = $1
+ORDER BY event_type ASC
+`
+
+func (q *Queries) GetEventTypeWindows(ctx context.Context, organizationID uuid.UUID) ([]EventTypeWindow, error) {
+	rows, err := q.db.Query(ctx, getEventTypeWindows, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []EventTypeWindow{}
+	for rows.Next() {
+		var i EventTypeWindow
+		if err := rows.Scan(
+			&i.OrganizationID,
+			&i.EventType,
+			&i.MinutesBefore,
+			&i.MinutesAfter,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOrganizationByArtistID = `-- name: GetOrganizationByArtistID :one
+SELECT organization.id, organization.organization_name, organization.organization_handle, organization.created_at, organization.updated_at, organization.version FROM organization
+JOIN artist ON artist.organization_id = organization.id
+WHERE artist.id = $1
+`
+
+type GetOrganizationByArtistIDRow struct {
+	Organization Organization `json:"organization"`
+}
+
+func (q *Queries) GetOrganizationByArtistID(ctx context.Context, artistID uuid.UUID) (GetOrganizationByArtistIDRow, error) {
+	row := q.db.QueryRow(ctx, getOrganizationByArtistID, artistID)
+	var i GetOrganizationByArtistIDRow
+	err := row.Scan(
+		&i.Organization.ID,
+		&i.Organization.OrganizationName,
+		&i.Organization.OrganizationHandle,
+		&i.Organization.CreatedAt,
+		&i.Organization.UpdatedAt,
+		&i.Organization.Version,
+	)
+	return i, err
+}
+
+const getOrganizationByEventID = `-- name: GetOrganizationByEventID :one
+SELECT organization.id, organization.organization_name, organization.organization_handle, organization.created_at, organization.updated_at, organization.version FROM organization
+JOIN venue ON venue.organization_id = organization.id
+JOIN event ON event.venue_id = venue.id
+WHERE event.id = $1
+`
+
+type GetOrganizationByEventIDRow struct {
+	Organization Organization `json:"organization"`
+}
+
+func (q *Queries) GetOrganizationByEventID(ctx context.Context, eventID uuid.UUID) (GetOrganizationByEventIDRow, error) {
+	row := q.db.QueryRow(ctx, getOrganizationByEventID, eventID)
+	var i GetOrganizationByEventIDRow
+	err := row.Scan(
+		&i.Organization.ID,
+		&i.Organization.OrganizationName,
+		&i.Organization.OrganizationHandle,
+		&i.Organization.CreatedAt,
+		&i.Organization.UpdatedAt,
+		&i.Organization.Version,
+	)
+	return i, err
+}
+
+const getOrganizationByID = `-- name: GetOrganizationByID :one
+SELECT organization.id, organization.organization_name, organization.organization_handle, organization.created_at, organization.updated_at, organization.version FROM organization
+WHERE organization.id = $1
+`
+
+type GetOrganizationByIDRow struct {
+	Organization Organization `json:"organization"`
+}
+
+func (q *Queries) GetOrganizationByID(ctx context.Context, id uuid.UUID) (GetOrganizationByIDRow, error) {
+	row := q.db.QueryRow(ctx, getOrganizationByID, id)
+	var i GetOrganizationByIDRow
+	err := row.Scan(
+		&i.Organization.ID,
+		&i.Organization.OrganizationName,
+		&i.Organization.OrganizationHandle,
+		&i.Organization.CreatedAt,
+		&i.Organization.UpdatedAt,
+		&i.Organization.Version,
+	)
+	return i, err
+}
+
+const getOrganizationByVenueID = `-- name: GetOrganizationByVenueID :one
+SELECT organization.id, organization.organization_name, organization.organization_handle, organization.created_at, organization.updated_at, organization.version FROM organization
+JOIN venue ON venue.organization_id = organization.id
+WHERE venue.id = $1
+`
+
+type GetOrganizationByVenueIDRow struct {
+	Organization Organization `json:"organization"`
+}
+
+func (q *Queries) GetOrganizationByVenueID(ctx context.Context, venueID uuid.UUID) (GetOrganizationByVenueIDRow, error) {
+	row := q.db.QueryRow(ctx, getOrganizationByVenueID, venueID)
+	var i GetOrganizationByVenueIDRow
+	err := row.Scan(
+		&i.Organization.ID,
+		&i.Organization.OrganizationName,
+		&i.Organization.OrganizationHandle,
+		&i.Organization.CreatedAt,
+		&i.Organization.UpdatedAt,
+		&i.Organization.Version,
+	)
+	return i, err
+}
+
+const getOrganizationMembers = `-- name: GetOrganizationMembers :many
+SELECT users.id, users.given_name, users.family_name, users.email, users.email_verified, users.user_handle, users.claimed, users.avatar_id, users.created_at, users.updated_at, users.version, users.user_role FROM users
+JOIN organization_member ON organization_member.user_id = users.id
+WHERE organization_member.organization_id = $1
+ORDER BY users.email ASC
+`
+
+type GetOrganizationMembersRow struct {
+	User User `json:"user"`
+}
+
+func (q *Queries) GetOrganizationMembers(ctx context.Context, organizationID uuid.UUID) ([]GetOrganizationMembersRow, error) {
+	rows, err := q.db.Query(ctx, getOrganizationMembers, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetOrganizationMembersRow{}
+	for rows.Next() {
+		var i GetOrganizationMembersRow
+		if err := rows.Scan(
+			&i.User.ID,
+			&i.User.GivenName,
+			&i.User.FamilyName,
+			&i.User.Email,
+			&i.User.EmailVerified,
+			&i.User.UserHandle,
+			&i.User.Claimed,
+			&i.User.AvatarID,
+			&i.User.CreatedAt,
+			&i.User.UpdatedAt,
+			&i.User.Version,
+			&i.User.UserRole,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getOrganizationsByUserID = `-- name: GetOrganizationsByUserID :many
+SELECT organization.id, organization.organization_name, organization.organization_handle, organization.created_at, organization.updated_at, organization.version FROM organization
+JOIN organization_member ON organization_member.organization_id = organization.id
+WHERE organization_member.user_id = $1
+ORDER BY organization.organization_name ASC
+`
+
+type GetOrganizationsByUserIDRow struct {
+	Organization Organization `json:"organization"`
+}
+
+func (q *Queries) GetOrganizationsByUserID(ctx context.Context, userID uuid.UUID) ([]GetOrganizationsByUserIDRow, error) {
+	rows, err := q.db.Query(ctx, getOrganizationsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetOrganizationsByUserIDRow{}
+	for rows.Next() {
+		var i GetOrganizationsByUserIDRow
+		if err := rows.Scan(
+			&i.Organization.ID,
+			&i.Organization.OrganizationName,
+			&i.Organization.OrganizationHandle,
+			&i.Organization.CreatedAt,
+			&i.Organization.UpdatedAt,
+			&i.Organization.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getVenueByID = `-- name: GetVenueByID :one
+SELECT venue.id, venue.organization_id, venue.venue_name, venue.venue_address, venue.created_at, venue.updated_at, venue.version, venue.current_minutes_before, venue.current_minutes_after FROM venue
+WHERE venue.id = $1
+`
+
+type GetVenueByIDRow struct {
+	Venue Venue `json:"venue"`
+}
+
+func (q *Queries) GetVenueByID(ctx context.Context, id uuid.UUID) (GetVenueByIDRow, error) {
+	row := q.db.QueryRow(ctx, getVenueByID, id)
+	var i GetVenueByIDRow
+	err := row.Scan(
+		&i.Venue.ID,
+		&i.Venue.OrganizationID,
+		&i.Venue.VenueName,
+		&i.Venue.VenueAddress,
+		&i.Venue.CreatedAt,
+		&i.Venue.UpdatedAt,
+		&i.Venue.Version,
+		&i.Venue.CurrentMinutesBefore,
+		&i.Venue.CurrentMinutesAfter,
+	)
+	return i, err
+}
+
+const getVenuesByOrganizationID = `-- name: GetVenuesByOrganizationID :many
+SELECT venue.id, venue.organization_id, venue.venue_name, venue.venue_address, venue.created_at, venue.updated_at, venue.version, venue.current_minutes_before, venue.current_minutes_after FROM venue
+WHERE venue.organization_id = $1
+ORDER BY venue.venue_name ASC
+`
+
+type GetVenuesByOrganizationIDRow struct {
+	Venue Venue `json:"venue"`
+}
+
+func (q *Queries) GetVenuesByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]GetVenuesByOrganizationIDRow, error) {
+	rows, err := q.db.Query(ctx, getVenuesByOrganizationID, organizationID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetVenuesByOrganizationIDRow{}
+	for rows.Next() {
+		var i GetVenuesByOrganizationIDRow
+		if err := rows.Scan(
+			&i.Venue.ID,
+			&i.Venue.OrganizationID,
+			&i.Venue.VenueName,
+			&i.Venue.VenueAddress,
+			&i.Venue.CreatedAt,
+			&i.Venue.UpdatedAt,
+			&i.Venue.Version,
+			&i.Venue.CurrentMinutesBefore,
+			&i.Venue.CurrentMinutesAfter,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const isOrganizationMember = `-- name: IsOrganizationMember :one
+SELECT EXISTS (
+    SELECT 1 FROM organization_member
+    WHERE organization_id = $1 AND user_id = $2
+)
+`
+
+type IsOrganizationMemberParams struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+	UserID         uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) IsOrganizationMember(ctx context.Context, arg IsOrganizationMemberParams) (bool, error) {
+	row := q.db.QueryRow(ctx, isOrganizationMember, arg.OrganizationID, arg.UserID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const removeOrganizationMember = `-- name: RemoveOrganizationMember :exec
+DELETE FROM organization_member
+WHERE organization_id = $1 AND user_id = $2
+`
+
+type RemoveOrganizationMemberParams struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+	UserID         uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) RemoveOrganizationMember(ctx context.Context, arg RemoveOrganizationMemberParams) error {
+	_, err := q.db.Exec(ctx, removeOrganizationMember, arg.OrganizationID, arg.UserID)
+	return err
+}
+
+const setEventTypeWindow = `-- name: SetEventTypeWindow :one
+INSERT INTO event_type_window (organization_id, event_type, minutes_before, minutes_after)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (organization_id, event_type) DO UPDATE
+SET minutes_before = EXCLUDED.minutes_before, minutes_after = EXCLUDED.minutes_after
+RETURNING organization_id, event_type, minutes_before, minutes_after
+`
+
+type SetEventTypeWindowParams struct {
+	OrganizationID uuid.UUID `json:"organization_id"`
+	EventType      string    `json:"event_type"`
+	MinutesBefore  int32     `json:"minutes_before"`
+	MinutesAfter   int32     `json:"minutes_after"`
+}
+
+func (q *Queries) SetEventTypeWindow(ctx context.Context, arg SetEventTypeWindowParams) (EventTypeWindow, error) {
+	row := q.db.QueryRow(ctx, setEventTypeWindow,
+		arg.OrganizationID,
+		arg.EventType,
+		arg.MinutesBefore,
+		arg.MinutesAfter,
+	)
+	var i EventTypeWindow
+	err := row.Scan(
+		&i.OrganizationID,
+		&i.EventType,
+		&i.MinutesBefore,
+		&i.MinutesAfter,
+	)
+	return i, err
+}
+
+const updateOrganization = `-- name: UpdateOrganization :one
+UPDATE organization
+SET organization_name = $1, organization_handle = $2
+WHERE id = $3 RETURNING id, organization_name, organization_handle, created_at, updated_at, version
+`
+
+type UpdateOrganizationParams struct {
+	OrganizationName   string    `json:"organization_name"`
+	OrganizationHandle string    `json:"organization_handle"`
+	ID                 uuid.UUID `json:"id"`
+}
+
+func (q *Queries) UpdateOrganization(ctx context.Context, arg UpdateOrganizationParams) (Organization, error) {
+	row := q.db.QueryRow(ctx, updateOrganization, arg.OrganizationName, arg.OrganizationHandle, arg.ID)
+	var i Organization
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationName,
+		&i.OrganizationHandle,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const updateVenue = `-- name: UpdateVenue :one
+UPDATE venue
+SET venue_name = $1, venue_address = $2, current_minutes_before = $3, current_minutes_after = $4
+WHERE id = $5 RETURNING id, organization_id, venue_name, venue_address, created_at, updated_at, version, current_minutes_before, current_minutes_after
+`
+
+type UpdateVenueParams struct {
+	VenueName            string    `json:"venue_name"`
+	VenueAddress         *string   `json:"venue_address"`
+	CurrentMinutesBefore *int32    `json:"current_minutes_before"`
+	CurrentMinutesAfter  *int32    `json:"current_minutes_after"`
+	ID                   uuid.UUID `json:"id"`
+}
+
+func (q *Queries) UpdateVenue(ctx context.Context, arg UpdateVenueParams) (Venue, error) {
+	row := q.db.QueryRow(ctx, updateVenue,
+		arg.VenueName,
+		arg.VenueAddress,
+		arg.CurrentMinutesBefore,
+		arg.CurrentMinutesAfter,
+		arg.ID,
+	)
+	var i Venue
+	err := row.Scan(
+		&i.ID,
+		&i.OrganizationID,
+		&i.VenueName,
+		&i.VenueAddress,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.CurrentMinutesBefore,
+		&i.CurrentMinutesAfter,
+	)
+	return i, err
+}
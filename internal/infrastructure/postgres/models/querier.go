@@ -6,45 +6,93 @@ package models
 
 import (
 	"context"
-	"time"
 
 	"github.com/google/uuid"
 )
 
 type Querier interface {
-	AddArtistToEvent(ctx context.Context, arg AddArtistToEventParams) error
+	AddArtistToEvent(ctx context.Context, arg AddArtistToEventParams) (int64, error)
+	AddLineupItem(ctx context.Context, arg AddLineupItemParams) error
+	AddOrganizationMember(ctx context.Context, arg AddOrganizationMemberParams) error
+	ConsumeOIDCLoginRequest(ctx context.Context, stateHash string) (OidcLoginRequest, error)
+	CreateAPIKey(ctx context.Context, arg CreateAPIKeyParams) (ApiKey, error)
 	CreateArtist(ctx context.Context, arg CreateArtistParams) (Artist, error)
 	CreateEvent(ctx context.Context, arg CreateEventParams) (Event, error)
 	CreateImage(ctx context.Context, arg CreateImageParams) (Image, error)
+	CreateImpersonationAudit(ctx context.Context, arg CreateImpersonationAuditParams) error
+	CreateImpersonationSession(ctx context.Context, arg CreateImpersonationSessionParams) (UserSession, error)
+	CreateOIDCLoginRequest(ctx context.Context, arg CreateOIDCLoginRequestParams) (OidcLoginRequest, error)
+	CreateOrganization(ctx context.Context, arg CreateOrganizationParams) (Organization, error)
 	CreateReferenceLink(ctx context.Context, arg CreateReferenceLinkParams) (ReferenceLink, error)
 	CreateTimeslotMarker(ctx context.Context, arg CreateTimeslotMarkerParams) (TimeslotMarker, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (User, error)
+	CreateUserIdentity(ctx context.Context, arg CreateUserIdentityParams) (UserIdentity, error)
 	CreateUserSession(ctx context.Context, arg CreateUserSessionParams) (UserSession, error)
-	DeleteArtist(ctx context.Context, id uuid.UUID) error
-	DeleteEvent(ctx context.Context, id uuid.UUID) error
+	CreateVenue(ctx context.Context, arg CreateVenueParams) (Venue, error)
+	DeleteArtist(ctx context.Context, arg DeleteArtistParams) (int64, error)
+	DeleteEvent(ctx context.Context, arg DeleteEventParams) (int64, error)
+	DeleteEventTypeWindow(ctx context.Context, arg DeleteEventTypeWindowParams) (int64, error)
+	DeleteExpiredOIDCLoginRequests(ctx context.Context) error
 	DeleteReferenceLink(ctx context.Context, id uuid.UUID) (ReferenceLink, error)
+	DeleteTimeSlot(ctx context.Context, arg DeleteTimeSlotParams) (int64, error)
 	DeleteTimeslotMarker(ctx context.Context, id uuid.UUID) error
-	ExpireUserSession(ctx context.Context, id uuid.UUID) error
-	GetAllArtists(ctx context.Context) ([]GetAllArtistsRow, error)
-	GetAllEvents(ctx context.Context, startTime time.Time) ([]GetAllEventsRow, error)
+	DeleteVenue(ctx context.Context, id uuid.UUID) error
+	EndTimeSlotRuns(ctx context.Context, arg EndTimeSlotRunsParams) error
+	ExpireAllUserSessions(ctx context.Context, userID uuid.UUID) error
+	ExpireUserSession(ctx context.Context, arg ExpireUserSessionParams) (int64, error)
+	GetAPIKeyByID(ctx context.Context, id uuid.UUID) (GetAPIKeyByIDRow, error)
+	GetAPIKeys(ctx context.Context) ([]GetAPIKeysRow, error)
+	GetActiveAPIKeyByHash(ctx context.Context, keyHash string) (GetActiveAPIKeyByHashRow, error)
+	GetActiveUserSessions(ctx context.Context, userID uuid.UUID) ([]GetActiveUserSessionsRow, error)
+	GetAllArtists(ctx context.Context, organizationID uuid.UUID) ([]GetAllArtistsRow, error)
+	GetAllEvents(ctx context.Context, arg GetAllEventsParams) ([]GetAllEventsRow, error)
 	GetArtistByID(ctx context.Context, id uuid.UUID) (GetArtistByIDRow, error)
 	GetArtistsByTitle(ctx context.Context, arg GetArtistsByTitleParams) ([]GetArtistsByTitleRow, error)
+	// The current event is the one whose window holds now. A show with a timeslot
+	// on stage wins over the others, then the one starting closest to now.
+	GetCurrentEvent(ctx context.Context, arg GetCurrentEventParams) (GetCurrentEventRow, error)
 	GetEventByID(ctx context.Context, id uuid.UUID) (GetEventByIDRow, error)
+	GetEventTypeWindows(ctx context.Context, organizationID uuid.UUID) ([]EventTypeWindow, error)
 	GetImageByID(ctx context.Context, id uuid.UUID) (GetImageByIDRow, error)
+	GetOrganizationByArtistID(ctx context.Context, artistID uuid.UUID) (GetOrganizationByArtistIDRow, error)
+	GetOrganizationByEventID(ctx context.Context, eventID uuid.UUID) (GetOrganizationByEventIDRow, error)
+	GetOrganizationByID(ctx context.Context, id uuid.UUID) (GetOrganizationByIDRow, error)
+	GetOrganizationByVenueID(ctx context.Context, venueID uuid.UUID) (GetOrganizationByVenueIDRow, error)
+	GetOrganizationMembers(ctx context.Context, organizationID uuid.UUID) ([]GetOrganizationMembersRow, error)
+	GetOrganizationsByUserID(ctx context.Context, userID uuid.UUID) ([]GetOrganizationsByUserIDRow, error)
 	GetReferenceLinkByID(ctx context.Context, id uuid.UUID) (GetReferenceLinkByIDRow, error)
-	GetReferenceLinkByToken(ctx context.Context, token string) (GetReferenceLinkByTokenRow, error)
+	GetReferenceLinkByTokenHash(ctx context.Context, tokenHash string) (GetReferenceLinkByTokenHashRow, error)
 	GetUserByEmail(ctx context.Context, email string) (GetUserByEmailRow, error)
 	GetUserByHandle(ctx context.Context, userHandle string) (GetUserByHandleRow, error)
 	GetUserByID(ctx context.Context, id uuid.UUID) (GetUserByIDRow, error)
-	GetUserBySessionToken(ctx context.Context, token string) (GetUserBySessionTokenRow, error)
+	GetUserBySessionTokenHash(ctx context.Context, tokenHash string) (GetUserBySessionTokenHashRow, error)
+	GetUserIdentitiesByUserID(ctx context.Context, userID uuid.UUID) ([]GetUserIdentitiesByUserIDRow, error)
+	GetUserIdentity(ctx context.Context, arg GetUserIdentityParams) (GetUserIdentityRow, error)
+	GetVenueByID(ctx context.Context, id uuid.UUID) (GetVenueByIDRow, error)
+	GetVenuesByOrganizationID(ctx context.Context, organizationID uuid.UUID) ([]GetVenuesByOrganizationIDRow, error)
+	IsOrganizationMember(ctx context.Context, arg IsOrganizationMemberParams) (bool, error)
+	LockEvent(ctx context.Context, id uuid.UUID) (uuid.UUID, error)
+	NextEventChangeSeq(ctx context.Context, id uuid.UUID) (int64, error)
 	RemoveArtistFromEvent(ctx context.Context, arg RemoveArtistFromEventParams) error
+	RemoveOrganizationMember(ctx context.Context, arg RemoveOrganizationMemberParams) error
+	RevokeAPIKey(ctx context.Context, id uuid.UUID) (ApiKey, error)
+	RotateAPIKey(ctx context.Context, arg RotateAPIKeyParams) (ApiKey, error)
 	SetAvatarImage(ctx context.Context, arg SetAvatarImageParams) (User, error)
+	SetEventTypeWindow(ctx context.Context, arg SetEventTypeWindowParams) (EventTypeWindow, error)
+	SetTimeslotSortKeys(ctx context.Context, arg SetTimeslotSortKeysParams) (int64, error)
+	SetUserRole(ctx context.Context, arg SetUserRoleParams) (User, error)
+	StartTimeSlotRun(ctx context.Context, arg StartTimeSlotRunParams) (TimeslotRun, error)
+	TimeSlotRunsByEventID(ctx context.Context, eventID uuid.UUID) ([]TimeslotRun, error)
 	TimeSlotsByEventID(ctx context.Context, eventID uuid.UUID) ([]TimeSlotsByEventIDRow, error)
+	TouchAPIKey(ctx context.Context, id uuid.UUID) error
+	TouchUserSession(ctx context.Context, arg TouchUserSessionParams) error
 	UpdateArtist(ctx context.Context, arg UpdateArtistParams) (Artist, error)
 	UpdateEvent(ctx context.Context, arg UpdateEventParams) (Event, error)
-	UpdateTimeSlot(ctx context.Context, arg UpdateTimeSlotParams) ([]Timeslot, error)
+	UpdateOrganization(ctx context.Context, arg UpdateOrganizationParams) (Organization, error)
+	UpdateTimeSlot(ctx context.Context, arg UpdateTimeSlotParams) (int64, error)
 	UpdateTimeslotMarker(ctx context.Context, arg UpdateTimeslotMarkerParams) (TimeslotMarker, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error)
+	UpdateVenue(ctx context.Context, arg UpdateVenueParams) (Venue, error)
 }
 
 var _ Querier = (*Queries)(nil)
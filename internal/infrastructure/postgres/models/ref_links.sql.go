@@ -13,15 +13,15 @@ import (
 )
 
 const createReferenceLink = `-- name: CreateReferenceLink :one
-INSERT INTO reference_link (id, link_id, link_type, token, expires_at) 
-VALUES ($1, $2, $3, $4, $5) RETURNING id, link_id, link_type, token, expires_at, created_at, updated_at, version
+INSERT INTO reference_link (id, link_id, link_type, token_hash, expires_at) 
+VALUES ($1, $2, $3, $4, $5) RETURNING id, link_id, link_type, token_hash, expires_at, created_at, updated_at, version
 `
 
 type CreateReferenceLinkParams struct {
 	ID        uuid.UUID `json:"id"`
 	LinkID    uuid.UUID `json:"link_id"`
 	LinkType  string    `json:"link_type"`
-	Token     string    `json:"token"`
+	TokenHash string    `json:"token_hash"`
 	ExpiresAt time.Time `json:"expires_at"`
 }
 
@@ -30,7 +30,7 @@ func (q *Queries) CreateReferenceLink(ctx context.Context, arg CreateReferenceLi
 		arg.ID,
 		arg.LinkID,
 		arg.LinkType,
-		arg.Token,
+		arg.TokenHash,
 		arg.ExpiresAt,
 	)
 	var i ReferenceLink
@@ -38,7 +38,7 @@ func (q *Queries) CreateReferenceLink(ctx context.Context, arg CreateReferenceLi
 		&i.ID,
 		&i.LinkID,
 		&i.LinkType,
-		&i.Token,
+		&i.TokenHash,
 		&i.ExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -48,7 +48,7 @@ func (q *Queries) CreateReferenceLink(ctx context.Context, arg CreateReferenceLi
 }
 
 const deleteReferenceLink = `-- name: DeleteReferenceLink :one
-DELETE FROM reference_link WHERE reference_link.id = $1 RETURNING id, link_id, link_type, token, expires_at, created_at, updated_at, version
+DELETE FROM reference_link WHERE reference_link.id = $1 RETURNING id, link_id, link_type, token_hash, expires_at, created_at, updated_at, version
 `
 
 func (q *Queries) DeleteReferenceLink(ctx context.Context, id uuid.UUID) (ReferenceLink, error) {
@@ -58,7 +58,7 @@ func (q *Queries) DeleteReferenceLink(ctx context.Context, id uuid.UUID) (Refere
 		&i.ID,
 		&i.LinkID,
 		&i.LinkType,
-		&i.Token,
+		&i.TokenHash,
 		&i.ExpiresAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
@@ -68,7 +68,7 @@ func (q *Queries) DeleteReferenceLink(ctx context.Context, id uuid.UUID) (Refere
 }
 
 const getReferenceLinkByID = `-- name: GetReferenceLinkByID :one
-SELECT reference_link.id, reference_link.link_id, reference_link.link_type, reference_link.token, reference_link.expires_at, reference_link.created_at, reference_link.updated_at, reference_link.version FROM reference_link
+SELECT reference_link.id, reference_link.link_id, reference_link.link_type, reference_link.token_hash, reference_link.expires_at, reference_link.created_at, reference_link.updated_at, reference_link.version FROM reference_link
 WHERE reference_link.id = $1
 `
 
@@ -83,7 +83,7 @@ func (q *Queries) GetReferenceLinkByID(ctx context.Context, id uuid.UUID) (GetRe
 		&i.ReferenceLink.ID,
 		&i.ReferenceLink.LinkID,
 		&i.ReferenceLink.LinkType,
-		&i.ReferenceLink.Token,
+		&i.ReferenceLink.TokenHash,
 		&i.ReferenceLink.ExpiresAt,
 		&i.ReferenceLink.CreatedAt,
 		&i.ReferenceLink.UpdatedAt,
@@ -92,23 +92,23 @@ func (q *Queries) GetReferenceLinkByID(ctx context.Context, id uuid.UUID) (GetRe
 	return i, err
 }
 
-const getReferenceLinkByToken = `-- name: GetReferenceLinkByToken :one
-SELECT reference_link.id, reference_link.link_id, reference_link.link_type, reference_link.token, reference_link.expires_at, reference_link.created_at, reference_link.updated_at, reference_link.version FROM reference_link
-WHERE reference_link.token = $1
+const getReferenceLinkByTokenHash = `-- name: GetReferenceLinkByTokenHash :one
+SELECT reference_link.id, reference_link.link_id, reference_link.link_type, reference_link.token_hash, reference_link.expires_at, reference_link.created_at, reference_link.updated_at, reference_link.version FROM reference_link
+WHERE reference_link.token_hash = $1
 `
 
-type GetReferenceLinkByTokenRow struct {
+type GetReferenceLinkByTokenHashRow struct {
 	ReferenceLink ReferenceLink `json:"reference_link"`
 }
 
-func (q *Queries) GetReferenceLinkByToken(ctx context.Context, token string) (GetReferenceLinkByTokenRow, error) {
-	row := q.db.QueryRow(ctx, getReferenceLinkByToken, token)
-	var i GetReferenceLinkByTokenRow
+func (q *Queries) GetReferenceLinkByTokenHash(ctx context.Context, tokenHash string) (GetReferenceLinkByTokenHashRow, error) {
+	row := q.db.QueryRow(ctx, getReferenceLinkByTokenHash, tokenHash)
+	var i GetReferenceLinkByTokenHashRow
 	err := row.Scan(
 		&i.ReferenceLink.ID,
 		&i.ReferenceLink.LinkID,
 		&i.ReferenceLink.LinkType,
-		&i.ReferenceLink.Token,
+		&i.ReferenceLink.TokenHash,
 		&i.ReferenceLink.ExpiresAt,
 		&i.ReferenceLink.CreatedAt,
 		&i.ReferenceLink.UpdatedAt,
@@ -12,6 +12,66 @@ import (
 	"github.com/google/uuid"
 )
 
+const createImpersonationAudit = `-- name: CreateImpersonationAudit :exec
+INSERT INTO impersonation_audit (impersonator_id, user_id, session_id, request_method, request_path)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type CreateImpersonationAuditParams struct {
+	ImpersonatorID uuid.UUID `json:"impersonator_id"`
+	UserID         uuid.UUID `json:"user_id"`
+	SessionID      uuid.UUID `json:"session_id"`
+	RequestMethod  string    `json:"request_method"`
+	RequestPath    string    `json:"request_path"`
+}
+
+func (q *Queries) CreateImpersonationAudit(ctx context.Context, arg CreateImpersonationAuditParams) error {
+	_, err := q.db.Exec(ctx, createImpersonationAudit,
+		arg.ImpersonatorID,
+		arg.UserID,
+		arg.SessionID,
+		arg.RequestMethod,
+		arg.RequestPath,
+	)
+	return err
+}
+
+const createImpersonationSession = `-- name: CreateImpersonationSession :one
+INSERT INTO user_session (user_id, impersonator_id, token_hash, expires_at)
+VALUES ($1, $2, $3, $4) RETURNING id, user_id, impersonator_id, token_hash, expires_at, user_expired, created_at, updated_at, version, last_seen_at, ip_address
+`
+
+type CreateImpersonationSessionParams struct {
+	UserID         uuid.UUID  `json:"user_id"`
+	ImpersonatorID *uuid.UUID `json:"impersonator_id"`
+	TokenHash      string     `json:"token_hash"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+}
+
+func (q *Queries) CreateImpersonationSession(ctx context.Context, arg CreateImpersonationSessionParams) (UserSession, error) {
+	row := q.db.QueryRow(ctx, createImpersonationSession,
+		arg.UserID,
+		arg.ImpersonatorID,
+		arg.TokenHash,
+		arg.ExpiresAt,
+	)
+	var i UserSession
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.ImpersonatorID,
+		&i.TokenHash,
+		&i.ExpiresAt,
+		&i.UserExpired,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.LastSeenAt,
+		&i.IpAddress,
+	)
+	return i, err
+}
+
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (id, given_name, family_name, email, email_verified, claimed, user_handle) 
 VALUES (
@@ -22,7 +82,7 @@ VALUES (
     $5::boolean,
     $6,
     $7
-) RETURNING id, given_name, family_name, email, email_verified, user_handle, claimed, avatar_id, created_at, updated_at, version
+) RETURNING id, given_name, family_name, email, email_verified, user_handle, claimed, avatar_id, created_at, updated_at, version, user_role
 `
 
 type CreateUserParams struct {
@@ -58,48 +118,112 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Version,
+		&i.UserRole,
 	)
 	return i, err
 }
 
 const createUserSession = `-- name: CreateUserSession :one
-INSERT INTO user_session (user_id, token, expires_at) VALUES ($1, $2, $3) RETURNING id, user_id, impersonator_id, token, expires_at, user_expired, created_at, updated_at, version
+INSERT INTO user_session (user_id, token_hash, expires_at) VALUES ($1, $2, $3) RETURNING id, user_id, impersonator_id, token_hash, expires_at, user_expired, created_at, updated_at, version, last_seen_at, ip_address
 `
 
 type CreateUserSessionParams struct {
 	UserID    uuid.UUID `json:"user_id"`
-	Token     string    `json:"token"`
+	TokenHash string    `json:"token_hash"`
 	ExpiresAt time.Time `json:"expires_at"`
 }
 
 func (q *Queries) CreateUserSession(ctx context.Context, arg CreateUserSessionParams) (UserSession, error) {
-	row := q.db.QueryRow(ctx, createUserSession, arg.UserID, arg.Token, arg.ExpiresAt)
+	row := q.db.QueryRow(ctx, createUserSession, arg.UserID, arg.TokenHash, arg.ExpiresAt)
 	var i UserSession
 	err := row.Scan(
 		&i.ID,
 		&i.UserID,
 		&i.ImpersonatorID,
-		&i.Token,
+		&i.TokenHash,
 		&i.ExpiresAt,
 		&i.UserExpired,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Version,
+		&i.LastSeenAt,
+		&i.IpAddress,
 	)
 	return i, err
 }
 
-const expireUserSession = `-- name: ExpireUserSession :exec
-UPDATE user_session SET user_expired = TRUE WHERE user_session.id = $1
+const expireAllUserSessions = `-- name: ExpireAllUserSessions :exec
+UPDATE user_session SET user_expired = TRUE
+WHERE user_session.user_id = $1 AND user_session.user_expired = FALSE
 `
 
-func (q *Queries) ExpireUserSession(ctx context.Context, id uuid.UUID) error {
-	_, err := q.db.Exec(ctx, expireUserSession, id)
+func (q *Queries) ExpireAllUserSessions(ctx context.Context, userID uuid.UUID) error {
+	_, err := q.db.Exec(ctx, expireAllUserSessions, userID)
 	return err
 }
 
+const expireUserSession = `-- name: ExpireUserSession :execrows
+UPDATE user_session SET user_expired = TRUE
+WHERE user_session.id = $1 AND user_session.user_id = $2 AND user_session.user_expired = FALSE
+`
+
+type ExpireUserSessionParams struct {
+	ID     uuid.UUID `json:"id"`
+	UserID uuid.UUID `json:"user_id"`
+}
+
+func (q *Queries) ExpireUserSession(ctx context.Context, arg ExpireUserSessionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, expireUserSession, arg.ID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getActiveUserSessions = `-- name: GetActiveUserSessions :many
+SELECT user_session.id, user_session.user_id, user_session.impersonator_id, user_session.token_hash, user_session.expires_at, user_session.user_expired, user_session.created_at, user_session.updated_at, user_session.version, user_session.last_seen_at, user_session.ip_address FROM user_session
+WHERE user_session.user_id = $1 AND user_session.user_expired = FALSE AND user_session.expires_at > now()
+ORDER BY user_session.last_seen_at DESC
+`
+
+type GetActiveUserSessionsRow struct {
+	UserSession UserSession `json:"user_session"`
+}
+
+func (q *Queries) GetActiveUserSessions(ctx context.Context, userID uuid.UUID) ([]GetActiveUserSessionsRow, error) {
+	rows, err := q.db.Query(ctx, getActiveUserSessions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetActiveUserSessionsRow{}
+	for rows.Next() {
+		var i GetActiveUserSessionsRow
+		if err := rows.Scan(
+			&i.UserSession.ID,
+			&i.UserSession.UserID,
+			&i.UserSession.ImpersonatorID,
+			&i.UserSession.TokenHash,
+			&i.UserSession.ExpiresAt,
+			&i.UserSession.UserExpired,
+			&i.UserSession.CreatedAt,
+			&i.UserSession.UpdatedAt,
+			&i.UserSession.Version,
+			&i.UserSession.LastSeenAt,
+			&i.UserSession.IpAddress,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT users.id, users.given_name, users.family_name, users.email, users.email_verified, users.user_handle, users.claimed, users.avatar_id, users.created_at, users.updated_at, users.version FROM users
+SELECT users.id, users.given_name, users.family_name, users.email, users.email_verified, users.user_handle, users.claimed, users.avatar_id, users.created_at, users.updated_at, users.version, users.user_role FROM users
 WHERE users.email = $1
 `
 
@@ -122,12 +246,13 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (GetUserByEm
 		&i.User.CreatedAt,
 		&i.User.UpdatedAt,
 		&i.User.Version,
+		&i.User.UserRole,
 	)
 	return i, err
 }
 
 const getUserByHandle = `-- name: GetUserByHandle :one
-SELECT users.id, users.given_name, users.family_name, users.email, users.email_verified, users.user_handle, users.claimed, users.avatar_id, users.created_at, users.updated_at, users.version FROM users
+SELECT users.id, users.given_name, users.family_name, users.email, users.email_verified, users.user_handle, users.claimed, users.avatar_id, users.created_at, users.updated_at, users.version, users.user_role FROM users
 WHERE users.user_handle = $1
 `
 
@@ -150,12 +275,13 @@ func (q *Queries) GetUserByHandle(ctx context.Context, userHandle string) (GetUs
 		&i.User.CreatedAt,
 		&i.User.UpdatedAt,
 		&i.User.Version,
+		&i.User.UserRole,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT users.id, users.given_name, users.family_name, users.email, users.email_verified, users.user_handle, users.claimed, users.avatar_id, users.created_at, users.updated_at, users.version FROM users
+SELECT users.id, users.given_name, users.family_name, users.email, users.email_verified, users.user_handle, users.claimed, users.avatar_id, users.created_at, users.updated_at, users.version, users.user_role FROM users
 WHERE users.id = $1
 `
 
@@ -178,24 +304,25 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (GetUserByIDRow
 		&i.User.CreatedAt,
 		&i.User.UpdatedAt,
 		&i.User.Version,
+		&i.User.UserRole,
 	)
 	return i, err
 }
 
-const getUserBySessionToken = `-- name: GetUserBySessionToken :one
-SELECT users.id, users.given_name, users.family_name, users.email, users.email_verified, users.user_handle, users.claimed, users.avatar_id, users.created_at, users.updated_at, users.version, user_session.id, user_session.user_id, user_session.impersonator_id, user_session.token, user_session.expires_at, user_session.user_expired, user_session.created_at, user_session.updated_at, user_session.version FROM users
+const getUserBySessionTokenHash = `-- name: GetUserBySessionTokenHash :one
+SELECT users.id, users.given_name, users.family_name, users.email, users.email_verified, users.user_handle, users.claimed, users.avatar_id, users.created_at, users.updated_at, users.version, users.user_role, user_session.id, user_session.user_id, user_session.impersonator_id, user_session.token_hash, user_session.expires_at, user_session.user_expired, user_session.created_at, user_session.updated_at, user_session.version, user_session.last_seen_at, user_session.ip_address FROM users
 JOIN user_session ON users.id = user_session.user_id
-WHERE user_session.token = $1
+WHERE user_session.token_hash = $1
 `
 
-type GetUserBySessionTokenRow struct {
+type GetUserBySessionTokenHashRow struct {
 	User        User        `json:"user"`
 	UserSession UserSession `json:"user_session"`
 }
 
-func (q *Queries) GetUserBySessionToken(ctx context.Context, token string) (GetUserBySessionTokenRow, error) {
-	row := q.db.QueryRow(ctx, getUserBySessionToken, token)
-	var i GetUserBySessionTokenRow
+func (q *Queries) GetUserBySessionTokenHash(ctx context.Context, tokenHash string) (GetUserBySessionTokenHashRow, error) {
+	row := q.db.QueryRow(ctx, getUserBySessionTokenHash, tokenHash)
+	var i GetUserBySessionTokenHashRow
 	err := row.Scan(
 		&i.User.ID,
 		&i.User.GivenName,
@@ -208,21 +335,24 @@ func (q *Queries) GetUserBySessionToken(ctx context.Context, token string) (GetU
 		&i.User.CreatedAt,
 		&i.User.UpdatedAt,
 		&i.User.Version,
+		&i.User.UserRole,
 		&i.UserSession.ID,
 		&i.UserSession.UserID,
 		&i.UserSession.ImpersonatorID,
-		&i.UserSession.Token,
+		&i.UserSession.TokenHash,
 		&i.UserSession.ExpiresAt,
 		&i.UserSession.UserExpired,
 		&i.UserSession.CreatedAt,
 		&i.UserSession.UpdatedAt,
 		&i.UserSession.Version,
+		&i.UserSession.LastSeenAt,
+		&i.UserSession.IpAddress,
 	)
 	return i, err
 }
 
 const setAvatarImage = `-- name: SetAvatarImage :one
-UPDATE users SET avatar_id = $1 WHERE id = $2 RETURNING id, given_name, family_name, email, email_verified, user_handle, claimed, avatar_id, created_at, updated_at, version
+UPDATE users SET avatar_id = $1, version = version + 1 WHERE id = $2 RETURNING id, given_name, family_name, email, email_verified, user_handle, claimed, avatar_id, created_at, updated_at, version, user_role
 `
 
 type SetAvatarImageParams struct {
@@ -245,10 +375,57 @@ func (q *Queries) SetAvatarImage(ctx context.Context, arg SetAvatarImageParams)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Version,
+		&i.UserRole,
+	)
+	return i, err
+}
+
+const setUserRole = `-- name: SetUserRole :one
+UPDATE users SET user_role = $1, version = version + 1 WHERE id = $2 RETURNING id, given_name, family_name, email, email_verified, user_handle, claimed, avatar_id, created_at, updated_at, version, user_role
+`
+
+type SetUserRoleParams struct {
+	UserRole string    `json:"user_role"`
+	ID       uuid.UUID `json:"id"`
+}
+
+func (q *Queries) SetUserRole(ctx context.Context, arg SetUserRoleParams) (User, error) {
+	row := q.db.QueryRow(ctx, setUserRole, arg.UserRole, arg.ID)
+	var i User
+	err := row.Scan(
+		&i.ID,
+		&i.GivenName,
+		&i.FamilyName,
+		&i.Email,
+		&i.EmailVerified,
+		&i.UserHandle,
+		&i.Claimed,
+		&i.AvatarID,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+		&i.UserRole,
 	)
 	return i, err
 }
 
+const touchUserSession = `-- name: TouchUserSession :exec
+UPDATE user_session
+SET last_seen_at = now(), ip_address = $1, expires_at = GREATEST(expires_at, $2)
+WHERE user_session.id = $3 AND user_session.user_expired = FALSE
+`
+
+type TouchUserSessionParams struct {
+	IpAddress *string   `json:"ip_address"`
+	ExpiresAt time.Time `json:"expires_at"`
+	ID        uuid.UUID `json:"id"`
+}
+
+func (q *Queries) TouchUserSession(ctx context.Context, arg TouchUserSessionParams) error {
+	_, err := q.db.Exec(ctx, touchUserSession, arg.IpAddress, arg.ExpiresAt, arg.ID)
+	return err
+}
+
 const updateUser = `-- name: UpdateUser :one
 UPDATE users SET 
     id = $1, 
@@ -257,8 +434,9 @@ UPDATE users SET
     email = $4,
     email_verified = $5::boolean,
     claimed = $6,
-    user_handle = $7
-WHERE id = $1 RETURNING id, given_name, family_name, email, email_verified, user_handle, claimed, avatar_id, created_at, updated_at, version
+    user_handle = $7,
+    version = version + 1
+WHERE id = $1 AND version = $8 RETURNING id, given_name, family_name, email, email_verified, user_handle, claimed, avatar_id, created_at, updated_at, version, user_role
 `
 
 type UpdateUserParams struct {
@@ -269,6 +447,7 @@ type UpdateUserParams struct {
 	EmailVerified bool      `json:"email_verified"`
 	Claimed       bool      `json:"claimed"`
 	UserHandle    string    `json:"user_handle"`
+	Version       int32     `json:"version"`
 }
 
 func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, error) {
@@ -280,6 +459,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		arg.EmailVerified,
 		arg.Claimed,
 		arg.UserHandle,
+		arg.Version,
 	)
 	var i User
 	err := row.Scan(
@@ -294,6 +474,7 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (User, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.Version,
+		&i.UserRole,
 	)
 	return i, err
 }
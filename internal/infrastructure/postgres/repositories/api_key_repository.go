@@ -0,0 +1,136 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+)
+
+type postgresAPIKeyRepository struct {
+}
+
+func NewPostgresAPIKeyRepository() *postgresAPIKeyRepository {
+	return &postgresAPIKeyRepository{}
+}
+
+func (repo *postgresAPIKeyRepository) GetAPIKeyByID(ctx context.Context, querier models.Querier, apiKeyID uuid.UUID) (*entities.APIKeyEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	row, err := querier.GetAPIKeyByID(ctx, apiKeyID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+
+	return entities.NewAPIKeyEntity(row.ApiKey), nil
+}
+
+func (repo *postgresAPIKeyRepository) GetActiveAPIKeyByHash(ctx context.Context, querier models.Querier, keyHash string) (*entities.APIKeyEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	row, err := querier.GetActiveAPIKeyByHash(ctx, keyHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+
+	return entities.NewAPIKeyEntity(row.ApiKey), nil
+}
+
+func (repo *postgresAPIKeyRepository) GetAPIKeys(ctx context.Context, querier models.Querier) ([]*entities.APIKeyEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	rows, err := querier.GetAPIKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	apiKeyEntities := make([]*entities.APIKeyEntity, 0, len(rows))
+	for _, row := range rows {
+		apiKeyEntities = append(apiKeyEntities, entities.NewAPIKeyEntity(row.ApiKey))
+	}
+
+	return apiKeyEntities, nil
+}
+
+func (repo *postgresAPIKeyRepository) CreateAPIKey(ctx context.Context, querier models.Querier, apiKey *entities.APIKeyEntity) (*entities.APIKeyEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	scopes := make([]string, 0, len(apiKey.Scopes))
+	for _, scope := range apiKey.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	row, err := querier.CreateAPIKey(ctx, models.CreateAPIKeyParams{
+		ID:                 apiKey.ID,
+		KeyName:            apiKey.Name,
+		KeyHash:            apiKey.KeyHash,
+		Scopes:             scopes,
+		OrganizationID:     apiKey.OrganizationID,
+		RateLimitPerMinute: int32(apiKey.RateLimitPerMinute),
+		CreatedBy:          apiKey.CreatedBy,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, entities.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	return entities.NewAPIKeyEntity(row), nil
+}
+
+func (repo *postgresAPIKeyRepository) RotateAPIKey(ctx context.Context, querier models.Querier, apiKeyID uuid.UUID, keyHash string) (*entities.APIKeyEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	row, err := querier.RotateAPIKey(ctx, models.RotateAPIKeyParams{
+		ID:      apiKeyID,
+		KeyHash: keyHash,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+
+	return entities.NewAPIKeyEntity(row), nil
+}
+
+func (repo *postgresAPIKeyRepository) RevokeAPIKey(ctx context.Context, querier models.Querier, apiKeyID uuid.UUID) (*entities.APIKeyEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	row, err := querier.RevokeAPIKey(ctx, apiKeyID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrAPIKeyNotFound
+		}
+		return nil, err
+	}
+
+	return entities.NewAPIKeyEntity(row), nil
+}
+
+func (repo *postgresAPIKeyRepository) TouchAPIKey(ctx context.Context, querier models.Querier, apiKeyID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	return querier.TouchAPIKey(ctx, apiKeyID)
+}
@@ -2,8 +2,11 @@ package repositories
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/mcorrigan89/openmic/internal/domain/entities"
 	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres"
 	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
@@ -22,19 +25,23 @@ func (repo *postgresArtistRepository) GetArtistByID(ctx context.Context, querier
 
 	row, err := querier.GetArtistByID(ctx, artistID)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrArtistNotFound
+		}
 		return nil, err
 	}
 
 	return entities.NewArtistEntity(row.Artist), nil
 }
 
-func (repo *postgresArtistRepository) GetArtistsByTitle(ctx context.Context, querier models.Querier, title string) ([]*entities.ArtistEntity, error) {
+func (repo *postgresArtistRepository) GetArtistsByTitle(ctx context.Context, querier models.Querier, organizationID uuid.UUID, title string) ([]*entities.ArtistEntity, error) {
 	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
 	defer cancel()
 
 	rows, err := querier.GetArtistsByTitle(ctx, models.GetArtistsByTitleParams{
-		Title:         title,
-		MinSimilarity: 0.2,
+		OrganizationID: organizationID,
+		Title:          title,
+		MinSimilarity:  0.2,
 	})
 	if err != nil {
 		return nil, err
@@ -48,11 +55,11 @@ func (repo *postgresArtistRepository) GetArtistsByTitle(ctx context.Context, que
 	return artistEntities, nil
 }
 
-func (repo *postgresArtistRepository) GetAllArtists(ctx context.Context, querier models.Querier) ([]*entities.ArtistEntity, error) {
+func (repo *postgresArtistRepository) GetAllArtists(ctx context.Context, querier models.Querier, organizationID uuid.UUID) ([]*entities.ArtistEntity, error) {
 	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
 	defer cancel()
 
-	rows, err := querier.GetAllArtists(ctx)
+	rows, err := querier.GetAllArtists(ctx, organizationID)
 	if err != nil {
 		return nil, err
 	}
@@ -74,8 +81,14 @@ func (repo *postgresArtistRepository) CreateArtist(ctx context.Context, querier
 		ArtistTitle:    artist.Title,
 		ArtistSubtitle: artist.SubTitle,
 		Bio:            artist.Bio,
+		UserID:         artist.UserID,
+		OrganizationID: artist.OrganizationID,
 	})
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == "artist_organization_id_fkey" {
+			return nil, entities.ErrOrganizationNotFound
+		}
 		return nil, err
 	}
 
@@ -91,22 +104,35 @@ func (repo *postgresArtistRepository) UpdateArtist(ctx context.Context, querier
 		ArtistTitle:    artist.Title,
 		ArtistSubtitle: artist.SubTitle,
 		Bio:            artist.Bio,
+		Version:        artist.Version,
 	})
 	if err != nil {
+		// The caller read the artist in this transaction, so a missing row
+		// means it was changed or deleted since.
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrVersionMismatch
+		}
 		return nil, err
 	}
 
 	return entities.NewArtistEntity(row), nil
 }
 
-func (repo *postgresArtistRepository) DeleteArtist(ctx context.Context, querier models.Querier, artistID uuid.UUID) error {
+func (repo *postgresArtistRepository) DeleteArtist(ctx context.Context, querier models.Querier, artist *entities.ArtistEntity) error {
 	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
 	defer cancel()
 
-	err := querier.DeleteArtist(ctx, artistID)
+	rowsAffected, err := querier.DeleteArtist(ctx, models.DeleteArtistParams{
+		ID:      artist.ID,
+		Version: artist.Version,
+	})
 	if err != nil {
 		return err
 	}
 
+	if rowsAffected == 0 {
+		return entities.ErrVersionMismatch
+	}
+
 	return nil
 }
@@ -3,9 +3,12 @@ package repositories
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/mcorrigan89/openmic/internal/domain/entities"
 	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres"
 	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
@@ -28,42 +31,100 @@ func (repo *postgresEventRepository) GetEventByID(ctx context.Context, querier m
 
 	row, err := querier.GetEventByID(ctx, eventID)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrEventNotFound
+		}
 		repo.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
 		return nil, err
 	}
 
+	return repo.loadEvent(ctx, querier, row.Event, row.Markers)
+}
+
+// loadEvent completes an event row with its lineup and run log.
+func (repo *postgresEventRepository) loadEvent(ctx context.Context, querier models.Querier, eventModel models.Event, markers []byte) (*entities.EventEntity, error) {
 	markerModels := make([]*models.TimeslotMarker, 0)
 
-	if row.Markers != nil {
-		err = json.Unmarshal(row.Markers, &markerModels)
+	if markers != nil {
+		err := json.Unmarshal(markers, &markerModels)
 		if err != nil {
 			repo.logger.Err(err).Ctx(ctx).Msg("Failed to unmarshal markers")
 			return nil, err
 		}
 	}
 
-	timeslotRows, err := querier.TimeSlotsByEventID(ctx, eventID)
+	timeslotRows, err := querier.TimeSlotsByEventID(ctx, eventModel.ID)
 	if err != nil {
 		repo.logger.Err(err).Ctx(ctx).Msg("Failed to get timeslots by event ID")
 		return nil, err
 	}
 
-	timeslotArgs := make([]*entities.NewEventEntitySlotsArgs, 0)
-	for _, timeslotRow := range timeslotRows {
-		timeslotArgs = append(timeslotArgs, &entities.NewEventEntitySlotsArgs{
-			TimeSlot: timeslotRow.Timeslot,
-			Artist:   timeslotRow.Artist,
-		})
+	timeslotArgs, err := newEventEntitySlotsArgs(timeslotRows)
+	if err != nil {
+		repo.logger.Err(err).Ctx(ctx).Msg("Failed to unmarshal timeslot artists")
+		return nil, err
+	}
+
+	runRows, err := querier.TimeSlotRunsByEventID(ctx, eventModel.ID)
+	if err != nil {
+		repo.logger.Err(err).Ctx(ctx).Msg("Failed to get timeslot runs by event ID")
+		return nil, err
+	}
+
+	runs := make([]*entities.TimeSlotRunEntity, 0, len(runRows))
+	for _, runRow := range runRows {
+		runs = append(runs, entities.NewTimeSlotRunEntity(runRow))
+	}
+
+	event := entities.NewEventEntity(eventModel, timeslotArgs, markerModels)
+	event.ApplyRunLog(runs, time.Now())
+
+	return event, nil
+}
+
+// GetCurrentEvent resolves the organization's current event, optionally at one
+// venue, with each event's own window in a single query.
+func (repo *postgresEventRepository) GetCurrentEvent(ctx context.Context, querier models.Querier, organizationID uuid.UUID, venueID *uuid.UUID, now time.Time) (*entities.EventEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	defaultWindow := entities.DefaultCurrentEventWindow()
+	row, err := querier.GetCurrentEvent(ctx, models.GetCurrentEventParams{
+		OrganizationID:       organizationID,
+		VenueID:              venueID,
+		Now:                  now,
+		DefaultEventType:     entities.DefaultCurrentEventType,
+		DefaultMinutesBefore: defaultWindow.MinutesBefore,
+		DefaultMinutesAfter:  defaultWindow.MinutesAfter,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrEventNotFound
+		}
+		repo.logger.Err(err).Ctx(ctx).Msg("Failed to get current event")
+		return nil, err
 	}
 
-	return entities.NewEventEntity(row.Event, timeslotArgs, markerModels), nil
+	event, err := repo.loadEvent(ctx, querier, row.Event, row.Markers)
+	if err != nil {
+		return nil, err
+	}
+	event.CurrentWindow = entities.CurrentEventWindow{
+		MinutesBefore: row.MinutesBefore,
+		MinutesAfter:  row.MinutesAfter,
+	}
+
+	return event, nil
 }
 
-func (repo *postgresEventRepository) GetEvents(ctx context.Context, querier models.Querier, afterDate time.Time) ([]*entities.EventEntity, error) {
+func (repo *postgresEventRepository) GetEvents(ctx context.Context, querier models.Querier, organizationID uuid.UUID, afterDate time.Time) ([]*entities.EventEntity, error) {
 	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
 	defer cancel()
 
-	rows, err := querier.GetAllEvents(ctx, afterDate)
+	rows, err := querier.GetAllEvents(ctx, models.GetAllEventsParams{
+		OrganizationID: organizationID,
+		StartTime:      afterDate,
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -86,12 +147,10 @@ func (repo *postgresEventRepository) GetEvents(ctx context.Context, querier mode
 			return nil, err
 		}
 
-		timeslotArgs := make([]*entities.NewEventEntitySlotsArgs, 0)
-		for _, timeslotRow := range timeslotRows {
-			timeslotArgs = append(timeslotArgs, &entities.NewEventEntitySlotsArgs{
-				TimeSlot: timeslotRow.Timeslot,
-				Artist:   timeslotRow.Artist,
-			})
+		timeslotArgs, err := newEventEntitySlotsArgs(timeslotRows)
+		if err != nil {
+			repo.logger.Err(err).Ctx(ctx).Msg("Failed to unmarshal timeslot artists")
+			return nil, err
 		}
 
 		eventEntities = append(eventEntities, entities.NewEventEntity(row.Event, timeslotArgs, markerModels))
@@ -105,76 +164,223 @@ func (repo *postgresEventRepository) CreateEvent(ctx context.Context, querier mo
 	defer cancel()
 
 	row, err := querier.CreateEvent(ctx, models.CreateEventParams{
-		ID:        event.ID,
-		StartTime: event.StartTime,
-		EndTime:   event.EndTime,
-		EventType: event.EventType,
+		ID:                    event.ID,
+		VenueID:               event.VenueID,
+		StartTime:             event.StartTime,
+		EndTime:               event.EndTime,
+		EventType:             event.EventType,
+		MinutesPerSong:        event.SlotPolicy.MinutesPerSong,
+		BaseSlotMinutes:       event.SlotPolicy.BaseMinutes,
+		ChangeoverMinutes:     event.SlotPolicy.ChangeoverMinutes,
+		AllowDuplicateArtists: event.AllowDuplicateArtists,
 	})
 	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, entities.ErrVenueNotFound
+		}
 		return nil, err
 	}
 
 	return entities.NewEventEntity(row, nil, nil), nil
 }
 
+func (repo *postgresEventRepository) LockEvent(ctx context.Context, querier models.Querier, eventID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	_, err := querier.LockEvent(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entities.ErrEventNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
 func (repo *postgresEventRepository) UpdateEvent(ctx context.Context, querier models.Querier, event *entities.EventEntity) (*entities.EventEntity, error) {
 	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
 	defer cancel()
 
 	row, err := querier.UpdateEvent(ctx, models.UpdateEventParams{
-		ID:        event.ID,
-		StartTime: event.StartTime,
-		EndTime:   event.EndTime,
-		EventType: event.EventType,
+		ID:                    event.ID,
+		StartTime:             event.StartTime,
+		EndTime:               event.EndTime,
+		EventType:             event.EventType,
+		MinutesPerSong:        event.SlotPolicy.MinutesPerSong,
+		BaseSlotMinutes:       event.SlotPolicy.BaseMinutes,
+		ChangeoverMinutes:     event.SlotPolicy.ChangeoverMinutes,
+		AllowDuplicateArtists: event.AllowDuplicateArtists,
+		Version:               event.Version,
 	})
 	if err != nil {
+		// The caller read the event in this transaction, so a missing row
+		// means it was changed or deleted since.
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrVersionMismatch
+		}
 		return nil, err
 	}
 
 	return entities.NewEventEntity(row, nil, nil), nil
 }
 
-func (repo *postgresEventRepository) DeleteEvent(ctx context.Context, querier models.Querier, eventID uuid.UUID) error {
+func (repo *postgresEventRepository) DeleteEvent(ctx context.Context, querier models.Querier, event *entities.EventEntity) error {
 	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
 	defer cancel()
 
-	err := querier.DeleteEvent(ctx, eventID)
+	rowsAffected, err := querier.DeleteEvent(ctx, models.DeleteEventParams{
+		ID:      event.ID,
+		Version: event.Version,
+	})
 	if err != nil {
 		return err
 	}
 
+	if rowsAffected == 0 {
+		return entities.ErrVersionMismatch
+	}
+
 	return nil
 }
 
+func (repo *postgresEventRepository) NextChangeSeq(ctx context.Context, querier models.Querier, eventID uuid.UUID) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	seq, err := querier.NextEventChangeSeq(ctx, eventID)
+	if err != nil {
+		return 0, err
+	}
+
+	return seq, nil
+}
+
 func (repo *postgresEventRepository) UpdateTimeSlot(ctx context.Context, querier models.Querier, timeslot *entities.TimeSlotEntity) error {
 	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
 	defer cancel()
 
-	_, err := querier.UpdateTimeSlot(ctx, models.UpdateTimeSlotParams{
-		ID:                 timeslot.ID,
-		ArtistNameOverride: timeslot.NameOverride,
-		SortKey:            timeslot.SortKey,
-		SongCount:          timeslot.SongCount,
+	rowsAffected, err := querier.UpdateTimeSlot(ctx, models.UpdateTimeSlotParams{
+		ID:                      timeslot.ID,
+		ArtistNameOverride:      timeslot.NameOverride,
+		SortKey:                 timeslot.SortKey,
+		SongCount:               timeslot.SongCount,
+		DurationOverrideMinutes: timeslot.DurationOverrideMinutes,
+		Notes:                   timeslot.Notes,
+		Version:                 timeslot.Version,
+	})
+	if err != nil {
+		if isSortKeyTaken(err) {
+			return entities.ErrSortKeyTaken
+		}
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrVersionMismatch
+	}
+
+	return nil
+}
+
+func (repo *postgresEventRepository) DeleteTimeSlot(ctx context.Context, querier models.Querier, eventID uuid.UUID, timeslot *entities.TimeSlotEntity) error {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	rowsAffected, err := querier.DeleteTimeSlot(ctx, models.DeleteTimeSlotParams{
+		ID:      timeslot.ID,
+		EventID: eventID,
+		Version: timeslot.Version,
+	})
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrVersionMismatch
+	}
+
+	return nil
+}
+
+// SetTimeSlotSortKeys saves the sort key of every timeslot in one statement,
+// so keys can be swapped between them.
+func (repo *postgresEventRepository) SetTimeSlotSortKeys(ctx context.Context, querier models.Querier, eventID uuid.UUID, timeslots []*entities.TimeSlotEntity) error {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	ids := make([]uuid.UUID, 0, len(timeslots))
+	sortKeys := make([]string, 0, len(timeslots))
+	for _, timeslot := range timeslots {
+		ids = append(ids, timeslot.ID)
+		sortKeys = append(sortKeys, timeslot.SortKey)
+	}
+
+	rowsAffected, err := querier.SetTimeslotSortKeys(ctx, models.SetTimeslotSortKeysParams{
+		EventID:  eventID,
+		Ids:      ids,
+		SortKeys: sortKeys,
 	})
 	if err != nil {
+		if isSortKeyTaken(err) {
+			return entities.ErrSortKeyTaken
+		}
 		return err
 	}
 
+	if rowsAffected != int64(len(timeslots)) {
+		return entities.ErrTimeslotNotFound
+	}
+
 	return nil
 }
 
-func (repo *postgresEventRepository) AddArtistToEvent(ctx context.Context, querier models.Querier, eventID uuid.UUID, artistID uuid.UUID, sortKey string, artistNameOverride *string) error {
+func (repo *postgresEventRepository) AddArtistToEvent(ctx context.Context, querier models.Querier, eventID uuid.UUID, artistID uuid.UUID, sortKey string, artistNameOverride *string, songCount int32) error {
 	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
 	defer cancel()
 
-	err := querier.AddArtistToEvent(ctx, models.AddArtistToEventParams{
+	rowsAffected, err := querier.AddArtistToEvent(ctx, models.AddArtistToEventParams{
 		ID:                 uuid.New(),
 		EventID:            eventID,
 		ArtistID:           artistID,
 		ArtistNameOverride: artistNameOverride,
 		SortKey:            sortKey,
+		SongCount:          songCount,
+	})
+	if err != nil {
+		if isSortKeyTaken(err) {
+			return entities.ErrSortKeyTaken
+		}
+		return err
+	}
+
+	// Nothing is inserted when the artist belongs to another organization.
+	if rowsAffected == 0 {
+		return entities.ErrArtistNotFound
+	}
+
+	return nil
+}
+
+func (repo *postgresEventRepository) AddLineupItem(ctx context.Context, querier models.Querier, eventID uuid.UUID, item *entities.TimeSlotEntity) error {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	err := querier.AddLineupItem(ctx, models.AddLineupItemParams{
+		ID:              item.ID,
+		EventID:         eventID,
+		ItemType:        string(item.ItemType),
+		Title:           *item.NameOverride,
+		SortKey:         item.SortKey,
+		DurationMinutes: *item.DurationOverrideMinutes,
+		Notes:           item.Notes,
 	})
 	if err != nil {
+		if isSortKeyTaken(err) {
+			return entities.ErrSortKeyTaken
+		}
 		return err
 	}
 
@@ -187,7 +393,7 @@ func (repo *postgresEventRepository) RemoveArtistFromEvent(ctx context.Context,
 
 	err := querier.RemoveArtistFromEvent(ctx, models.RemoveArtistFromEventParams{
 		EventID:  eventID,
-		ArtistID: artistID,
+		ArtistID: &artistID,
 	})
 	if err != nil {
 		return err
@@ -201,11 +407,11 @@ func (repo *postgresEventRepository) CreateTimeslotMarker(ctx context.Context, q
 	defer cancel()
 
 	_, err := querier.CreateTimeslotMarker(ctx, models.CreateTimeslotMarkerParams{
-		EventID:       eventID,
-		ID:            timeSlotMarker.ID,
-		MarkerType:    timeSlotMarker.Type,
-		MarkerValue:   timeSlotMarker.Time,
-		TimeslotIndex: int32(timeSlotMarker.Index),
+		EventID:    eventID,
+		ID:         timeSlotMarker.ID,
+		TimeslotID: timeSlotMarker.TimeSlotID,
+		MarkerType: string(timeSlotMarker.Type),
+		MarkerTime: timeSlotMarker.Time,
 	})
 	if err != nil {
 		return err
@@ -219,10 +425,10 @@ func (repo *postgresEventRepository) UpdateTimeslotMarker(ctx context.Context, q
 	defer cancel()
 
 	_, err := querier.UpdateTimeslotMarker(ctx, models.UpdateTimeslotMarkerParams{
-		ID:            timeSlotMarker.ID,
-		TimeslotIndex: int32(timeSlotMarker.Index),
-		MarkerValue:   timeSlotMarker.Time,
-		MarkerType:    timeSlotMarker.Type,
+		ID:         timeSlotMarker.ID,
+		TimeslotID: timeSlotMarker.TimeSlotID,
+		MarkerType: string(timeSlotMarker.Type),
+		MarkerTime: timeSlotMarker.Time,
 	})
 	if err != nil {
 		return err
@@ -243,3 +449,64 @@ func (repo *postgresEventRepository) DeleteTimeslotMarker(ctx context.Context, q
 
 	return nil
 }
+
+func (repo *postgresEventRepository) StartTimeSlotRun(ctx context.Context, querier models.Querier, eventID uuid.UUID, run *entities.TimeSlotRunEntity) error {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	_, err := querier.StartTimeSlotRun(ctx, models.StartTimeSlotRunParams{
+		ID:         run.ID,
+		EventID:    eventID,
+		TimeslotID: run.TimeSlotID,
+		StartedAt:  run.StartedAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *postgresEventRepository) EndTimeSlotRuns(ctx context.Context, querier models.Querier, eventID uuid.UUID, endedAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	err := querier.EndTimeSlotRuns(ctx, models.EndTimeSlotRunsParams{
+		EndedAt: &endedAt,
+		EventID: eventID,
+	})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// isSortKeyTaken reports whether err is a second timeslot at the same place in
+// an event's lineup.
+func isSortKeyTaken(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == "timeslot_event_id_sort_key_key"
+}
+
+// newEventEntitySlotsArgs pairs each timeslot with its artist. Lineup items
+// other than artist sets come back without one.
+func newEventEntitySlotsArgs(timeslotRows []models.TimeSlotsByEventIDRow) ([]*entities.NewEventEntitySlotsArgs, error) {
+	timeslotArgs := make([]*entities.NewEventEntitySlotsArgs, 0, len(timeslotRows))
+	for _, timeslotRow := range timeslotRows {
+		var artist *models.Artist
+		if timeslotRow.Artist != nil {
+			err := json.Unmarshal(timeslotRow.Artist, &artist)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		timeslotArgs = append(timeslotArgs, &entities.NewEventEntitySlotsArgs{
+			TimeSlot: timeslotRow.Timeslot,
+			Artist:   artist,
+		})
+	}
+
+	return timeslotArgs, nil
+}
@@ -0,0 +1,112 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+)
+
+type postgresIdentityRepository struct {
+}
+
+func NewPostgresIdentityRepository() *postgresIdentityRepository {
+	return &postgresIdentityRepository{}
+}
+
+func (repo *postgresIdentityRepository) GetIdentity(ctx context.Context, querier models.Querier, provider string, subject string) (*entities.IdentityEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	row, err := querier.GetUserIdentity(ctx, models.GetUserIdentityParams{
+		Provider: provider,
+		Subject:  subject,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrIdentityNotFound
+		}
+		return nil, err
+	}
+
+	return entities.NewIdentityEntity(row.UserIdentity), nil
+}
+
+func (repo *postgresIdentityRepository) GetIdentitiesByUserID(ctx context.Context, querier models.Querier, userID uuid.UUID) ([]*entities.IdentityEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	rows, err := querier.GetUserIdentitiesByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	identityEntities := make([]*entities.IdentityEntity, 0, len(rows))
+	for _, row := range rows {
+		identityEntities = append(identityEntities, entities.NewIdentityEntity(row.UserIdentity))
+	}
+
+	return identityEntities, nil
+}
+
+func (repo *postgresIdentityRepository) CreateIdentity(ctx context.Context, querier models.Querier, identity *entities.IdentityEntity) (*entities.IdentityEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	row, err := querier.CreateUserIdentity(ctx, models.CreateUserIdentityParams{
+		ID:       identity.ID,
+		UserID:   identity.UserID,
+		Provider: identity.Provider,
+		Subject:  identity.Subject,
+		Email:    identity.Email,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.NewIdentityEntity(row), nil
+}
+
+func (repo *postgresIdentityRepository) CreateLoginRequest(ctx context.Context, querier models.Querier, loginRequest *entities.LoginRequestEntity) (*entities.LoginRequestEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	row, err := querier.CreateOIDCLoginRequest(ctx, models.CreateOIDCLoginRequestParams{
+		StateHash:    loginRequest.StateHash,
+		Provider:     loginRequest.Provider,
+		Nonce:        loginRequest.Nonce,
+		CodeVerifier: loginRequest.CodeVerifier,
+		ExpiresAt:    loginRequest.ExpiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.NewLoginRequestEntity(row), nil
+}
+
+func (repo *postgresIdentityRepository) ConsumeLoginRequest(ctx context.Context, querier models.Querier, stateHash string) (*entities.LoginRequestEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	row, err := querier.ConsumeOIDCLoginRequest(ctx, stateHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrLoginRequestInvalid
+		}
+		return nil, err
+	}
+
+	return entities.NewLoginRequestEntity(row), nil
+}
+
+func (repo *postgresIdentityRepository) DeleteExpiredLoginRequests(ctx context.Context, querier models.Querier) error {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	return querier.DeleteExpiredOIDCLoginRequests(ctx)
+}
@@ -2,8 +2,10 @@ package repositories
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/mcorrigan89/openmic/internal/domain/entities"
 	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres"
 	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
@@ -22,6 +24,9 @@ func (repo *postgresImageRepository) GetImageByID(ctx context.Context, querier m
 
 	row, err := querier.GetImageByID(ctx, imageID)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrImageNotFound
+		}
 		return nil, err
 	}
 
@@ -0,0 +1,353 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+)
+
+type postgresOrganizationRepository struct {
+}
+
+func NewPostgresOrganizationRepository() *postgresOrganizationRepository {
+	return &postgresOrganizationRepository{}
+}
+
+func (repo *postgresOrganizationRepository) GetOrganizationByID(ctx context.Context, querier models.Querier, organizationID uuid.UUID) (*entities.OrganizationEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	row, err := querier.GetOrganizationByID(ctx, organizationID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	return entities.NewOrganizationEntity(row.Organization), nil
+}
+
+func (repo *postgresOrganizationRepository) GetOrganizationByVenueID(ctx context.Context, querier models.Querier, venueID uuid.UUID) (*entities.OrganizationEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	row, err := querier.GetOrganizationByVenueID(ctx, venueID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	return entities.NewOrganizationEntity(row.Organization), nil
+}
+
+func (repo *postgresOrganizationRepository) GetOrganizationByEventID(ctx context.Context, querier models.Querier, eventID uuid.UUID) (*entities.OrganizationEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	row, err := querier.GetOrganizationByEventID(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	return entities.NewOrganizationEntity(row.Organization), nil
+}
+
+func (repo *postgresOrganizationRepository) GetOrganizationByArtistID(ctx context.Context, querier models.Querier, artistID uuid.UUID) (*entities.OrganizationEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	row, err := querier.GetOrganizationByArtistID(ctx, artistID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	return entities.NewOrganizationEntity(row.Organization), nil
+}
+
+func (repo *postgresOrganizationRepository) GetOrganizationsByUserID(ctx context.Context, querier models.Querier, userID uuid.UUID) ([]*entities.OrganizationEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	rows, err := querier.GetOrganizationsByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	organizationEntities := make([]*entities.OrganizationEntity, 0, len(rows))
+	for _, row := range rows {
+		organizationEntities = append(organizationEntities, entities.NewOrganizationEntity(row.Organization))
+	}
+
+	return organizationEntities, nil
+}
+
+func (repo *postgresOrganizationRepository) CreateOrganization(ctx context.Context, querier models.Querier, organization *entities.OrganizationEntity) (*entities.OrganizationEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	row, err := querier.CreateOrganization(ctx, models.CreateOrganizationParams{
+		ID:                 organization.ID,
+		OrganizationName:   organization.Name,
+		OrganizationHandle: organization.Handle,
+	})
+	if err != nil {
+		return nil, organizationConstraintError(err)
+	}
+
+	return entities.NewOrganizationEntity(row), nil
+}
+
+func (repo *postgresOrganizationRepository) UpdateOrganization(ctx context.Context, querier models.Querier, organization *entities.OrganizationEntity) (*entities.OrganizationEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	row, err := querier.UpdateOrganization(ctx, models.UpdateOrganizationParams{
+		ID:                 organization.ID,
+		OrganizationName:   organization.Name,
+		OrganizationHandle: organization.Handle,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrOrganizationNotFound
+		}
+		return nil, organizationConstraintError(err)
+	}
+
+	return entities.NewOrganizationEntity(row), nil
+}
+
+func organizationConstraintError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		switch pgErr.ConstraintName {
+		case "organization_organization_handle_key":
+			return entities.ErrOrganizationHandleInUse
+		default:
+			return fmt.Errorf("unique constraint violation: %s", pgErr.ConstraintName)
+		}
+	}
+	return err
+}
+
+func (repo *postgresOrganizationRepository) GetOrganizationMembers(ctx context.Context, querier models.Querier, organizationID uuid.UUID) ([]*entities.UserEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	rows, err := querier.GetOrganizationMembers(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	userEntities := make([]*entities.UserEntity, 0, len(rows))
+	for _, row := range rows {
+		userEntities = append(userEntities, entities.NewUserEntity(row.User, nil))
+	}
+
+	return userEntities, nil
+}
+
+func (repo *postgresOrganizationRepository) IsOrganizationMember(ctx context.Context, querier models.Querier, organizationID uuid.UUID, userID uuid.UUID) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	return querier.IsOrganizationMember(ctx, models.IsOrganizationMemberParams{
+		OrganizationID: organizationID,
+		UserID:         userID,
+	})
+}
+
+func (repo *postgresOrganizationRepository) AddOrganizationMember(ctx context.Context, querier models.Querier, organizationID uuid.UUID, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	err := querier.AddOrganizationMember(ctx, models.AddOrganizationMemberParams{
+		OrganizationID: organizationID,
+		UserID:         userID,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			if pgErr.ConstraintName == "organization_member_user_id_fkey" {
+				return entities.ErrUserNotFound
+			}
+			return entities.ErrOrganizationNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (repo *postgresOrganizationRepository) RemoveOrganizationMember(ctx context.Context, querier models.Querier, organizationID uuid.UUID, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	return querier.RemoveOrganizationMember(ctx, models.RemoveOrganizationMemberParams{
+		OrganizationID: organizationID,
+		UserID:         userID,
+	})
+}
+
+func (repo *postgresOrganizationRepository) GetVenueByID(ctx context.Context, querier models.Querier, venueID uuid.UUID) (*entities.VenueEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	row, err := querier.GetVenueByID(ctx, venueID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrVenueNotFound
+		}
+		return nil, err
+	}
+
+	return entities.NewVenueEntity(row.Venue), nil
+}
+
+func (repo *postgresOrganizationRepository) GetVenuesByOrganizationID(ctx context.Context, querier models.Querier, organizationID uuid.UUID) ([]*entities.VenueEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	rows, err := querier.GetVenuesByOrganizationID(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	venueEntities := make([]*entities.VenueEntity, 0, len(rows))
+	for _, row := range rows {
+		venueEntities = append(venueEntities, entities.NewVenueEntity(row.Venue))
+	}
+
+	return venueEntities, nil
+}
+
+func (repo *postgresOrganizationRepository) CreateVenue(ctx context.Context, querier models.Querier, venue *entities.VenueEntity) (*entities.VenueEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	minutesBefore, minutesAfter := venueWindowMinutes(venue)
+	row, err := querier.CreateVenue(ctx, models.CreateVenueParams{
+		ID:                   venue.ID,
+		OrganizationID:       venue.OrganizationID,
+		VenueName:            venue.Name,
+		VenueAddress:         venue.Address,
+		CurrentMinutesBefore: minutesBefore,
+		CurrentMinutesAfter:  minutesAfter,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, entities.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	return entities.NewVenueEntity(row), nil
+}
+
+func (repo *postgresOrganizationRepository) UpdateVenue(ctx context.Context, querier models.Querier, venue *entities.VenueEntity) (*entities.VenueEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	minutesBefore, minutesAfter := venueWindowMinutes(venue)
+	row, err := querier.UpdateVenue(ctx, models.UpdateVenueParams{
+		ID:                   venue.ID,
+		VenueName:            venue.Name,
+		VenueAddress:         venue.Address,
+		CurrentMinutesBefore: minutesBefore,
+		CurrentMinutesAfter:  minutesAfter,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrVenueNotFound
+		}
+		return nil, err
+	}
+
+	return entities.NewVenueEntity(row), nil
+}
+
+func (repo *postgresOrganizationRepository) DeleteVenue(ctx context.Context, querier models.Querier, venueID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	return querier.DeleteVenue(ctx, venueID)
+}
+
+func (repo *postgresOrganizationRepository) GetEventTypeWindows(ctx context.Context, querier models.Querier, organizationID uuid.UUID) ([]*entities.EventTypeWindowEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	rows, err := querier.GetEventTypeWindows(ctx, organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	windowEntities := make([]*entities.EventTypeWindowEntity, 0, len(rows))
+	for _, row := range rows {
+		windowEntities = append(windowEntities, entities.NewEventTypeWindowEntity(row))
+	}
+
+	return windowEntities, nil
+}
+
+func (repo *postgresOrganizationRepository) SetEventTypeWindow(ctx context.Context, querier models.Querier, window *entities.EventTypeWindowEntity) (*entities.EventTypeWindowEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	row, err := querier.SetEventTypeWindow(ctx, models.SetEventTypeWindowParams{
+		OrganizationID: window.OrganizationID,
+		EventType:      window.EventType,
+		MinutesBefore:  window.Window.MinutesBefore,
+		MinutesAfter:   window.Window.MinutesAfter,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23503" {
+			return nil, entities.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+
+	return entities.NewEventTypeWindowEntity(row), nil
+}
+
+func (repo *postgresOrganizationRepository) DeleteEventTypeWindow(ctx context.Context, querier models.Querier, organizationID uuid.UUID, eventType string) error {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	rows, err := querier.DeleteEventTypeWindow(ctx, models.DeleteEventTypeWindowParams{
+		OrganizationID: organizationID,
+		EventType:      eventType,
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return entities.ErrEventTypeWindowMissing
+	}
+
+	return nil
+}
+
+func venueWindowMinutes(venue *entities.VenueEntity) (*int32, *int32) {
+	if venue.CurrentWindow == nil {
+		return nil, nil
+	}
+	return &venue.CurrentWindow.MinutesBefore, &venue.CurrentWindow.MinutesAfter
+}
@@ -2,8 +2,10 @@ package repositories
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/mcorrigan89/openmic/internal/domain/entities"
 	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres"
 	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
@@ -24,7 +26,7 @@ func (repo *postgresReferenceLinkRepository) CreateReferenceLink(ctx context.Con
 		ID:        emailLink.ID,
 		LinkID:    emailLink.LinkID,
 		LinkType:  emailLink.Type,
-		Token:     emailLink.Token,
+		TokenHash: emailLink.TokenHash,
 		ExpiresAt: emailLink.ExpiresAt,
 	})
 	if err != nil {
@@ -46,12 +48,15 @@ func (repo *postgresReferenceLinkRepository) GetReferenceLinkByID(ctx context.Co
 	return entities.NewReferenceLinkEntity(row.ReferenceLink), nil
 }
 
-func (repo *postgresReferenceLinkRepository) GetReferenceLinkByToken(ctx context.Context, querier models.Querier, token string) (*entities.ReferenceLinkEntity, error) {
+func (repo *postgresReferenceLinkRepository) GetReferenceLinkByTokenHash(ctx context.Context, querier models.Querier, tokenHash string) (*entities.ReferenceLinkEntity, error) {
 	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
 	defer cancel()
 
-	row, err := querier.GetReferenceLinkByToken(ctx, token)
+	row, err := querier.GetReferenceLinkByTokenHash(ctx, tokenHash)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrLinkNotFound
+		}
 		return nil, err
 	}
 
@@ -27,6 +27,9 @@ func (repo *postgresUserRepository) GetUserByID(ctx context.Context, querier mod
 
 	row, err := querier.GetUserByID(ctx, userID)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrUserNotFound
+		}
 		return nil, err
 	}
 
@@ -78,12 +81,15 @@ func (repo *postgresUserRepository) GetUserByHandle(ctx context.Context, querier
 	return entities.NewUserEntity(row.User, avatarImageEntity), nil
 }
 
-func (repo *postgresUserRepository) GetUserContextBySessionToken(ctx context.Context, querier models.Querier, sessionToken string) (*entities.UserContextEntity, error) {
+func (repo *postgresUserRepository) GetUserContextBySessionTokenHash(ctx context.Context, querier models.Querier, tokenHash string) (*entities.UserContextEntity, error) {
 	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
 	defer cancel()
 
-	row, err := querier.GetUserBySessionToken(ctx, sessionToken)
+	row, err := querier.GetUserBySessionTokenHash(ctx, tokenHash)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrSessionNotFound
+		}
 		return nil, err
 	}
 
@@ -106,7 +112,7 @@ func (repo *postgresUserRepository) CreateUser(ctx context.Context, querier mode
 		GivenName:     user.GivenName,
 		FamilyName:    user.FamilyName,
 		Email:         user.Email,
-		EmailVerified: false,
+		EmailVerified: user.EmailVerified,
 		Claimed:       user.Claimed,
 		UserHandle:    user.Handle,
 	})
@@ -145,8 +151,14 @@ func (repo *postgresUserRepository) UpdateUser(ctx context.Context, querier mode
 		EmailVerified: user.EmailVerified,
 		Claimed:       user.Claimed,
 		UserHandle:    user.Handle,
+		Version:       user.Version,
 	})
 	if err != nil {
+		// The caller read the user in this transaction, so a missing row
+		// means it was changed since.
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrVersionMismatch
+		}
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
 			switch pgErr.ConstraintName {
@@ -169,13 +181,13 @@ func (repo *postgresUserRepository) UpdateUser(ctx context.Context, querier mode
 	return entities.NewUserEntity(row, avatarImageEntity), nil
 }
 
-func (repo *postgresUserRepository) CreateSession(ctx context.Context, querier models.Querier, user *entities.UserEntity, token string, expiresAt time.Time) (*entities.UserContextEntity, error) {
+func (repo *postgresUserRepository) CreateSession(ctx context.Context, querier models.Querier, user *entities.UserEntity, tokenHash string, expiresAt time.Time) (*entities.UserContextEntity, error) {
 	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
 	defer cancel()
 
 	row, err := querier.CreateUserSession(ctx, models.CreateUserSessionParams{
 		UserID:    user.ID,
-		Token:     token,
+		TokenHash: tokenHash,
 		ExpiresAt: expiresAt,
 	})
 	if err != nil {
@@ -185,6 +197,36 @@ func (repo *postgresUserRepository) CreateSession(ctx context.Context, querier m
 	return entities.NewUserContextEntity(user, row), nil
 }
 
+func (repo *postgresUserRepository) CreateImpersonationSession(ctx context.Context, querier models.Querier, user *entities.UserEntity, impersonatorID uuid.UUID, tokenHash string, expiresAt time.Time) (*entities.UserContextEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	row, err := querier.CreateImpersonationSession(ctx, models.CreateImpersonationSessionParams{
+		UserID:         user.ID,
+		ImpersonatorID: &impersonatorID,
+		TokenHash:      tokenHash,
+		ExpiresAt:      expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.NewUserContextEntity(user, row), nil
+}
+
+func (repo *postgresUserRepository) CreateImpersonationAudit(ctx context.Context, querier models.Querier, impersonatorID uuid.UUID, userID uuid.UUID, sessionID uuid.UUID, method string, path string) error {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	return querier.CreateImpersonationAudit(ctx, models.CreateImpersonationAuditParams{
+		ImpersonatorID: impersonatorID,
+		UserID:         userID,
+		SessionID:      sessionID,
+		RequestMethod:  method,
+		RequestPath:    path,
+	})
+}
+
 func (repo *postgresUserRepository) SetAvatarImage(ctx context.Context, querier models.Querier, image *entities.ImageEntity, user *entities.UserEntity) (*entities.UserEntity, error) {
 	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
 	defer cancel()
@@ -217,3 +259,80 @@ func (repo *postgresUserRepository) getAvatarImageEntity(ctx context.Context, qu
 
 	return nil, nil
 }
+
+func (repo *postgresUserRepository) SetUserRole(ctx context.Context, querier models.Querier, userID uuid.UUID, role entities.Role) (*entities.UserEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	row, err := querier.SetUserRole(ctx, models.SetUserRoleParams{
+		ID:       userID,
+		UserRole: string(role),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, entities.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	avatarImageEntity, err := repo.getAvatarImageEntity(ctx, querier, &row)
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.NewUserEntity(row, avatarImageEntity), nil
+}
+
+func (repo *postgresUserRepository) GetActiveSessions(ctx context.Context, querier models.Querier, userID uuid.UUID) ([]*entities.SessionEntity, error) {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	rows, err := querier.GetActiveUserSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionEntities := make([]*entities.SessionEntity, 0, len(rows))
+	for _, row := range rows {
+		sessionEntities = append(sessionEntities, entities.NewSessionEntity(row.UserSession))
+	}
+
+	return sessionEntities, nil
+}
+
+func (repo *postgresUserRepository) TouchSession(ctx context.Context, querier models.Querier, sessionID uuid.UUID, ipAddress string, expiresAt time.Time) error {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	return querier.TouchUserSession(ctx, models.TouchUserSessionParams{
+		ID:        sessionID,
+		IpAddress: &ipAddress,
+		ExpiresAt: expiresAt,
+	})
+}
+
+func (repo *postgresUserRepository) ExpireSession(ctx context.Context, querier models.Querier, userID uuid.UUID, sessionID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	rowsAffected, err := querier.ExpireUserSession(ctx, models.ExpireUserSessionParams{
+		ID:     sessionID,
+		UserID: userID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrSessionNotFound
+	}
+
+	return nil
+}
+
+func (repo *postgresUserRepository) ExpireAllSessions(ctx context.Context, querier models.Querier, userID uuid.UUID) error {
+	ctx, cancel := context.WithTimeout(ctx, postgres.DefaultTimeout)
+	defer cancel()
+
+	return querier.ExpireAllUserSessions(ctx, userID)
+}
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	maxTransactionAttempts = 3
+	retryBaseDelay         = 20 * time.Millisecond
+)
+
+// IsSerializationFailure reports whether err means the transaction lost a race
+// with another one and can be run again from the start.
+func IsSerializationFailure(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	// serialization_failure and deadlock_detected
+	return pgErr.Code == "40001" || pgErr.Code == "40P01"
+}
+
+// RetryTransaction calls attempt until it succeeds or fails with anything other
+// than a serialization failure. Each attempt must open its own transaction.
+func RetryTransaction(ctx context.Context, attempt func() error) error {
+	var err error
+	for i := 1; ; i++ {
+		err = attempt()
+		if !IsSerializationFailure(err) || i == maxTransactionAttempts {
+			return err
+		}
+
+		delay := time.Duration(i)*retryBaseDelay + rand.N(retryBaseDelay)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsSerializationFailure(t *testing.T) {
+	assert.True(t, IsSerializationFailure(&pgconn.PgError{Code: "40001"}))
+	assert.True(t, IsSerializationFailure(fmt.Errorf("commit: %w", &pgconn.PgError{Code: "40P01"})))
+	assert.False(t, IsSerializationFailure(&pgconn.PgError{Code: "23505"}))
+	assert.False(t, IsSerializationFailure(errors.New("connection refused")))
+	assert.False(t, IsSerializationFailure(nil))
+}
+
+func TestRetryTransaction(t *testing.T) {
+	serializationFailure := &pgconn.PgError{Code: "40001"}
+
+	t.Run("retries until the attempt succeeds", func(t *testing.T) {
+		attempts := 0
+		err := RetryTransaction(context.Background(), func() error {
+			attempts++
+			if attempts < 2 {
+				return serializationFailure
+			}
+			return nil
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, attempts)
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		attempts := 0
+		err := RetryTransaction(context.Background(), func() error {
+			attempts++
+			return serializationFailure
+		})
+
+		assert.ErrorIs(t, err, serializationFailure)
+		assert.Equal(t, maxTransactionAttempts, attempts)
+	})
+
+	t.Run("does not retry other errors", func(t *testing.T) {
+		attempts := 0
+		failure := errors.New("timeslot not found")
+		err := RetryTransaction(context.Background(), func() error {
+			attempts++
+			return failure
+		})
+
+		assert.ErrorIs(t, err, failure)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		attempts := 0
+		err := RetryTransaction(ctx, func() error {
+			attempts++
+			return serializationFailure
+		})
+
+		assert.ErrorIs(t, err, serializationFailure)
+		assert.Equal(t, 1, attempts)
+	})
+}
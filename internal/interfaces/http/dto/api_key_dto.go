@@ -0,0 +1,84 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+)
+
+type APIKeyDto struct {
+	ID                 uuid.UUID  `json:"id"`
+	Name               string     `json:"name"`
+	Scopes             []string   `json:"scopes"`
+	OrganizationID     *uuid.UUID `json:"organization_id"`
+	RateLimitPerMinute int        `json:"rate_limit_per_minute"`
+	LastUsedAt         *time.Time `json:"last_used_at"`
+	RevokedAt          *time.Time `json:"revoked_at"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+func NewAPIKeyDtoFromEntity(entity *entities.APIKeyEntity) *APIKeyDto {
+	scopes := make([]string, 0, len(entity.Scopes))
+	for _, scope := range entity.Scopes {
+		scopes = append(scopes, string(scope))
+	}
+
+	return &APIKeyDto{
+		ID:                 entity.ID,
+		Name:               entity.Name,
+		Scopes:             scopes,
+		OrganizationID:     entity.OrganizationID,
+		RateLimitPerMinute: entity.RateLimitPerMinute,
+		LastUsedAt:         entity.LastUsedAt,
+		RevokedAt:          entity.RevokedAt,
+		CreatedAt:          entity.CreatedAt,
+	}
+}
+
+// APIKeySecretDto carries the raw key. It is only returned when a key is
+// created or rotated.
+type APIKeySecretDto struct {
+	APIKeyDto
+	Key string `json:"key"`
+}
+
+func NewAPIKeySecretDtoFromEntity(entity *entities.APIKeyEntity) *APIKeySecretDto {
+	return &APIKeySecretDto{
+		APIKeyDto: *NewAPIKeyDtoFromEntity(entity),
+		Key:       entity.Key,
+	}
+}
+
+type GetAPIKeysResponse struct {
+	Body []*APIKeyDto `json:"body"`
+}
+
+type CreateAPIKeyRequest struct {
+	Body struct {
+		Name               string     `json:"name" minLength:"1"`
+		Scopes             []string   `json:"scopes" minItems:"1"`
+		OrganizationID     *uuid.UUID `json:"organization_id,omitempty" required:"false"`
+		RateLimitPerMinute int        `json:"rate_limit_per_minute,omitempty" minimum:"0" required:"false"`
+	}
+}
+
+type CreateAPIKeyResponse struct {
+	Body *APIKeySecretDto `json:"body"`
+}
+
+type RotateAPIKeyRequest struct {
+	ID uuid.UUID `path:"id"`
+}
+
+type RotateAPIKeyResponse struct {
+	Body *APIKeySecretDto `json:"body"`
+}
+
+type RevokeAPIKeyRequest struct {
+	ID uuid.UUID `path:"id"`
+}
+
+type RevokeAPIKeyResponse struct {
+	Body *APIKeyDto `json:"body"`
+}
@@ -6,22 +6,27 @@ import (
 )
 
 type ArtistDto struct {
-	ID       uuid.UUID `json:"id"`
-	Title    string    `json:"title"`
-	SubTitle *string   `json:"sub_title"`
-	Bio      *string   `json:"bio"`
+	ID             uuid.UUID `json:"id"`
+	OrganizationID uuid.UUID `json:"organization_id"`
+	Title          string    `json:"title"`
+	SubTitle       *string   `json:"sub_title"`
+	Bio            *string   `json:"bio"`
+	Version        int32     `json:"version"`
 }
 
 func NewArtistDtoFromEntity(entity *entities.ArtistEntity) *ArtistDto {
 	return &ArtistDto{
-		ID:       entity.ID,
-		Title:    entity.Title,
-		SubTitle: entity.SubTitle,
-		Bio:      entity.Bio,
+		ID:             entity.ID,
+		OrganizationID: entity.OrganizationID,
+		Title:          entity.Title,
+		SubTitle:       entity.SubTitle,
+		Bio:            entity.Bio,
+		Version:        entity.Version,
 	}
 }
 
 type GetArtistByIDResponse struct {
+	ETag string     `header:"ETag"`
 	Body *ArtistDto `json:"body"`
 }
 
@@ -34,7 +39,8 @@ type GetAllArtistsResponse struct {
 }
 
 type CreateArtistRequest struct {
-	Body struct {
+	OrganizationID uuid.UUID `path:"organization_id"`
+	Body           struct {
 		Title    string  `json:"title"`
 		SubTitle *string `json:"sub_title"`
 		Bio      *string `json:"bio"`
@@ -42,12 +48,14 @@ type CreateArtistRequest struct {
 }
 
 type CreateArtistResponse struct {
+	ETag string     `header:"ETag"`
 	Body *ArtistDto `json:"body"`
 }
 
 type UpdateArtistRequest struct {
-	ID   uuid.UUID `path:"id"`
-	Body struct {
+	ID      uuid.UUID `path:"id"`
+	IfMatch string    `header:"If-Match" doc:"ETag of the version last read; the request fails with 412 if it has changed since"`
+	Body    struct {
 		Title    string  `json:"title"`
 		SubTitle *string `json:"sub_title"`
 		Bio      *string `json:"bio"`
@@ -55,11 +63,13 @@ type UpdateArtistRequest struct {
 }
 
 type UpdateArtistResponse struct {
+	ETag string     `header:"ETag"`
 	Body *ArtistDto `json:"body"`
 }
 
 type DeleteArtistRequest struct {
-	ID uuid.UUID `path:"id"`
+	ID      uuid.UUID `path:"id"`
+	IfMatch string    `header:"If-Match" doc:"ETag of the version last read; the request fails with 412 if it has changed since"`
 }
 
 type DeleteArtistResponse struct {
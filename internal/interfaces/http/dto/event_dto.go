@@ -8,66 +8,184 @@ import (
 )
 
 type TimeslotDto struct {
-	ID          uuid.UUID  `json:"id"`
-	SongCount   int32      `json:"song_count"`
-	Artist      *ArtistDto `json:"artist"`
-	TimeDisplay string     `json:"time_display"`
+	ID                      uuid.UUID  `json:"id"`
+	ItemType                string     `json:"item_type"`
+	SongCount               int32      `json:"song_count"`
+	Artist                  *ArtistDto `json:"artist"`
+	NameOverride            *string    `json:"name_override"`
+	Notes                   *string    `json:"notes"`
+	DurationMinutes         int32      `json:"duration_minutes"`
+	DurationOverrideMinutes *int32     `json:"duration_override_minutes"`
+	TimeDisplay             string     `json:"time_display"`
+	PlannedTime             string     `json:"planned_time"`
+	StartedAt               *string    `json:"started_at"`
+	EndedAt                 *string    `json:"ended_at"`
+	ActualDurationSeconds   *int64     `json:"actual_duration_seconds"`
+	DriftSeconds            int64      `json:"drift_seconds"`
+	Version                 int32      `json:"version"`
+}
+
+type TimeSlotRunDto struct {
+	ID              uuid.UUID `json:"id"`
+	TimeSlotID      uuid.UUID `json:"timeslot_id"`
+	StartedAt       string    `json:"started_at"`
+	EndedAt         *string   `json:"ended_at"`
+	DurationSeconds *int64    `json:"duration_seconds"`
+}
+
+type SlotPolicyDto struct {
+	MinutesPerSong    int32 `json:"minutes_per_song" minimum:"0"`
+	BaseMinutes       int32 `json:"base_minutes" minimum:"0"`
+	ChangeoverMinutes int32 `json:"changeover_minutes" minimum:"0"`
+}
+
+func NewSlotPolicyDtoFromEntity(policy entities.SlotDurationPolicy) *SlotPolicyDto {
+	return &SlotPolicyDto{
+		MinutesPerSong:    policy.MinutesPerSong,
+		BaseMinutes:       policy.BaseMinutes,
+		ChangeoverMinutes: policy.ChangeoverMinutes,
+	}
+}
+
+func (dto *SlotPolicyDto) ToEntity() *entities.SlotDurationPolicy {
+	if dto == nil {
+		return nil
+	}
+
+	return &entities.SlotDurationPolicy{
+		MinutesPerSong:    dto.MinutesPerSong,
+		BaseMinutes:       dto.BaseMinutes,
+		ChangeoverMinutes: dto.ChangeoverMinutes,
+	}
 }
 
 type TimesMarkerDto struct {
-	ID        uuid.UUID `json:"id"`
-	Display   string    `json:"display"`
-	Type      string    `json:"type"`
-	SlotIndex int       `json:"slot_index"`
+	ID         uuid.UUID `json:"id"`
+	Type       string    `json:"type" enum:"TIME,PLAYING"`
+	TimeSlotID uuid.UUID `json:"timeslot_id"`
+	SlotIndex  int       `json:"slot_index"`
+	Time       *string   `json:"time,omitempty"`
 }
 
 type EventDto struct {
-	ID        uuid.UUID         `json:"id"`
-	StartTime string            `json:"start_time"`
-	EndTime   string            `json:"end_time"`
-	IsCurrent bool              `json:"is_current"`
-	EventType string            `json:"event_type"`
-	TimeSlots []*TimeslotDto    `json:"time_slots"`
-	Markers   []*TimesMarkerDto `json:"time_markers"`
+	ID                    uuid.UUID         `json:"id"`
+	VenueID               uuid.UUID         `json:"venue_id"`
+	StartTime             string            `json:"start_time"`
+	EndTime               string            `json:"end_time"`
+	IsCurrent             bool              `json:"is_current"`
+	EventType             string            `json:"event_type"`
+	SlotPolicy            *SlotPolicyDto    `json:"slot_policy"`
+	AllowDuplicateArtists bool              `json:"allow_duplicate_artists"`
+	TimeSlots             []*TimeslotDto    `json:"time_slots"`
+	Markers               []*TimesMarkerDto `json:"time_markers"`
+	OnDeck                []*TimeslotDto    `json:"on_deck" doc:"Next artist sets to go on, for the venue screen"`
+	RunLog                []*TimeSlotRunDto `json:"run_log"`
+	DriftSeconds          int64             `json:"drift_seconds"`
+	Version               int32             `json:"version"`
 }
 
 func NewEventDtoFromEntity(entity *entities.EventEntity) *EventDto {
 
 	timeslotDtos := make([]*TimeslotDto, 0)
+	timeslotDtoByID := make(map[uuid.UUID]*TimeslotDto)
 	for _, timeslot := range entity.TimeSlots() {
-		timeslotDtos = append(timeslotDtos, &TimeslotDto{
-			ID:          timeslot.ID,
-			SongCount:   timeslot.SongCount,
-			TimeDisplay: timeslot.TimeDisplay.Format(time.RFC1123Z),
-			Artist:      NewArtistDtoFromEntity(timeslot.Artist),
-		})
+		var artistDto *ArtistDto
+		if timeslot.Artist != nil {
+			artistDto = NewArtistDtoFromEntity(timeslot.Artist)
+		}
+
+		timeslotDto := &TimeslotDto{
+			ID:                      timeslot.ID,
+			ItemType:                string(timeslot.ItemType),
+			SongCount:               timeslot.SongCount,
+			DurationMinutes:         int32(timeslot.Duration / time.Minute),
+			DurationOverrideMinutes: timeslot.DurationOverrideMinutes,
+			TimeDisplay:             timeslot.TimeDisplay.Format(time.RFC1123Z),
+			PlannedTime:             timeslot.PlannedTime.Format(time.RFC1123Z),
+			StartedAt:               formatOptionalTime(timeslot.StartedAt),
+			EndedAt:                 formatOptionalTime(timeslot.EndedAt),
+			ActualDurationSeconds:   optionalSeconds(timeslot.ActualDuration()),
+			DriftSeconds:            int64(timeslot.Drift() / time.Second),
+			Artist:                  artistDto,
+			NameOverride:            timeslot.NameOverride,
+			Notes:                   timeslot.Notes,
+			Version:                 timeslot.Version,
+		}
+		timeslotDtos = append(timeslotDtos, timeslotDto)
+		timeslotDtoByID[timeslot.ID] = timeslotDto
+	}
+
+	onDeckDtos := make([]*TimeslotDto, 0, entities.OnDeckCount)
+	for _, timeslot := range entity.OnDeck(entities.OnDeckCount) {
+		onDeckDtos = append(onDeckDtos, timeslotDtoByID[timeslot.ID])
 	}
 
 	timeMarkerDtos := make([]*TimesMarkerDto, 0)
 	for _, marker := range entity.TimeMarkers() {
 		timeMarkerDtos = append(timeMarkerDtos, &TimesMarkerDto{
-			ID:        marker.ID,
-			Display:   marker.Time,
-			Type:      marker.Type,
-			SlotIndex: marker.Index,
+			ID:         marker.ID,
+			Type:       string(marker.Type),
+			TimeSlotID: marker.TimeSlotID,
+			SlotIndex:  entity.TimeSlotIndex(marker.TimeSlotID),
+			Time:       formatOptionalTime(marker.Time),
+		})
+	}
+
+	runDtos := make([]*TimeSlotRunDto, 0)
+	for _, run := range entity.RunLog() {
+		runDtos = append(runDtos, &TimeSlotRunDto{
+			ID:              run.ID,
+			TimeSlotID:      run.TimeSlotID,
+			StartedAt:       run.StartedAt.Format(time.RFC1123Z),
+			EndedAt:         formatOptionalTime(run.EndedAt),
+			DurationSeconds: optionalSeconds(run.Duration()),
 		})
 	}
 
 	return &EventDto{
-		ID:        entity.ID,
-		StartTime: entity.StartTime.Format(time.RFC1123Z),
-		EndTime:   entity.EndTime.Format(time.RFC1123Z),
-		IsCurrent: entity.IsCurrent(),
-		EventType: entity.EventType,
-		TimeSlots: timeslotDtos,
-		Markers:   timeMarkerDtos,
+		ID:                    entity.ID,
+		VenueID:               entity.VenueID,
+		StartTime:             entity.StartTime.Format(time.RFC1123Z),
+		EndTime:               entity.EndTime.Format(time.RFC1123Z),
+		IsCurrent:             entity.IsCurrent(),
+		EventType:             entity.EventType,
+		SlotPolicy:            NewSlotPolicyDtoFromEntity(entity.SlotPolicy),
+		AllowDuplicateArtists: entity.AllowDuplicateArtists,
+		TimeSlots:             timeslotDtos,
+		Markers:               timeMarkerDtos,
+		OnDeck:                onDeckDtos,
+		RunLog:                runDtos,
+		DriftSeconds:          int64(entity.Drift() / time.Second),
+		Version:               entity.Version,
+	}
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
 	}
+	formatted := t.Format(time.RFC1123Z)
+	return &formatted
+}
+
+func optionalSeconds(d time.Duration, ok bool) *int64 {
+	if !ok {
+		return nil
+	}
+	seconds := int64(d / time.Second)
+	return &seconds
 }
 
 type GetEventByIDResponse struct {
+	ETag string    `header:"ETag"`
 	Body *EventDto `json:"body"`
 }
 
+type GetCurrentEventRequest struct {
+	OrganizationID uuid.UUID `path:"organization_id"`
+	VenueID        string    `query:"venue_id" format:"uuid" doc:"Only consider events at this venue"`
+}
+
 type GetCurrentEventResponse struct {
 	Body *EventDto `json:"body"`
 }
@@ -77,30 +195,43 @@ type GetEventsResponse struct {
 }
 
 type CreateEventRequest struct {
-	Body struct {
-		StartTime time.Time `json:"start_time"`
-		EndTime   time.Time `json:"end_time"`
-		EventType string    `json:"event_type"`
+	VenueID uuid.UUID `path:"venue_id"`
+	Body    struct {
+		StartTime             time.Time      `json:"start_time"`
+		EndTime               time.Time      `json:"end_time"`
+		EventType             string         `json:"event_type"`
+		SlotPolicy            *SlotPolicyDto `json:"slot_policy,omitempty"`
+		AllowDuplicateArtists *bool          `json:"allow_duplicate_artists,omitempty" doc:"Whether an artist may appear in the lineup more than once; defaults to true"`
 	}
 }
 
 type CreateEventResponse struct {
+	ETag string    `header:"ETag"`
 	Body *EventDto `json:"body"`
 }
 
 type UpdateEventRequest struct {
-	ID   uuid.UUID `path:"id"`
-	Body struct {
-		StartTime time.Time `json:"start_time"`
-		EndTime   time.Time `json:"end_time"`
-		EventType string    `json:"event_type"`
+	ID      uuid.UUID `path:"id"`
+	IfMatch string    `header:"If-Match" doc:"ETag of the version last read; the request fails with 412 if it has changed since"`
+	Body    struct {
+		StartTime             time.Time      `json:"start_time"`
+		EndTime               time.Time      `json:"end_time"`
+		EventType             string         `json:"event_type"`
+		SlotPolicy            *SlotPolicyDto `json:"slot_policy,omitempty"`
+		AllowDuplicateArtists *bool          `json:"allow_duplicate_artists,omitempty" doc:"Whether an artist may appear in the lineup more than once; left unchanged when omitted"`
 	}
 }
 
 type UpdateEventResponse struct {
+	ETag string    `header:"ETag"`
 	Body *EventDto `json:"body"`
 }
 
+type DeleteEventRequest struct {
+	ID      uuid.UUID `path:"id"`
+	IfMatch string    `header:"If-Match" doc:"ETag of the version last read; the request fails with 412 if it has changed since"`
+}
+
 type DeleteEventResponse struct {
 	Body string `json:"body"`
 }
@@ -116,6 +247,38 @@ type AddArtistToEventEventResponst struct {
 	Body *EventDto `json:"body"`
 }
 
+type WalkInRequest struct {
+	EventID uuid.UUID `path:"event_id"`
+	Body    struct {
+		Title        string  `json:"title" minLength:"1" doc:"Name the walk-in gave; an artist with this title is reused, otherwise one is created"`
+		NameOverride *string `json:"name_override,omitempty" doc:"Name to show on the lineup instead of the artist's"`
+		SongCount    *int32  `json:"song_count,omitempty" minimum:"0"`
+	}
+}
+
+type WalkInResponse struct {
+	Body struct {
+		Event         *EventDto    `json:"event"`
+		Artist        *ArtistDto   `json:"artist"`
+		ArtistCreated bool         `json:"artist_created"`
+		Candidates    []*ArtistDto `json:"candidates" doc:"Other artists with similar titles that may be the same person"`
+	} `json:"body"`
+}
+
+type AddLineupItemRequest struct {
+	EventID uuid.UUID `path:"event_id"`
+	Body    struct {
+		ItemType        string  `json:"item_type" enum:"BREAK,FEATURE_SET,HOST_BIT,RAFFLE"`
+		Title           string  `json:"title" minLength:"1"`
+		DurationMinutes int32   `json:"duration_minutes" minimum:"0"`
+		Notes           *string `json:"notes,omitempty"`
+	}
+}
+
+type AddLineupItemResponse struct {
+	Body *EventDto `json:"body"`
+}
+
 type RemoveArtistFromEventEventRequest struct {
 	EventID uuid.UUID `path:"event_id"`
 	Body    struct {
@@ -127,15 +290,33 @@ type RemoveArtistFromEventEventResponse struct {
 	Body *EventDto `json:"body"`
 }
 
+type ListenForEventChangeRequest struct {
+	ID          uuid.UUID `path:"event_id"`
+	LastEventID int64     `header:"Last-Event-ID" minimum:"0" doc:"Sequence number of the last change received, sent by the browser when it reconnects"`
+}
+
 type ListenForChangeEventResponse struct {
+	Kind string    `json:"kind"`
 	Body *EventDto `json:"body"`
 }
 
+func NewListenForChangeEventResponseFromEntity(change *entities.EventChangeEntity) ListenForChangeEventResponse {
+	response := ListenForChangeEventResponse{
+		Kind: string(change.Kind),
+	}
+
+	if change.Event != nil {
+		response.Body = NewEventDtoFromEntity(change.Event)
+	}
+
+	return response
+}
+
 type SetTimeslotMarkerRequest struct {
 	EventID uuid.UUID `path:"event_id"`
 	Body    struct {
-		TimeDisplay string `json:"time_display"`
-		SlotIndex   int    `json:"slot_index"`
+		TimeSlotID uuid.UUID `json:"timeslot_id"`
+		Time       time.Time `json:"time"`
 	}
 }
 
@@ -163,11 +344,32 @@ type SetSortOrderResponse struct {
 	Body *EventDto `json:"body"`
 }
 
+type ReorderLineupRequest struct {
+	EventID uuid.UUID `path:"event_id"`
+	Body    struct {
+		TimeSlotIDs []uuid.UUID `json:"timeslot_ids" doc:"Every timeslot of the event, in the new order"`
+	}
+}
+
+type ReorderLineupResponse struct {
+	Body *EventDto `json:"body"`
+}
+
+type RebalanceLineupRequest struct {
+	EventID uuid.UUID `path:"event_id"`
+}
+
+type RebalanceLineupResponse struct {
+	Body *EventDto `json:"body"`
+}
+
 type UpdateTimeSlotRequest struct {
 	EventID    uuid.UUID `path:"event_id"`
 	TimeSlotID uuid.UUID `path:"timeslot_id"`
+	IfMatch    string    `header:"If-Match" doc:"ETag of the timeslot version last read; the request fails with 412 if it has changed since"`
 	Body       struct {
-		SongCount int32 `json:"song_count"`
+		SongCount               int32  `json:"song_count"`
+		DurationOverrideMinutes *int32 `json:"duration_override_minutes,omitempty" minimum:"0" doc:"Minutes the slot runs regardless of song count; left unchanged when omitted, 0 clears it"`
 	}
 }
 
@@ -175,13 +377,46 @@ type UpdateTimeSlotResponse struct {
 	Body *EventDto `json:"body"`
 }
 
+type PatchTimeSlotRequest struct {
+	EventID    uuid.UUID `path:"event_id"`
+	TimeSlotID uuid.UUID `path:"timeslot_id"`
+	IfMatch    string    `header:"If-Match" doc:"ETag of the timeslot version last read; the request fails with 412 if it has changed since"`
+	Body       struct {
+		NameOverride *string `json:"name_override,omitempty" doc:"Name to show instead of the artist's; an empty string clears it"`
+		SongCount    *int32  `json:"song_count,omitempty" minimum:"0"`
+		Notes        *string `json:"notes,omitempty" doc:"Notes for the host; an empty string clears them"`
+	}
+}
+
+type PatchTimeSlotResponse struct {
+	Body *EventDto `json:"body"`
+}
+
+type DeleteTimeSlotRequest struct {
+	EventID    uuid.UUID `path:"event_id"`
+	TimeSlotID uuid.UUID `path:"timeslot_id"`
+	IfMatch    string    `header:"If-Match" doc:"ETag of the timeslot version last read; the request fails with 412 if it has changed since"`
+}
+
+type DeleteTimeSlotResponse struct {
+	Body *EventDto `json:"body"`
+}
+
 type SetNowPlayingRequest struct {
 	EventID uuid.UUID `path:"event_id"`
 	Body    struct {
-		Index int `json:"index"`
+		TimeSlotID uuid.UUID `json:"timeslot_id" doc:"Timeslot now on stage; sending the one already playing clears it"`
 	}
 }
 
 type SetNowPlayingResponse struct {
 	Body *EventDto `json:"body"`
 }
+
+type ShowControlRequest struct {
+	EventID uuid.UUID `path:"event_id"`
+}
+
+type ShowControlResponse struct {
+	Body *EventDto `json:"body"`
+}
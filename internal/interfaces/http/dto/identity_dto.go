@@ -0,0 +1,60 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+)
+
+type IdentityDto struct {
+	ID        uuid.UUID `json:"id"`
+	Provider  string    `json:"provider"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewIdentityDtoFromEntity(entity *entities.IdentityEntity) *IdentityDto {
+	return &IdentityDto{
+		ID:        entity.ID,
+		Provider:  entity.Provider,
+		Email:     entity.Email,
+		CreatedAt: entity.CreatedAt,
+	}
+}
+
+type GetLoginProvidersResponse struct {
+	Body struct {
+		Providers []string `json:"providers"`
+	} `json:"body"`
+}
+
+type StartExternalLoginRequest struct {
+	Provider string `path:"provider"`
+}
+
+type StartExternalLoginResponse struct {
+	Body struct {
+		AuthorizationURL string `json:"authorization_url"`
+		Binding          string `json:"binding" doc:"Keep in the browser that started the login and send it back with the callback"`
+	} `json:"body"`
+}
+
+type CompleteExternalLoginRequest struct {
+	Body struct {
+		State   string `json:"state" minLength:"1"`
+		Code    string `json:"code" minLength:"1"`
+		Binding string `json:"binding" minLength:"1" doc:"The binding returned when the login was started"`
+	}
+}
+
+type CompleteExternalLoginResponse struct {
+	Body struct {
+		User       *UserDto    `json:"user"`
+		SessionDto *SessionDto `json:"session"`
+	} `json:"body"`
+}
+
+type GetIdentitiesResponse struct {
+	Body []*IdentityDto `json:"body"`
+}
@@ -0,0 +1,189 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+)
+
+type OrganizationDto struct {
+	ID     uuid.UUID `json:"id"`
+	Name   string    `json:"name"`
+	Handle string    `json:"handle"`
+}
+
+func NewOrganizationDtoFromEntity(entity *entities.OrganizationEntity) *OrganizationDto {
+	return &OrganizationDto{
+		ID:     entity.ID,
+		Name:   entity.Name,
+		Handle: entity.Handle,
+	}
+}
+
+type CurrentWindowDto struct {
+	MinutesBefore int32 `json:"minutes_before" minimum:"0" doc:"How long before its start an event counts as current"`
+	MinutesAfter  int32 `json:"minutes_after" minimum:"0" doc:"How long after its start an event counts as current"`
+}
+
+func NewCurrentWindowDtoFromEntity(window *entities.CurrentEventWindow) *CurrentWindowDto {
+	if window == nil {
+		return nil
+	}
+
+	return &CurrentWindowDto{
+		MinutesBefore: window.MinutesBefore,
+		MinutesAfter:  window.MinutesAfter,
+	}
+}
+
+func (dto *CurrentWindowDto) ToEntity() *entities.CurrentEventWindow {
+	if dto == nil {
+		return nil
+	}
+
+	return &entities.CurrentEventWindow{
+		MinutesBefore: dto.MinutesBefore,
+		MinutesAfter:  dto.MinutesAfter,
+	}
+}
+
+type VenueDto struct {
+	ID             uuid.UUID         `json:"id"`
+	OrganizationID uuid.UUID         `json:"organization_id"`
+	Name           string            `json:"name"`
+	Address        *string           `json:"address"`
+	CurrentWindow  *CurrentWindowDto `json:"current_window"`
+}
+
+func NewVenueDtoFromEntity(entity *entities.VenueEntity) *VenueDto {
+	return &VenueDto{
+		ID:             entity.ID,
+		OrganizationID: entity.OrganizationID,
+		Name:           entity.Name,
+		Address:        entity.Address,
+		CurrentWindow:  NewCurrentWindowDtoFromEntity(entity.CurrentWindow),
+	}
+}
+
+type EventTypeWindowDto struct {
+	EventType     string            `json:"event_type"`
+	CurrentWindow *CurrentWindowDto `json:"current_window"`
+}
+
+func NewEventTypeWindowDtoFromEntity(entity *entities.EventTypeWindowEntity) *EventTypeWindowDto {
+	return &EventTypeWindowDto{
+		EventType:     entity.EventType,
+		CurrentWindow: NewCurrentWindowDtoFromEntity(&entity.Window),
+	}
+}
+
+type GetOrganizationByIDResponse struct {
+	Body *OrganizationDto `json:"body"`
+}
+
+type GetMyOrganizationsResponse struct {
+	Body []*OrganizationDto `json:"body"`
+}
+
+type CreateOrganizationRequest struct {
+	Body struct {
+		Name   string `json:"name" minLength:"1"`
+		Handle string `json:"handle" minLength:"1"`
+	}
+}
+
+type CreateOrganizationResponse struct {
+	Body *OrganizationDto `json:"body"`
+}
+
+type UpdateOrganizationRequest struct {
+	OrganizationID uuid.UUID `path:"organization_id"`
+	Body           struct {
+		Name   string `json:"name" minLength:"1"`
+		Handle string `json:"handle" minLength:"1"`
+	}
+}
+
+type UpdateOrganizationResponse struct {
+	Body *OrganizationDto `json:"body"`
+}
+
+type GetOrganizationMembersResponse struct {
+	Body []*UserDto `json:"body"`
+}
+
+type AddOrganizationMemberRequest struct {
+	OrganizationID uuid.UUID `path:"organization_id"`
+	Body           struct {
+		UserID uuid.UUID `json:"user_id"`
+	}
+}
+
+type AddOrganizationMemberResponse struct{}
+
+type RemoveOrganizationMemberRequest struct {
+	OrganizationID uuid.UUID `path:"organization_id"`
+	UserID         uuid.UUID `path:"user_id"`
+}
+
+type RemoveOrganizationMemberResponse struct{}
+
+type GetVenueByIDResponse struct {
+	Body *VenueDto `json:"body"`
+}
+
+type GetVenuesResponse struct {
+	Body []*VenueDto `json:"body"`
+}
+
+type CreateVenueRequest struct {
+	OrganizationID uuid.UUID `path:"organization_id"`
+	Body           struct {
+		Name          string            `json:"name" minLength:"1"`
+		Address       *string           `json:"address,omitempty"`
+		CurrentWindow *CurrentWindowDto `json:"current_window,omitempty" doc:"Overrides the organization's window for every event at this venue"`
+	}
+}
+
+type CreateVenueResponse struct {
+	Body *VenueDto `json:"body"`
+}
+
+type UpdateVenueRequest struct {
+	VenueID uuid.UUID `path:"venue_id"`
+	Body    struct {
+		Name          string            `json:"name" minLength:"1"`
+		Address       *string           `json:"address,omitempty"`
+		CurrentWindow *CurrentWindowDto `json:"current_window,omitempty" doc:"Overrides the organization's window for every event at this venue"`
+	}
+}
+
+type UpdateVenueResponse struct {
+	Body *VenueDto `json:"body"`
+}
+
+type DeleteVenueRequest struct {
+	VenueID uuid.UUID `path:"venue_id"`
+}
+
+type DeleteVenueResponse struct{}
+
+type GetEventTypeWindowsResponse struct {
+	Body []*EventTypeWindowDto `json:"body"`
+}
+
+type SetEventTypeWindowRequest struct {
+	OrganizationID uuid.UUID `path:"organization_id"`
+	EventType      string    `path:"event_type"`
+	Body           CurrentWindowDto
+}
+
+type SetEventTypeWindowResponse struct {
+	Body *EventTypeWindowDto `json:"body"`
+}
+
+type DeleteEventTypeWindowRequest struct {
+	OrganizationID uuid.UUID `path:"organization_id"`
+	EventType      string    `path:"event_type"`
+}
+
+type DeleteEventTypeWindowResponse struct{}
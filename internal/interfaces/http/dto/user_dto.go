@@ -12,6 +12,8 @@ type UserDto struct {
 	GivenName  *string   `json:"given_name"`
 	FamilyName *string   `json:"family_name"`
 	Email      string    `json:"email"`
+	Role       string    `json:"role"`
+	Version    int32     `json:"version"`
 }
 
 func NewUserDtoFromEntity(entity *entities.UserEntity) *UserDto {
@@ -20,6 +22,8 @@ func NewUserDtoFromEntity(entity *entities.UserEntity) *UserDto {
 		GivenName:  entity.GivenName,
 		FamilyName: entity.FamilyName,
 		Email:      entity.Email,
+		Role:       string(entity.Role),
+		Version:    entity.Version,
 	}
 }
 
@@ -28,10 +32,48 @@ type SessionDto struct {
 	ExpiresAt time.Time `json:"expires_at"`
 }
 
+func NewSessionDtoFromEntity(entity *entities.UserContextEntity) *SessionDto {
+	return &SessionDto{
+		Token:     entity.SessionToken,
+		ExpiresAt: entity.ExpiresAt(),
+	}
+}
+
+type ActiveSessionDto struct {
+	ID             uuid.UUID  `json:"id"`
+	ImpersonatorID *uuid.UUID `json:"impersonator_id,omitempty"`
+	IPAddress      *string    `json:"ip_address"`
+	CreatedAt      *time.Time `json:"created_at"`
+	LastSeenAt     time.Time  `json:"last_seen_at"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	Current        bool       `json:"current"`
+}
+
+func NewActiveSessionDtoFromEntity(entity *entities.SessionEntity, currentSessionID uuid.UUID) *ActiveSessionDto {
+	return &ActiveSessionDto{
+		ID:             entity.ID,
+		ImpersonatorID: entity.ImpersonatorID,
+		IPAddress:      entity.IPAddress,
+		CreatedAt:      entity.CreatedAt,
+		LastSeenAt:     entity.LastSeenAt,
+		ExpiresAt:      entity.ExpiresAt,
+		Current:        entity.ID == currentSessionID,
+	}
+}
+
 type GetUserByIDResponse struct {
+	ETag string   `header:"ETag"`
 	Body *UserDto `json:"body"`
 }
 
+type GetCurrentUserResponse struct {
+	Body struct {
+		User           *UserDto    `json:"user"`
+		SessionDto     *SessionDto `json:"session"`
+		ImpersonatedBy *UserDto    `json:"impersonated_by,omitempty"`
+	} `json:"body"`
+}
+
 type GetUserByEmailResponse struct {
 	Body *UserDto `json:"body"`
 }
@@ -46,14 +88,14 @@ type CreateUserRequest struct {
 
 type CreateUserResponse struct {
 	Body struct {
-		User       *UserDto    `json:"user"`
-		SessionDto *SessionDto `json:"session"`
+		User *UserDto `json:"user"`
 	} `json:"body"`
 }
 
 type UpdateUserRequest struct {
-	ID   uuid.UUID `path:"id"`
-	Body struct {
+	ID      uuid.UUID `path:"id"`
+	IfMatch string    `header:"If-Match" doc:"ETag of the version last read; the request fails with 412 if it has changed since"`
+	Body    struct {
 		Email      string  `json:"email"`
 		GivenName  *string `json:"given_name"`
 		FamilyName *string `json:"family_name"`
@@ -62,7 +104,92 @@ type UpdateUserRequest struct {
 }
 
 type UpdateUserResponse struct {
+	ETag string `header:"ETag"`
 	Body struct {
 		User *UserDto `json:"user"`
 	} `json:"body"`
 }
+
+type RequestEmailLoginRequest struct {
+	Body struct {
+		Email string `json:"email" format:"email"`
+	}
+}
+
+type RequestEmailLoginResponse struct{}
+
+type LoginWithTokenRequest struct {
+	Body struct {
+		Token string `json:"token" minLength:"1"`
+	}
+}
+
+type LoginWithTokenResponse struct {
+	Body struct {
+		User       *UserDto    `json:"user"`
+		SessionDto *SessionDto `json:"session"`
+	} `json:"body"`
+}
+
+type InviteUserRequest struct {
+	Body struct {
+		Email      string  `json:"email" format:"email"`
+		GivenName  *string `json:"given_name,omitempty"`
+		FamilyName *string `json:"family_name,omitempty"`
+	}
+}
+
+type InviteUserResponse struct{}
+
+type AcceptInviteRequest struct {
+	Body struct {
+		Token string `json:"token" minLength:"1"`
+	}
+}
+
+type AcceptInviteResponse struct {
+	Body struct {
+		User       *UserDto    `json:"user"`
+		SessionDto *SessionDto `json:"session"`
+	} `json:"body"`
+}
+
+type SetUserRoleRequest struct {
+	ID   uuid.UUID `path:"id"`
+	Body struct {
+		Role string `json:"role" enum:"admin,host,performer,audience"`
+	}
+}
+
+type SetUserRoleResponse struct {
+	Body struct {
+		User *UserDto `json:"user"`
+	} `json:"body"`
+}
+
+type LogoutResponse struct{}
+
+type LogoutEverywhereResponse struct{}
+
+type GetSessionsResponse struct {
+	Body []*ActiveSessionDto `json:"body"`
+}
+
+type RevokeSessionRequest struct {
+	SessionID uuid.UUID `path:"session_id"`
+}
+
+type RevokeSessionResponse struct{}
+
+type StartImpersonationRequest struct {
+	ID uuid.UUID `path:"id"`
+}
+
+type StartImpersonationResponse struct {
+	Body struct {
+		User       *UserDto    `json:"user"`
+		SessionDto *SessionDto `json:"session"`
+	} `json:"body"`
+}
+
+type EndImpersonationResponse struct{}
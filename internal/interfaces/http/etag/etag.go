@@ -0,0 +1,39 @@
+// Package etag maps row versions to HTTP entity tags so clients can make
+// their writes conditional with If-Match.
+package etag
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+)
+
+// Format is the strong entity tag for a row version.
+func Format(version int32) string {
+	return strconv.Quote(strconv.FormatInt(int64(version), 10))
+}
+
+// Parse reads the version out of an If-Match header. An empty header or "*"
+// asks for no particular version and returns nil. Anything that is not a tag
+// from Format, weak tags included, can never match, so it fails the
+// precondition.
+func Parse(ifMatch string) (*int32, error) {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" || ifMatch == "*" {
+		return nil, nil
+	}
+
+	unquoted, err := strconv.Unquote(ifMatch)
+	if err != nil || !strings.HasPrefix(ifMatch, `"`) {
+		return nil, entities.ErrVersionMismatch
+	}
+
+	version, err := strconv.ParseInt(unquoted, 10, 32)
+	if err != nil {
+		return nil, entities.ErrVersionMismatch
+	}
+
+	expected := int32(version)
+	return &expected, nil
+}
@@ -0,0 +1,40 @@
+package etag
+
+import (
+	"testing"
+
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, `"7"`, Format(7))
+}
+
+func TestParse(t *testing.T) {
+	t.Run("round trip", func(t *testing.T) {
+		version, err := Parse(Format(12))
+
+		require.NoError(t, err)
+		require.NotNil(t, version)
+		assert.Equal(t, int32(12), *version)
+	})
+
+	t.Run("no header or any version", func(t *testing.T) {
+		for _, header := range []string{"", "*", " * "} {
+			version, err := Parse(header)
+
+			assert.NoError(t, err)
+			assert.Nil(t, version)
+		}
+	})
+
+	t.Run("tags that can never match", func(t *testing.T) {
+		for _, header := range []string{`W/"3"`, "3", `"abc"`, `"3", "4"`, `"99999999999"`} {
+			_, err := Parse(header)
+
+			assert.ErrorIs(t, err, entities.ErrVersionMismatch, header)
+		}
+	})
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/application"
+	"github.com/mcorrigan89/openmic/internal/application/commands"
+	"github.com/mcorrigan89/openmic/internal/application/queries"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/dto"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/middleware"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/problem"
+	"github.com/rs/zerolog"
+)
+
+type APIKeyHandler struct {
+	logger           *zerolog.Logger
+	apiKeyAppService application.APIKeyApplicationService
+}
+
+func NewAPIKeyHandler(logger *zerolog.Logger, apiKeyAppService application.APIKeyApplicationService) *APIKeyHandler {
+	return &APIKeyHandler{
+		logger:           logger,
+		apiKeyAppService: apiKeyAppService,
+	}
+}
+
+// apiKeyIssuer is the key the request is made with, or nil when a user
+// session authorized it.
+func apiKeyIssuer(ctx context.Context) *entities.APIKeyEntity {
+	if userContext := middleware.GetUserFromContext(ctx); userContext != nil && !userContext.IsExpired() {
+		return nil
+	}
+
+	return middleware.GetAPIKeyFromContext(ctx)
+}
+
+func (h *APIKeyHandler) GetAPIKeys(ctx context.Context, input *struct{}) (*dto.GetAPIKeysResponse, error) {
+
+	apiKeys, err := h.apiKeyAppService.GetAPIKeys(ctx, queries.APIKeysQuery{
+		Issuer: apiKeyIssuer(ctx),
+	})
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to get API keys")
+	}
+
+	apiKeyDtos := make([]*dto.APIKeyDto, 0, len(apiKeys))
+	for _, apiKey := range apiKeys {
+		apiKeyDtos = append(apiKeyDtos, dto.NewAPIKeyDtoFromEntity(apiKey))
+	}
+
+	return &dto.GetAPIKeysResponse{
+		Body: apiKeyDtos,
+	}, nil
+}
+
+func (h *APIKeyHandler) CreateAPIKey(ctx context.Context, input *dto.CreateAPIKeyRequest) (*dto.CreateAPIKeyResponse, error) {
+
+	scopes := make([]entities.APIKeyScope, 0, len(input.Body.Scopes))
+	for _, value := range input.Body.Scopes {
+		scope, err := entities.ParseAPIKeyScope(value)
+		if err != nil {
+			return nil, problem.FromError(err, "Failed to create API key")
+		}
+		scopes = append(scopes, scope)
+	}
+
+	// Keys created with the bootstrap key have no user behind them.
+	var createdBy *uuid.UUID
+	if userContext := middleware.GetUserFromContext(ctx); userContext != nil {
+		createdBy = &userContext.UserID
+	}
+
+	cmd := commands.CreateAPIKeyCommand{
+		Name:               input.Body.Name,
+		Scopes:             scopes,
+		OrganizationID:     input.Body.OrganizationID,
+		RateLimitPerMinute: input.Body.RateLimitPerMinute,
+		CreatedBy:          createdBy,
+		Issuer:             apiKeyIssuer(ctx),
+	}
+
+	apiKey, err := h.apiKeyAppService.CreateAPIKey(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to create API key")
+	}
+
+	return &dto.CreateAPIKeyResponse{
+		Body: dto.NewAPIKeySecretDtoFromEntity(apiKey),
+	}, nil
+}
+
+func (h *APIKeyHandler) RotateAPIKey(ctx context.Context, input *dto.RotateAPIKeyRequest) (*dto.RotateAPIKeyResponse, error) {
+
+	cmd := commands.RotateAPIKeyCommand{
+		ID:     input.ID,
+		Issuer: apiKeyIssuer(ctx),
+	}
+
+	apiKey, err := h.apiKeyAppService.RotateAPIKey(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to rotate API key")
+	}
+
+	return &dto.RotateAPIKeyResponse{
+		Body: dto.NewAPIKeySecretDtoFromEntity(apiKey),
+	}, nil
+}
+
+func (h *APIKeyHandler) RevokeAPIKey(ctx context.Context, input *dto.RevokeAPIKeyRequest) (*dto.RevokeAPIKeyResponse, error) {
+
+	cmd := commands.RevokeAPIKeyCommand{
+		ID:     input.ID,
+		Issuer: apiKeyIssuer(ctx),
+	}
+
+	apiKey, err := h.apiKeyAppService.RevokeAPIKey(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to revoke API key")
+	}
+
+	return &dto.RevokeAPIKeyResponse{
+		Body: dto.NewAPIKeyDtoFromEntity(apiKey),
+	}, nil
+}
@@ -8,7 +8,11 @@ import (
 	"github.com/mcorrigan89/openmic/internal/application"
 	"github.com/mcorrigan89/openmic/internal/application/commands"
 	"github.com/mcorrigan89/openmic/internal/application/queries"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
 	"github.com/mcorrigan89/openmic/internal/interfaces/http/dto"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/etag"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/middleware"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/problem"
 	"github.com/rs/zerolog"
 )
 
@@ -34,27 +38,30 @@ func (h *ArtistHandler) GetArtistByID(ctx context.Context, input *struct {
 
 	artist, err := h.artistAppService.GetArtistByID(ctx, query)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to get artist by ID", err)
+		return nil, problem.FromError(err, "Failed to get artist by ID")
 	}
 
 	artistDto := dto.NewArtistDtoFromEntity(artist)
 
 	return &dto.GetArtistByIDResponse{
+		ETag: etag.Format(artist.Version),
 		Body: artistDto,
 	}, nil
 }
 
 func (h *ArtistHandler) GetArtistsByTitle(ctx context.Context, input *struct {
-	Title string `query:"title"`
+	OrganizationID uuid.UUID `path:"organization_id"`
+	Title          string    `query:"title"`
 }) (*dto.GetArtistsByTitleResponse, error) {
 
 	query := queries.ArtistsByTitleQuery{
-		Title: input.Title,
+		OrganizationID: input.OrganizationID,
+		Title:          input.Title,
 	}
 
 	artists, err := h.artistAppService.GetArtistsByTitle(ctx, query)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to get artist by title", err)
+		return nil, problem.FromError(err, "Failed to get artist by title")
 	}
 
 	artistDtos := make([]*dto.ArtistDto, 0, len(artists))
@@ -68,11 +75,16 @@ func (h *ArtistHandler) GetArtistsByTitle(ctx context.Context, input *struct {
 }
 
 func (h *ArtistHandler) GetAllArtists(ctx context.Context, input *struct {
+	OrganizationID uuid.UUID `path:"organization_id"`
 }) (*dto.GetAllArtistsResponse, error) {
 
-	artists, err := h.artistAppService.GetAllArtists(ctx)
+	query := queries.ArtistsQuery{
+		OrganizationID: input.OrganizationID,
+	}
+
+	artists, err := h.artistAppService.GetAllArtists(ctx, query)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to get all artists", err)
+		return nil, problem.FromError(err, "Failed to get all artists")
 	}
 
 	artistDtos := make([]*dto.ArtistDto, 0, len(artists))
@@ -88,53 +100,101 @@ func (h *ArtistHandler) GetAllArtists(ctx context.Context, input *struct {
 func (h *ArtistHandler) CreateArtist(ctx context.Context, input *dto.CreateArtistRequest) (*dto.CreateArtistResponse, error) {
 
 	cmd := commands.CreateNewArtistCommand{
-		Title:    input.Body.Title,
-		SubTitle: input.Body.SubTitle,
-		Bio:      input.Body.Bio,
+		OrganizationID: input.OrganizationID,
+		Title:          input.Body.Title,
+		SubTitle:       input.Body.SubTitle,
+		Bio:            input.Body.Bio,
+	}
+
+	// Performers create their own artist. Hosts create artists on behalf of
+	// others, which stay unlinked.
+	userContext := middleware.GetUserFromContext(ctx)
+	if !userContext.HasRole(entities.RoleHost) {
+		cmd.UserID = &userContext.UserID
 	}
 
 	artist, err := h.artistAppService.CreateArtist(ctx, cmd)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to create artist", err)
+		return nil, problem.FromError(err, "Failed to create artist")
 	}
 
 	artistDto := dto.NewArtistDtoFromEntity(artist)
 
 	return &dto.CreateArtistResponse{
+		ETag: etag.Format(artist.Version),
 		Body: artistDto,
 	}, nil
 }
 
+// authorizeArtistEdit checks that the current user may edit the artist.
+func (h *ArtistHandler) authorizeArtistEdit(ctx context.Context, artistID uuid.UUID) error {
+	artist, err := h.artistAppService.GetArtistByID(ctx, queries.ArtistByIDQuery{
+		ID: artistID,
+	})
+	if err != nil {
+		return problem.FromError(err, "Failed to get artist by ID")
+	}
+
+	if !middleware.GetUserFromContext(ctx).CanEditArtist(artist) {
+		return huma.Error403Forbidden("Forbidden")
+	}
+
+	return nil
+}
+
 func (h *ArtistHandler) UpdateArtist(ctx context.Context, input *dto.UpdateArtistRequest) (*dto.UpdateArtistResponse, error) {
 
+	err := h.authorizeArtistEdit(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	expectedVersion, err := etag.Parse(input.IfMatch)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to update artist")
+	}
+
 	cmd := commands.UpdateArtistCommand{
-		ID:       input.ID,
-		Title:    input.Body.Title,
-		SubTitle: input.Body.SubTitle,
-		Bio:      input.Body.Bio,
+		ID:              input.ID,
+		Title:           input.Body.Title,
+		SubTitle:        input.Body.SubTitle,
+		Bio:             input.Body.Bio,
+		ExpectedVersion: expectedVersion,
 	}
 
 	artist, err := h.artistAppService.UpdateArtist(ctx, cmd)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to update artist", err)
+		return nil, problem.FromError(err, "Failed to update artist")
 	}
 
 	artistDto := dto.NewArtistDtoFromEntity(artist)
 
 	return &dto.UpdateArtistResponse{
+		ETag: etag.Format(artist.Version),
 		Body: artistDto,
 	}, nil
 }
 
 func (h *ArtistHandler) DeleteArtist(ctx context.Context, input *dto.DeleteArtistRequest) (*dto.DeleteArtistResponse, error) {
 
+	err := h.authorizeArtistEdit(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	expectedVersion, err := etag.Parse(input.IfMatch)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to delete artist")
+	}
+
 	cmd := commands.DeleteArtistCommand{
-		ID: input.ID,
+		ID:              input.ID,
+		ExpectedVersion: expectedVersion,
 	}
 
-	err := h.artistAppService.DeleteArtist(ctx, cmd)
+	err = h.artistAppService.DeleteArtist(ctx, cmd)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to delete artist", err)
+		return nil, problem.FromError(err, "Failed to delete artist")
 	}
 
 	return &dto.DeleteArtistResponse{}, nil
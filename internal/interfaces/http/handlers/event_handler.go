@@ -2,7 +2,7 @@ package handlers
 
 import (
 	"context"
-	"sync"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/sse"
@@ -10,10 +10,20 @@ import (
 	"github.com/mcorrigan89/openmic/internal/application"
 	"github.com/mcorrigan89/openmic/internal/application/commands"
 	"github.com/mcorrigan89/openmic/internal/application/queries"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
 	"github.com/mcorrigan89/openmic/internal/interfaces/http/dto"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/etag"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/problem"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/stream"
 	"github.com/rs/zerolog"
 )
 
+const sseHeartbeatInterval = 15 * time.Second
+
+// sseProjectionInterval is how often subscribers get fresh projected times
+// while a timeslot is on stage.
+const sseProjectionInterval = time.Minute
+
 type EventHandler struct {
 	logger          *zerolog.Logger
 	eventAppService application.EventApplicationService
@@ -36,22 +46,33 @@ func (h *EventHandler) GetEventByID(ctx context.Context, input *struct {
 
 	event, err := h.eventAppService.GetEventByID(ctx, query)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to get event by ID", err)
+		return nil, problem.FromError(err, "Failed to get event by ID")
 	}
 
 	eventDto := dto.NewEventDtoFromEntity(event)
 
 	return &dto.GetEventByIDResponse{
+		ETag: etag.Format(event.Version),
 		Body: eventDto,
 	}, nil
 }
 
-func (h *EventHandler) GetCurrentEvent(ctx context.Context, input *struct{}) (*dto.GetCurrentEventResponse, error) {
-	query := queries.CurrentEventQuery{}
+func (h *EventHandler) GetCurrentEvent(ctx context.Context, input *dto.GetCurrentEventRequest) (*dto.GetCurrentEventResponse, error) {
+	query := queries.CurrentEventQuery{
+		OrganizationID: input.OrganizationID,
+	}
+
+	if input.VenueID != "" {
+		venueID, err := uuid.Parse(input.VenueID)
+		if err != nil {
+			return nil, huma.Error422UnprocessableEntity("Invalid venue ID")
+		}
+		query.VenueID = &venueID
+	}
 
 	event, err := h.eventAppService.GetCurrentEvent(ctx, query)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to get event by ID", err)
+		return nil, problem.FromError(err, "Failed to get current event")
 	}
 
 	if event == nil {
@@ -68,13 +89,16 @@ func (h *EventHandler) GetCurrentEvent(ctx context.Context, input *struct{}) (*d
 }
 
 func (h *EventHandler) GetUpcomingEvents(ctx context.Context, input *struct {
+	OrganizationID uuid.UUID `path:"organization_id"`
 }) (*dto.GetEventsResponse, error) {
 
-	query := queries.EventsQuery{}
+	query := queries.EventsQuery{
+		OrganizationID: input.OrganizationID,
+	}
 
 	events, err := h.eventAppService.GetEvents(ctx, query)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to get event by ID", err)
+		return nil, problem.FromError(err, "Failed to get event by ID")
 	}
 
 	eventDtos := make([]*dto.EventDto, 0)
@@ -91,55 +115,72 @@ func (h *EventHandler) GetUpcomingEvents(ctx context.Context, input *struct {
 func (h *EventHandler) CreateEvent(ctx context.Context, input *dto.CreateEventRequest) (*dto.CreateEventResponse, error) {
 
 	cmd := commands.CreateNewEventCommand{
-		StartTime: input.Body.StartTime,
-		EndTime:   input.Body.EndTime,
-		EventType: input.Body.EventType,
+		VenueID:               input.VenueID,
+		StartTime:             input.Body.StartTime,
+		EndTime:               input.Body.EndTime,
+		EventType:             input.Body.EventType,
+		SlotPolicy:            input.Body.SlotPolicy.ToEntity(),
+		AllowDuplicateArtists: input.Body.AllowDuplicateArtists,
 	}
 
 	event, err := h.eventAppService.CreateEvent(ctx, cmd)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to create event", err)
+		return nil, problem.FromError(err, "Failed to create event")
 	}
 
 	eventDto := dto.NewEventDtoFromEntity(event)
 
 	return &dto.CreateEventResponse{
+		ETag: etag.Format(event.Version),
 		Body: eventDto,
 	}, nil
 }
 
 func (h *EventHandler) UpdateEvent(ctx context.Context, input *dto.UpdateEventRequest) (*dto.UpdateEventResponse, error) {
 
+	expectedVersion, err := etag.Parse(input.IfMatch)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to update event")
+	}
+
 	cmd := commands.UpdateEventCommand{
-		ID:        input.ID,
-		StartTime: input.Body.StartTime,
-		EndTime:   input.Body.EndTime,
-		EventType: input.Body.EventType,
+		ID:                    input.ID,
+		StartTime:             input.Body.StartTime,
+		EndTime:               input.Body.EndTime,
+		EventType:             input.Body.EventType,
+		SlotPolicy:            input.Body.SlotPolicy.ToEntity(),
+		AllowDuplicateArtists: input.Body.AllowDuplicateArtists,
+		ExpectedVersion:       expectedVersion,
 	}
 
 	event, err := h.eventAppService.UpdateEvent(ctx, cmd)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to update event", err)
+		return nil, problem.FromError(err, "Failed to update event")
 	}
 
 	eventDto := dto.NewEventDtoFromEntity(event)
 
 	return &dto.UpdateEventResponse{
+		ETag: etag.Format(event.Version),
 		Body: eventDto,
 	}, nil
 }
 
-func (h *EventHandler) DeleteEvent(ctx context.Context, input *struct {
-	ID uuid.UUID `path:"id"`
-}) (*dto.DeleteEventResponse, error) {
+func (h *EventHandler) DeleteEvent(ctx context.Context, input *dto.DeleteEventRequest) (*dto.DeleteEventResponse, error) {
+
+	expectedVersion, err := etag.Parse(input.IfMatch)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to delete event")
+	}
 
 	cmd := commands.DeleteEventCommand{
-		ID: input.ID,
+		ID:              input.ID,
+		ExpectedVersion: expectedVersion,
 	}
 
-	err := h.eventAppService.DeleteEvent(ctx, cmd)
+	err = h.eventAppService.DeleteEvent(ctx, cmd)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to delete event", err)
+		return nil, problem.FromError(err, "Failed to delete event")
 	}
 
 	msg := dto.DeleteEventResponse{
@@ -158,18 +199,72 @@ func (h *EventHandler) AddArtistToEvent(ctx context.Context, input *dto.AddArtis
 
 	event, err := h.eventAppService.AddArtistToEvent(ctx, cmd)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to add artist to event", err)
+		return nil, problem.FromError(err, "Failed to add artist to event")
 	}
 
 	eventDto := dto.NewEventDtoFromEntity(event)
 
-	h.eventAppService.MessageBus().Publish(eventDto)
-
 	return &dto.AddArtistToEventEventResponst{
 		Body: eventDto,
 	}, nil
 }
 
+func (h *EventHandler) WalkIn(ctx context.Context, input *dto.WalkInRequest) (*dto.WalkInResponse, error) {
+
+	cmd := commands.WalkInCommand{
+		EventID:      input.EventID,
+		Title:        input.Body.Title,
+		NameOverride: input.Body.NameOverride,
+		SongCount:    input.Body.SongCount,
+	}
+
+	walkIn, err := h.eventAppService.WalkIn(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to add walk-in")
+	}
+
+	candidateDtos := make([]*dto.ArtistDto, 0, len(walkIn.Candidates))
+	for _, candidate := range walkIn.Candidates {
+		candidateDtos = append(candidateDtos, dto.NewArtistDtoFromEntity(candidate))
+	}
+
+	resp := dto.WalkInResponse{}
+
+	resp.Body.Event = dto.NewEventDtoFromEntity(walkIn.Event)
+	resp.Body.Artist = dto.NewArtistDtoFromEntity(walkIn.Artist)
+	resp.Body.ArtistCreated = walkIn.ArtistCreated
+	resp.Body.Candidates = candidateDtos
+
+	return &resp, nil
+}
+
+func (h *EventHandler) AddLineupItem(ctx context.Context, input *dto.AddLineupItemRequest) (*dto.AddLineupItemResponse, error) {
+
+	itemType, err := entities.ParseLineupItemType(input.Body.ItemType)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to add lineup item")
+	}
+
+	cmd := commands.AddLineupItemCommand{
+		EventID:         input.EventID,
+		ItemType:        itemType,
+		Title:           input.Body.Title,
+		DurationMinutes: input.Body.DurationMinutes,
+		Notes:           input.Body.Notes,
+	}
+
+	event, err := h.eventAppService.AddLineupItem(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to add lineup item")
+	}
+
+	eventDto := dto.NewEventDtoFromEntity(event)
+
+	return &dto.AddLineupItemResponse{
+		Body: eventDto,
+	}, nil
+}
+
 func (h *EventHandler) RemoveArtistFromEvent(ctx context.Context, input *dto.RemoveArtistFromEventEventRequest) (*dto.RemoveArtistFromEventEventResponse, error) {
 
 	cmd := commands.RemoveArtistFromEventCommand{
@@ -179,7 +274,7 @@ func (h *EventHandler) RemoveArtistFromEvent(ctx context.Context, input *dto.Rem
 
 	event, err := h.eventAppService.RemoveArtistFromEvent(ctx, cmd)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to remove artist from event", err)
+		return nil, problem.FromError(err, "Failed to remove artist from event")
 	}
 
 	eventDto := dto.NewEventDtoFromEntity(event)
@@ -192,14 +287,14 @@ func (h *EventHandler) RemoveArtistFromEvent(ctx context.Context, input *dto.Rem
 func (h *EventHandler) SetTimeslotMarker(ctx context.Context, input *dto.SetTimeslotMarkerRequest) (*dto.SetTimeslotMarkerResponse, error) {
 
 	cmd := commands.SetTimeslotMarkerCommand{
-		EventID:     input.EventID,
-		TimeDisplay: input.Body.TimeDisplay,
-		SlotIndex:   input.Body.SlotIndex,
+		EventID:    input.EventID,
+		TimeSlotID: input.Body.TimeSlotID,
+		Time:       input.Body.Time,
 	}
 
 	event, err := h.eventAppService.SetTimeslotMarker(ctx, cmd)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to set timeslot", err)
+		return nil, problem.FromError(err, "Failed to set timeslot")
 	}
 
 	eventDto := dto.NewEventDtoFromEntity(event)
@@ -218,7 +313,7 @@ func (h *EventHandler) DeleteTimeslotMarker(ctx context.Context, input *dto.Dele
 
 	event, err := h.eventAppService.DeleteTimeslotMarker(ctx, cmd)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to set timeslot", err)
+		return nil, problem.FromError(err, "Failed to set timeslot")
 	}
 
 	eventDto := dto.NewEventDtoFromEntity(event)
@@ -239,7 +334,7 @@ func (h *EventHandler) SetSortOrderRequest(ctx context.Context, input *dto.SetSo
 
 	event, err := h.eventAppService.SetSortOrder(ctx, cmd)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to set sort order", err)
+		return nil, problem.FromError(err, "Failed to set sort order")
 	}
 
 	eventDto := dto.NewEventDtoFromEntity(event)
@@ -249,17 +344,61 @@ func (h *EventHandler) SetSortOrderRequest(ctx context.Context, input *dto.SetSo
 	}, nil
 }
 
+func (h *EventHandler) ReorderLineup(ctx context.Context, input *dto.ReorderLineupRequest) (*dto.ReorderLineupResponse, error) {
+
+	cmd := commands.ReorderLineupCommand{
+		EventID:     input.EventID,
+		TimeSlotIDs: input.Body.TimeSlotIDs,
+	}
+
+	event, err := h.eventAppService.ReorderLineup(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to reorder lineup")
+	}
+
+	eventDto := dto.NewEventDtoFromEntity(event)
+
+	return &dto.ReorderLineupResponse{
+		Body: eventDto,
+	}, nil
+}
+
+func (h *EventHandler) RebalanceLineup(ctx context.Context, input *dto.RebalanceLineupRequest) (*dto.RebalanceLineupResponse, error) {
+
+	cmd := commands.RebalanceLineupCommand{
+		EventID: input.EventID,
+	}
+
+	event, err := h.eventAppService.RebalanceLineup(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to rebalance lineup")
+	}
+
+	eventDto := dto.NewEventDtoFromEntity(event)
+
+	return &dto.RebalanceLineupResponse{
+		Body: eventDto,
+	}, nil
+}
+
 func (h *EventHandler) UpdateTimeSlot(ctx context.Context, input *dto.UpdateTimeSlotRequest) (*dto.UpdateTimeSlotResponse, error) {
 
+	expectedVersion, err := etag.Parse(input.IfMatch)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to update timeslot")
+	}
+
 	cmd := commands.UpdateTimeSlotCommand{
-		EventID:    input.EventID,
-		TimeSlotID: input.TimeSlotID,
-		SongCount:  input.Body.SongCount,
+		EventID:                 input.EventID,
+		TimeSlotID:              input.TimeSlotID,
+		SongCount:               input.Body.SongCount,
+		DurationOverrideMinutes: input.Body.DurationOverrideMinutes,
+		ExpectedVersion:         expectedVersion,
 	}
 
 	event, err := h.eventAppService.UpdateTimeSlot(ctx, cmd)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to update timeslot", err)
+		return nil, problem.FromError(err, "Failed to update timeslot")
 	}
 
 	eventDto := dto.NewEventDtoFromEntity(event)
@@ -269,15 +408,68 @@ func (h *EventHandler) UpdateTimeSlot(ctx context.Context, input *dto.UpdateTime
 	}, nil
 }
 
+func (h *EventHandler) PatchTimeSlot(ctx context.Context, input *dto.PatchTimeSlotRequest) (*dto.PatchTimeSlotResponse, error) {
+
+	expectedVersion, err := etag.Parse(input.IfMatch)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to patch timeslot")
+	}
+
+	cmd := commands.PatchTimeSlotCommand{
+		EventID:         input.EventID,
+		TimeSlotID:      input.TimeSlotID,
+		NameOverride:    input.Body.NameOverride,
+		SongCount:       input.Body.SongCount,
+		Notes:           input.Body.Notes,
+		ExpectedVersion: expectedVersion,
+	}
+
+	event, err := h.eventAppService.PatchTimeSlot(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to patch timeslot")
+	}
+
+	eventDto := dto.NewEventDtoFromEntity(event)
+
+	return &dto.PatchTimeSlotResponse{
+		Body: eventDto,
+	}, nil
+}
+
+func (h *EventHandler) DeleteTimeSlot(ctx context.Context, input *dto.DeleteTimeSlotRequest) (*dto.DeleteTimeSlotResponse, error) {
+
+	expectedVersion, err := etag.Parse(input.IfMatch)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to delete timeslot")
+	}
+
+	cmd := commands.DeleteTimeSlotCommand{
+		EventID:         input.EventID,
+		TimeSlotID:      input.TimeSlotID,
+		ExpectedVersion: expectedVersion,
+	}
+
+	event, err := h.eventAppService.DeleteTimeSlot(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to delete timeslot")
+	}
+
+	eventDto := dto.NewEventDtoFromEntity(event)
+
+	return &dto.DeleteTimeSlotResponse{
+		Body: eventDto,
+	}, nil
+}
+
 func (h *EventHandler) SetNowPlaying(ctx context.Context, input *dto.SetNowPlayingRequest) (*dto.SetNowPlayingResponse, error) {
 	cmd := commands.SetNowPlayingCommand{
-		EventID: input.EventID,
-		Index:   input.Body.Index,
+		EventID:    input.EventID,
+		TimeSlotID: input.Body.TimeSlotID,
 	}
 
 	event, err := h.eventAppService.SetNowPlaying(ctx, cmd)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to set now playing", err)
+		return nil, problem.FromError(err, "Failed to set now playing")
 	}
 
 	eventDto := dto.NewEventDtoFromEntity(event)
@@ -287,58 +479,192 @@ func (h *EventHandler) SetNowPlaying(ctx context.Context, input *dto.SetNowPlayi
 	}, nil
 }
 
-func (h *EventHandler) ListenForEventChange(ctx context.Context, input *struct {
-	ID uuid.UUID `path:"event_id"`
-}, send sse.Sender) {
+func (h *EventHandler) StartShow(ctx context.Context, input *dto.ShowControlRequest) (*dto.ShowControlResponse, error) {
+	cmd := commands.StartShowCommand{
+		EventID: input.EventID,
+	}
 
-	event, err := h.eventAppService.GetEventByID(ctx, queries.EventByIDQuery{
-		ID: input.ID,
-	})
+	event, err := h.eventAppService.StartShow(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to start show")
+	}
 
+	return &dto.ShowControlResponse{
+		Body: dto.NewEventDtoFromEntity(event),
+	}, nil
+}
+
+func (h *EventHandler) NextTimeSlot(ctx context.Context, input *dto.ShowControlRequest) (*dto.ShowControlResponse, error) {
+	cmd := commands.NextTimeSlotCommand{
+		EventID: input.EventID,
+	}
+
+	event, err := h.eventAppService.NextTimeSlot(ctx, cmd)
 	if err != nil {
-		h.logger.Err(err).Msg("Failed to get event by ID")
-		return
+		return nil, problem.FromError(err, "Failed to advance to next timeslot")
 	}
 
-	eventDto := dto.NewEventDtoFromEntity(event)
+	return &dto.ShowControlResponse{
+		Body: dto.NewEventDtoFromEntity(event),
+	}, nil
+}
 
-	send(sse.Message{
-		Retry: 5000,
-		Data: dto.ListenForChangeEventResponse{
-			Body: eventDto,
-		},
-	})
+func (h *EventHandler) PreviousTimeSlot(ctx context.Context, input *dto.ShowControlRequest) (*dto.ShowControlResponse, error) {
+	cmd := commands.PreviousTimeSlotCommand{
+		EventID: input.EventID,
+	}
 
-	var wg sync.WaitGroup
+	event, err := h.eventAppService.PreviousTimeSlot(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to go back to previous timeslot")
+	}
 
-	c, clientID, err := h.eventAppService.MessageBus().Subscribe()
+	return &dto.ShowControlResponse{
+		Body: dto.NewEventDtoFromEntity(event),
+	}, nil
+}
+
+func (h *EventHandler) EndShow(ctx context.Context, input *dto.ShowControlRequest) (*dto.ShowControlResponse, error) {
+	cmd := commands.EndShowCommand{
+		EventID: input.EventID,
+	}
+
+	event, err := h.eventAppService.EndShow(ctx, cmd)
 	if err != nil {
-		h.logger.Err(err).Msg("Failed to subscribe to event changes")
+		return nil, problem.FromError(err, "Failed to end show")
+	}
+
+	return &dto.ShowControlResponse{
+		Body: dto.NewEventDtoFromEntity(event),
+	}, nil
+}
+
+func (h *EventHandler) ListenForEventChange(ctx context.Context, input *dto.ListenForEventChangeRequest, send stream.Sender) {
+
+	messageBus := h.eventAppService.MessageBus()
+	topic := input.ID.String()
+
+	// Subscribe before loading the snapshot so no change slips in between.
+	c, clientID, err := messageBus.Subscribe(topic)
+	if err != nil {
+		h.logger.Err(err).Ctx(ctx).Msg("Failed to subscribe to event changes")
 		return
 	}
-	defer h.eventAppService.MessageBus().Unsubscribe(clientID)
+	defer messageBus.Unsubscribe(topic, clientID)
+
+	var lastSeq int64
+	caughtUp := false
+	// Whether a timeslot is on stage, so projected times move with the clock.
+	live := false
+
+	if input.LastEventID > 0 {
+		changes, ok := messageBus.Replay(topic, input.LastEventID)
+		if ok {
+			for i, change := range changes {
+				msg := sse.Message{
+					ID:   int(change.Seq),
+					Data: dto.NewListenForChangeEventResponseFromEntity(change),
+				}
+				if i == 0 {
+					msg.Retry = 5000
+				}
+				live = change.Event != nil && change.Event.OpenRun() != nil
+				err = send.Send(msg)
+				if err != nil {
+					h.logger.Err(err).Ctx(ctx).Msg("Failed to send event change")
+					return
+				}
+			}
+			lastSeq = input.LastEventID
+			if len(changes) > 0 {
+				lastSeq = changes[len(changes)-1].Seq
+			}
+			caughtUp = true
+		}
+	}
+
+	if !caughtUp {
+		event, err := h.eventAppService.GetEventByID(ctx, queries.EventByIDQuery{
+			ID: input.ID,
+		})
+		if err != nil {
+			h.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
+			return
+		}
+
+		err = send.Send(sse.Message{
+			ID:    int(event.ChangeSeq),
+			Retry: 5000,
+			Data: dto.ListenForChangeEventResponse{
+				Kind: string(entities.EventChangeSnapshot),
+				Body: dto.NewEventDtoFromEntity(event),
+			},
+		})
+		if err != nil {
+			h.logger.Err(err).Ctx(ctx).Msg("Failed to send event snapshot")
+			return
+		}
+		lastSeq = event.ChangeSeq
+		live = event.OpenRun() != nil
+	}
 
-	wg.Add(1)
+	heartbeat := time.NewTicker(sseHeartbeatInterval)
+	defer heartbeat.Stop()
 
-	go func() {
-		defer wg.Done()
+	projection := time.NewTicker(sseProjectionInterval)
+	defer projection.Stop()
+
+	for {
 		select {
 		case msg, ok := <-c:
 			if !ok {
 				return
 			}
-			send(sse.Message{
+			// Already covered by the snapshot or the replayed changes.
+			if msg.Seq <= lastSeq {
+				continue
+			}
+			err = send.Send(sse.Message{
+				ID:   int(msg.Seq),
+				Data: dto.NewListenForChangeEventResponseFromEntity(msg),
+			})
+			if err != nil {
+				h.logger.Err(err).Ctx(ctx).Msg("Failed to send event change")
+				return
+			}
+			lastSeq = msg.Seq
+			live = msg.Event != nil && msg.Event.OpenRun() != nil
+		case <-projection.C:
+			if !live {
+				continue
+			}
+			event, err := h.eventAppService.GetEventByID(ctx, queries.EventByIDQuery{
+				ID: input.ID,
+			})
+			if err != nil {
+				h.logger.Err(err).Ctx(ctx).Msg("Failed to get event by ID")
+				return
+			}
+			// No ID, so a reconnecting browser still resumes from the last change.
+			err = send.Send(sse.Message{
 				Data: dto.ListenForChangeEventResponse{
-					Body: msg,
+					Kind: string(entities.EventChangeProjectionUpdated),
+					Body: dto.NewEventDtoFromEntity(event),
 				},
 			})
+			if err != nil {
+				h.logger.Err(err).Ctx(ctx).Msg("Failed to send projected times")
+				return
+			}
+		case <-heartbeat.C:
+			err = send.Comment("heartbeat")
+			if err != nil {
+				return
+			}
+		case <-messageBus.Done():
+			return
 		case <-ctx.Done():
 			return
-
 		}
-	}()
-
-	wg.Wait()
-
-	h.eventAppService.MessageBus().Unsubscribe(clientID)
+	}
 }
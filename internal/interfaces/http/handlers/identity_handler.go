@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/mcorrigan89/openmic/internal/application"
+	"github.com/mcorrigan89/openmic/internal/application/commands"
+	"github.com/mcorrigan89/openmic/internal/application/queries"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/dto"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/middleware"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/problem"
+	"github.com/rs/zerolog"
+)
+
+type IdentityHandler struct {
+	logger             *zerolog.Logger
+	identityAppService application.IdentityApplicationService
+}
+
+func NewIdentityHandler(logger *zerolog.Logger, identityAppService application.IdentityApplicationService) *IdentityHandler {
+	return &IdentityHandler{
+		logger:             logger,
+		identityAppService: identityAppService,
+	}
+}
+
+func (h *IdentityHandler) GetLoginProviders(ctx context.Context, input *struct{}) (*dto.GetLoginProvidersResponse, error) {
+	resp := dto.GetLoginProvidersResponse{}
+	resp.Body.Providers = h.identityAppService.GetProviders(ctx)
+
+	return &resp, nil
+}
+
+func (h *IdentityHandler) StartExternalLogin(ctx context.Context, input *dto.StartExternalLoginRequest) (*dto.StartExternalLoginResponse, error) {
+	cmd := commands.StartExternalLoginCommand{
+		Provider: input.Provider,
+	}
+
+	loginRequest, err := h.identityAppService.StartExternalLogin(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to start login")
+	}
+
+	resp := dto.StartExternalLoginResponse{}
+	resp.Body.AuthorizationURL = loginRequest.AuthorizationURL
+	resp.Body.Binding = loginRequest.Binding
+
+	return &resp, nil
+}
+
+func (h *IdentityHandler) CompleteExternalLogin(ctx context.Context, input *dto.CompleteExternalLoginRequest) (*dto.CompleteExternalLoginResponse, error) {
+	cmd := commands.CompleteExternalLoginCommand{
+		State:   input.Body.State,
+		Code:    input.Body.Code,
+		Binding: input.Body.Binding,
+	}
+
+	userSessionEntity, err := h.identityAppService.CompleteExternalLogin(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to complete login")
+	}
+
+	resp := dto.CompleteExternalLoginResponse{}
+
+	resp.Body.User = dto.NewUserDtoFromEntity(userSessionEntity.User)
+	resp.Body.SessionDto = dto.NewSessionDtoFromEntity(userSessionEntity)
+
+	return &resp, nil
+}
+
+func (h *IdentityHandler) GetIdentities(ctx context.Context, input *struct{}) (*dto.GetIdentitiesResponse, error) {
+	userContext := middleware.GetUserFromContext(ctx)
+
+	identities, err := h.identityAppService.GetIdentities(ctx, queries.IdentitiesByUserQuery{
+		UserID: userContext.UserID,
+	})
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to get identities")
+	}
+
+	identityDtos := make([]*dto.IdentityDto, 0, len(identities))
+	for _, identity := range identities {
+		identityDtos = append(identityDtos, dto.NewIdentityDtoFromEntity(identity))
+	}
+
+	return &dto.GetIdentitiesResponse{
+		Body: identityDtos,
+	}, nil
+}
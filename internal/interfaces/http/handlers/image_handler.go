@@ -11,6 +11,7 @@ import (
 	"github.com/mcorrigan89/openmic/internal/infrastructure/media"
 	"github.com/mcorrigan89/openmic/internal/interfaces/http/dto"
 	"github.com/mcorrigan89/openmic/internal/interfaces/http/middleware"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/problem"
 	"github.com/rs/zerolog"
 )
 
@@ -33,7 +34,7 @@ func (h *ImageHandler) GetImageByID(w http.ResponseWriter, r *http.Request) {
 
 	imageUUID, err := uuid.Parse(imageID)
 	if err != nil {
-		http.Error(w, "Failed to parse UUID", http.StatusInternalServerError)
+		problem.Write(w, http.StatusUnprocessableEntity, "Invalid image ID")
 		return
 	}
 
@@ -43,7 +44,7 @@ func (h *ImageHandler) GetImageByID(w http.ResponseWriter, r *http.Request) {
 
 	image, err := h.imageAppService.GetImageByID(ctx, query)
 	if err != nil {
-		http.Error(w, "Failed to get user by ID", http.StatusInternalServerError)
+		problem.WriteError(w, err, "Failed to get image")
 		return
 	}
 
@@ -51,7 +52,7 @@ func (h *ImageHandler) GetImageByID(w http.ResponseWriter, r *http.Request) {
 
 	imageJson, err := imageDto.ToJson()
 	if err != nil {
-		http.Error(w, "Failed to marshal image to JSON", http.StatusInternalServerError)
+		problem.Write(w, http.StatusInternalServerError, "Failed to marshal image to JSON")
 		return
 	}
 
@@ -66,7 +67,7 @@ func (h *ImageHandler) GetImageDataByID(w http.ResponseWriter, r *http.Request)
 
 	imageUUID, err := uuid.Parse(imageID)
 	if err != nil {
-		http.Error(w, "Failed to parse UUID", http.StatusInternalServerError)
+		problem.Write(w, http.StatusUnprocessableEntity, "Invalid image ID")
 		return
 	}
 
@@ -78,7 +79,7 @@ func (h *ImageHandler) GetImageDataByID(w http.ResponseWriter, r *http.Request)
 	image, contentType, err := h.imageAppService.GetImageDataByID(ctx, query)
 	if err != nil {
 		h.logger.Err(err).Ctx(ctx).Msg("Failed to get image")
-		http.Error(w, "Failed to get image", http.StatusInternalServerError)
+		problem.WriteError(w, err, "Failed to get image")
 		return
 	}
 
@@ -94,7 +95,7 @@ func (h *ImageHandler) UploadImage(w http.ResponseWriter, r *http.Request) {
 	file, handler, err := r.FormFile("image")
 	if err != nil {
 		h.logger.Err(err).Ctx(ctx).Msg("Failed to get file from form")
-		http.Error(w, "Failed to get file from form", http.StatusBadRequest)
+		problem.Write(w, http.StatusBadRequest, "Failed to get file from form")
 		return
 	}
 	defer file.Close()
@@ -102,7 +103,7 @@ func (h *ImageHandler) UploadImage(w http.ResponseWriter, r *http.Request) {
 	userContextEntity := middleware.GetUserFromContext(ctx)
 	if userContextEntity == nil {
 		h.logger.Error().Ctx(ctx).Msg("User is not authenticated")
-		http.Error(w, "User is not authenticated", http.StatusUnauthorized)
+		problem.Write(w, http.StatusUnauthorized, "User is not authenticated")
 		return
 	}
 
@@ -118,7 +119,7 @@ func (h *ImageHandler) UploadImage(w http.ResponseWriter, r *http.Request) {
 
 	image, err := h.imageAppService.UploadAvatarImage(ctx, cmd)
 	if err != nil {
-		http.Error(w, "Failed to get user by ID", http.StatusInternalServerError)
+		problem.WriteError(w, err, "Failed to upload image")
 		return
 	}
 
@@ -126,7 +127,7 @@ func (h *ImageHandler) UploadImage(w http.ResponseWriter, r *http.Request) {
 
 	imageJson, err := imageDto.ToJson()
 	if err != nil {
-		http.Error(w, "Failed to marshal image to JSON", http.StatusInternalServerError)
+		problem.Write(w, http.StatusInternalServerError, "Failed to marshal image to JSON")
 		return
 	}
 
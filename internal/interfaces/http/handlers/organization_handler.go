@@ -0,0 +1,303 @@
+package handlers
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/application"
+	"github.com/mcorrigan89/openmic/internal/application/commands"
+	"github.com/mcorrigan89/openmic/internal/application/queries"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/dto"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/middleware"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/problem"
+	"github.com/rs/zerolog"
+)
+
+type OrganizationHandler struct {
+	logger                 *zerolog.Logger
+	organizationAppService application.OrganizationApplicationService
+}
+
+func NewOrganizationHandler(logger *zerolog.Logger, organizationAppService application.OrganizationApplicationService) *OrganizationHandler {
+	return &OrganizationHandler{
+		logger:                 logger,
+		organizationAppService: organizationAppService,
+	}
+}
+
+func (h *OrganizationHandler) GetOrganizationByID(ctx context.Context, input *struct {
+	OrganizationID uuid.UUID `path:"organization_id"`
+}) (*dto.GetOrganizationByIDResponse, error) {
+
+	query := queries.OrganizationByIDQuery{
+		ID: input.OrganizationID,
+	}
+
+	organization, err := h.organizationAppService.GetOrganizationByID(ctx, query)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to get organization by ID")
+	}
+
+	return &dto.GetOrganizationByIDResponse{
+		Body: dto.NewOrganizationDtoFromEntity(organization),
+	}, nil
+}
+
+func (h *OrganizationHandler) GetMyOrganizations(ctx context.Context, input *struct{}) (*dto.GetMyOrganizationsResponse, error) {
+
+	query := queries.OrganizationsByUserQuery{
+		UserID: middleware.GetUserFromContext(ctx).UserID,
+	}
+
+	organizations, err := h.organizationAppService.GetOrganizationsByUser(ctx, query)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to get organizations")
+	}
+
+	organizationDtos := make([]*dto.OrganizationDto, 0, len(organizations))
+	for _, organization := range organizations {
+		organizationDtos = append(organizationDtos, dto.NewOrganizationDtoFromEntity(organization))
+	}
+
+	return &dto.GetMyOrganizationsResponse{
+		Body: organizationDtos,
+	}, nil
+}
+
+func (h *OrganizationHandler) CreateOrganization(ctx context.Context, input *dto.CreateOrganizationRequest) (*dto.CreateOrganizationResponse, error) {
+
+	cmd := commands.CreateOrganizationCommand{
+		Name:    input.Body.Name,
+		Handle:  input.Body.Handle,
+		OwnerID: middleware.GetUserFromContext(ctx).UserID,
+	}
+
+	organization, err := h.organizationAppService.CreateOrganization(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to create organization")
+	}
+
+	return &dto.CreateOrganizationResponse{
+		Body: dto.NewOrganizationDtoFromEntity(organization),
+	}, nil
+}
+
+func (h *OrganizationHandler) UpdateOrganization(ctx context.Context, input *dto.UpdateOrganizationRequest) (*dto.UpdateOrganizationResponse, error) {
+
+	cmd := commands.UpdateOrganizationCommand{
+		ID:     input.OrganizationID,
+		Name:   input.Body.Name,
+		Handle: input.Body.Handle,
+	}
+
+	organization, err := h.organizationAppService.UpdateOrganization(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to update organization")
+	}
+
+	return &dto.UpdateOrganizationResponse{
+		Body: dto.NewOrganizationDtoFromEntity(organization),
+	}, nil
+}
+
+func (h *OrganizationHandler) GetOrganizationMembers(ctx context.Context, input *struct {
+	OrganizationID uuid.UUID `path:"organization_id"`
+}) (*dto.GetOrganizationMembersResponse, error) {
+
+	query := queries.OrganizationMembersQuery{
+		OrganizationID: input.OrganizationID,
+	}
+
+	members, err := h.organizationAppService.GetOrganizationMembers(ctx, query)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to get organization members")
+	}
+
+	userDtos := make([]*dto.UserDto, 0, len(members))
+	for _, member := range members {
+		userDtos = append(userDtos, dto.NewUserDtoFromEntity(member))
+	}
+
+	return &dto.GetOrganizationMembersResponse{
+		Body: userDtos,
+	}, nil
+}
+
+func (h *OrganizationHandler) AddOrganizationMember(ctx context.Context, input *dto.AddOrganizationMemberRequest) (*dto.AddOrganizationMemberResponse, error) {
+
+	cmd := commands.AddOrganizationMemberCommand{
+		OrganizationID: input.OrganizationID,
+		UserID:         input.Body.UserID,
+	}
+
+	err := h.organizationAppService.AddOrganizationMember(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to add organization member")
+	}
+
+	return &dto.AddOrganizationMemberResponse{}, nil
+}
+
+func (h *OrganizationHandler) RemoveOrganizationMember(ctx context.Context, input *dto.RemoveOrganizationMemberRequest) (*dto.RemoveOrganizationMemberResponse, error) {
+
+	cmd := commands.RemoveOrganizationMemberCommand{
+		OrganizationID: input.OrganizationID,
+		UserID:         input.UserID,
+	}
+
+	err := h.organizationAppService.RemoveOrganizationMember(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to remove organization member")
+	}
+
+	return &dto.RemoveOrganizationMemberResponse{}, nil
+}
+
+func (h *OrganizationHandler) GetVenueByID(ctx context.Context, input *struct {
+	VenueID uuid.UUID `path:"venue_id"`
+}) (*dto.GetVenueByIDResponse, error) {
+
+	query := queries.VenueByIDQuery{
+		ID: input.VenueID,
+	}
+
+	venue, err := h.organizationAppService.GetVenueByID(ctx, query)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to get venue by ID")
+	}
+
+	return &dto.GetVenueByIDResponse{
+		Body: dto.NewVenueDtoFromEntity(venue),
+	}, nil
+}
+
+func (h *OrganizationHandler) GetVenues(ctx context.Context, input *struct {
+	OrganizationID uuid.UUID `path:"organization_id"`
+}) (*dto.GetVenuesResponse, error) {
+
+	query := queries.VenuesByOrganizationQuery{
+		OrganizationID: input.OrganizationID,
+	}
+
+	venues, err := h.organizationAppService.GetVenuesByOrganization(ctx, query)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to get venues")
+	}
+
+	venueDtos := make([]*dto.VenueDto, 0, len(venues))
+	for _, venue := range venues {
+		venueDtos = append(venueDtos, dto.NewVenueDtoFromEntity(venue))
+	}
+
+	return &dto.GetVenuesResponse{
+		Body: venueDtos,
+	}, nil
+}
+
+func (h *OrganizationHandler) CreateVenue(ctx context.Context, input *dto.CreateVenueRequest) (*dto.CreateVenueResponse, error) {
+
+	cmd := commands.CreateVenueCommand{
+		OrganizationID: input.OrganizationID,
+		Name:           input.Body.Name,
+		Address:        input.Body.Address,
+		CurrentWindow:  input.Body.CurrentWindow.ToEntity(),
+	}
+
+	venue, err := h.organizationAppService.CreateVenue(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to create venue")
+	}
+
+	return &dto.CreateVenueResponse{
+		Body: dto.NewVenueDtoFromEntity(venue),
+	}, nil
+}
+
+func (h *OrganizationHandler) UpdateVenue(ctx context.Context, input *dto.UpdateVenueRequest) (*dto.UpdateVenueResponse, error) {
+
+	cmd := commands.UpdateVenueCommand{
+		ID:            input.VenueID,
+		Name:          input.Body.Name,
+		Address:       input.Body.Address,
+		CurrentWindow: input.Body.CurrentWindow.ToEntity(),
+	}
+
+	venue, err := h.organizationAppService.UpdateVenue(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to update venue")
+	}
+
+	return &dto.UpdateVenueResponse{
+		Body: dto.NewVenueDtoFromEntity(venue),
+	}, nil
+}
+
+func (h *OrganizationHandler) DeleteVenue(ctx context.Context, input *dto.DeleteVenueRequest) (*dto.DeleteVenueResponse, error) {
+
+	cmd := commands.DeleteVenueCommand{
+		ID: input.VenueID,
+	}
+
+	err := h.organizationAppService.DeleteVenue(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to delete venue")
+	}
+
+	return &dto.DeleteVenueResponse{}, nil
+}
+
+func (h *OrganizationHandler) GetEventTypeWindows(ctx context.Context, input *struct {
+	OrganizationID uuid.UUID `path:"organization_id"`
+}) (*dto.GetEventTypeWindowsResponse, error) {
+
+	query := queries.EventTypeWindowsQuery{
+		OrganizationID: input.OrganizationID,
+	}
+
+	windows, err := h.organizationAppService.GetEventTypeWindows(ctx, query)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to get event type windows")
+	}
+
+	windowDtos := make([]*dto.EventTypeWindowDto, 0, len(windows))
+	for _, window := range windows {
+		windowDtos = append(windowDtos, dto.NewEventTypeWindowDtoFromEntity(window))
+	}
+
+	return &dto.GetEventTypeWindowsResponse{
+		Body: windowDtos,
+	}, nil
+}
+
+func (h *OrganizationHandler) SetEventTypeWindow(ctx context.Context, input *dto.SetEventTypeWindowRequest) (*dto.SetEventTypeWindowResponse, error) {
+
+	cmd := commands.SetEventTypeWindowCommand{
+		OrganizationID: input.OrganizationID,
+		EventType:      input.EventType,
+		Window:         *input.Body.ToEntity(),
+	}
+
+	window, err := h.organizationAppService.SetEventTypeWindow(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to set event type window")
+	}
+
+	return &dto.SetEventTypeWindowResponse{
+		Body: dto.NewEventTypeWindowDtoFromEntity(window),
+	}, nil
+}
+
+func (h *OrganizationHandler) DeleteEventTypeWindow(ctx context.Context, input *dto.DeleteEventTypeWindowRequest) (*dto.DeleteEventTypeWindowResponse, error) {
+
+	cmd := commands.DeleteEventTypeWindowCommand{
+		OrganizationID: input.OrganizationID,
+		EventType:      input.EventType,
+	}
+
+	err := h.organizationAppService.DeleteEventTypeWindow(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to delete event type window")
+	}
+
+	return &dto.DeleteEventTypeWindowResponse{}, nil
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"net/mail"
 
 	"github.com/danielgtaylor/huma/v2"
@@ -9,7 +10,11 @@ import (
 	"github.com/mcorrigan89/openmic/internal/application"
 	"github.com/mcorrigan89/openmic/internal/application/commands"
 	"github.com/mcorrigan89/openmic/internal/application/queries"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
 	"github.com/mcorrigan89/openmic/internal/interfaces/http/dto"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/etag"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/middleware"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/problem"
 	"github.com/rs/zerolog"
 )
 
@@ -35,23 +40,45 @@ func (h *UserHandler) GetUserByID(ctx context.Context, input *struct {
 
 	user, err := h.userAppService.GetUserByID(ctx, query)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to get user by ID", err)
+		return nil, problem.FromError(err, "Failed to get user by ID")
 	}
 
 	userDto := dto.NewUserDtoFromEntity(user)
 
 	return &dto.GetUserByIDResponse{
+		ETag: etag.Format(user.Version),
 		Body: userDto,
 	}, nil
 }
 
+func (h *UserHandler) GetCurrentUser(ctx context.Context, input *struct{}) (*dto.GetCurrentUserResponse, error) {
+	userContext := middleware.GetUserFromContext(ctx)
+
+	resp := dto.GetCurrentUserResponse{}
+
+	resp.Body.User = dto.NewUserDtoFromEntity(userContext.User)
+	resp.Body.SessionDto = dto.NewSessionDtoFromEntity(userContext)
+
+	if userContext.IsImpersonated() {
+		impersonator, err := h.userAppService.GetUserByID(ctx, queries.UserByIDQuery{
+			ID: *userContext.ImpersonatorID(),
+		})
+		if err != nil {
+			return nil, problem.FromError(err, "Failed to get impersonator")
+		}
+		resp.Body.ImpersonatedBy = dto.NewUserDtoFromEntity(impersonator)
+	}
+
+	return &resp, nil
+}
+
 func (h *UserHandler) GetUserByEmail(ctx context.Context, input *struct {
 	Email string `path:"email"`
 }) (*dto.GetUserByEmailResponse, error) {
 
 	_, err := mail.ParseAddress(input.Email)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to parse email", err)
+		return nil, huma.Error422UnprocessableEntity("Invalid email")
 	}
 
 	query := queries.UserByEmailQuery{
@@ -60,7 +87,7 @@ func (h *UserHandler) GetUserByEmail(ctx context.Context, input *struct {
 
 	user, err := h.userAppService.GetUserByEmail(ctx, query)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to get user by email", err)
+		return nil, problem.FromError(err, "Failed to get user by email")
 	}
 
 	userDto := dto.NewUserDtoFromEntity(user)
@@ -77,44 +104,232 @@ func (h *UserHandler) CreateUser(ctx context.Context, input *dto.CreateUserReque
 		FamilyName: input.Body.FamilyName,
 	}
 
-	userSessionEntity, err := h.userAppService.CreateUser(ctx, cmd)
+	userEntity, err := h.userAppService.CreateUser(ctx, cmd)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to create user", err)
-	}
-
-	userDto := dto.NewUserDtoFromEntity(userSessionEntity.User)
-	sessionDto := dto.SessionDto{
-		Token:     userSessionEntity.SessionToken,
-		ExpiresAt: userSessionEntity.ExpiresAt(),
+		return nil, problem.FromError(err, "Failed to create user")
 	}
 
 	resp := dto.CreateUserResponse{}
 
-	resp.Body.User = userDto
-	resp.Body.SessionDto = &sessionDto
+	resp.Body.User = dto.NewUserDtoFromEntity(userEntity)
 
 	return &resp, nil
 }
 
 func (h *UserHandler) UpdateUser(ctx context.Context, input *dto.UpdateUserRequest) (*dto.UpdateUserResponse, error) {
+	if !middleware.GetUserFromContext(ctx).CanEditUser(input.ID) {
+		return nil, huma.Error403Forbidden("Forbidden")
+	}
+
+	expectedVersion, err := etag.Parse(input.IfMatch)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to update user")
+	}
+
 	cmd := commands.UpdateUserCommand{
-		ID:         input.ID,
-		Email:      input.Body.Email,
-		GivenName:  input.Body.GivenName,
-		FamilyName: input.Body.FamilyName,
-		Handle:     input.Body.Handle,
+		ID:              input.ID,
+		Email:           input.Body.Email,
+		GivenName:       input.Body.GivenName,
+		FamilyName:      input.Body.FamilyName,
+		Handle:          input.Body.Handle,
+		ExpectedVersion: expectedVersion,
 	}
 
 	userEntity, err := h.userAppService.UpdateUser(ctx, cmd)
 	if err != nil {
-		return nil, huma.Error500InternalServerError("Failed to update user", err)
+		return nil, problem.FromError(err, "Failed to update user")
 	}
 
 	userDto := dto.NewUserDtoFromEntity(userEntity)
 
-	resp := dto.UpdateUserResponse{}
+	resp := dto.UpdateUserResponse{
+		ETag: etag.Format(userEntity.Version),
+	}
 
 	resp.Body.User = userDto
 
 	return &resp, nil
 }
+
+func (h *UserHandler) RequestEmailLogin(ctx context.Context, input *dto.RequestEmailLoginRequest) (*dto.RequestEmailLoginResponse, error) {
+	cmd := commands.RequestEmailLoginCommand{
+		Email: input.Body.Email,
+	}
+
+	err := h.userAppService.RequestEmailLogin(ctx, cmd)
+	// Respond the same way for unknown emails so accounts cannot be discovered.
+	if err != nil && !errors.Is(err, entities.ErrUserNotFound) {
+		return nil, problem.FromError(err, "Failed to request email login")
+	}
+
+	return &dto.RequestEmailLoginResponse{}, nil
+}
+
+func (h *UserHandler) LoginWithToken(ctx context.Context, input *dto.LoginWithTokenRequest) (*dto.LoginWithTokenResponse, error) {
+	cmd := commands.LoginWithReferenceLinkCommand{
+		ReferenceLinkToken: input.Body.Token,
+	}
+
+	userSessionEntity, err := h.userAppService.LoginWithReferenceLink(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to login with token")
+	}
+
+	resp := dto.LoginWithTokenResponse{}
+
+	resp.Body.User = dto.NewUserDtoFromEntity(userSessionEntity.User)
+	resp.Body.SessionDto = dto.NewSessionDtoFromEntity(userSessionEntity)
+
+	return &resp, nil
+}
+
+func (h *UserHandler) InviteUser(ctx context.Context, input *dto.InviteUserRequest) (*dto.InviteUserResponse, error) {
+	cmd := commands.InviteUserCommand{
+		Email:      input.Body.Email,
+		GivenName:  input.Body.GivenName,
+		FamilyName: input.Body.FamilyName,
+	}
+
+	err := h.userAppService.InviteUser(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to invite user")
+	}
+
+	return &dto.InviteUserResponse{}, nil
+}
+
+func (h *UserHandler) AcceptInvite(ctx context.Context, input *dto.AcceptInviteRequest) (*dto.AcceptInviteResponse, error) {
+	cmd := commands.AcceptInviteReferenceLinkCommand{
+		ReferenceLinkToken: input.Body.Token,
+	}
+
+	userSessionEntity, err := h.userAppService.AcceptInviteReferenceLink(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to accept invite")
+	}
+
+	resp := dto.AcceptInviteResponse{}
+
+	resp.Body.User = dto.NewUserDtoFromEntity(userSessionEntity.User)
+	resp.Body.SessionDto = dto.NewSessionDtoFromEntity(userSessionEntity)
+
+	return &resp, nil
+}
+
+func (h *UserHandler) SetUserRole(ctx context.Context, input *dto.SetUserRoleRequest) (*dto.SetUserRoleResponse, error) {
+	cmd := commands.SetUserRoleCommand{
+		ID:   input.ID,
+		Role: entities.Role(input.Body.Role),
+	}
+
+	userEntity, err := h.userAppService.SetUserRole(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to set user role")
+	}
+
+	resp := dto.SetUserRoleResponse{}
+
+	resp.Body.User = dto.NewUserDtoFromEntity(userEntity)
+
+	return &resp, nil
+}
+
+func (h *UserHandler) Logout(ctx context.Context, input *struct{}) (*dto.LogoutResponse, error) {
+	userContext := middleware.GetUserFromContext(ctx)
+
+	cmd := commands.LogoutCommand{
+		UserID:    userContext.UserID,
+		SessionID: userContext.SessionID,
+	}
+
+	err := h.userAppService.Logout(ctx, cmd)
+	if err != nil && !errors.Is(err, entities.ErrSessionNotFound) {
+		return nil, problem.FromError(err, "Failed to log out")
+	}
+
+	return &dto.LogoutResponse{}, nil
+}
+
+func (h *UserHandler) LogoutEverywhere(ctx context.Context, input *struct{}) (*dto.LogoutEverywhereResponse, error) {
+	cmd := commands.LogoutEverywhereCommand{
+		UserID: middleware.GetUserFromContext(ctx).UserID,
+	}
+
+	err := h.userAppService.LogoutEverywhere(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to log out everywhere")
+	}
+
+	return &dto.LogoutEverywhereResponse{}, nil
+}
+
+func (h *UserHandler) GetSessions(ctx context.Context, input *struct{}) (*dto.GetSessionsResponse, error) {
+	userContext := middleware.GetUserFromContext(ctx)
+
+	query := queries.SessionsByUserQuery{
+		UserID: userContext.UserID,
+	}
+
+	sessions, err := h.userAppService.GetActiveSessions(ctx, query)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to get sessions")
+	}
+
+	sessionDtos := make([]*dto.ActiveSessionDto, 0, len(sessions))
+	for _, session := range sessions {
+		sessionDtos = append(sessionDtos, dto.NewActiveSessionDtoFromEntity(session, userContext.SessionID))
+	}
+
+	return &dto.GetSessionsResponse{
+		Body: sessionDtos,
+	}, nil
+}
+
+func (h *UserHandler) RevokeSession(ctx context.Context, input *dto.RevokeSessionRequest) (*dto.RevokeSessionResponse, error) {
+	cmd := commands.RevokeSessionCommand{
+		UserID:    middleware.GetUserFromContext(ctx).UserID,
+		SessionID: input.SessionID,
+	}
+
+	err := h.userAppService.RevokeSession(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to revoke session")
+	}
+
+	return &dto.RevokeSessionResponse{}, nil
+}
+
+func (h *UserHandler) StartImpersonation(ctx context.Context, input *dto.StartImpersonationRequest) (*dto.StartImpersonationResponse, error) {
+	cmd := commands.StartImpersonationCommand{
+		ImpersonatorID: middleware.GetUserFromContext(ctx).UserID,
+		UserID:         input.ID,
+	}
+
+	userSessionEntity, err := h.userAppService.StartImpersonation(ctx, cmd)
+	if err != nil {
+		return nil, problem.FromError(err, "Failed to start impersonation")
+	}
+
+	resp := dto.StartImpersonationResponse{}
+
+	resp.Body.User = dto.NewUserDtoFromEntity(userSessionEntity.User)
+	resp.Body.SessionDto = dto.NewSessionDtoFromEntity(userSessionEntity)
+
+	return &resp, nil
+}
+
+func (h *UserHandler) EndImpersonation(ctx context.Context, input *struct{}) (*dto.EndImpersonationResponse, error) {
+	cmd := commands.EndImpersonationCommand{
+		UserContext: middleware.GetUserFromContext(ctx),
+	}
+
+	err := h.userAppService.EndImpersonation(ctx, cmd)
+	if err != nil {
+		if errors.Is(err, entities.ErrSessionNotFound) {
+			return &dto.EndImpersonationResponse{}, nil
+		}
+		return nil, problem.FromError(err, "Failed to end impersonation")
+	}
+
+	return &dto.EndImpersonationResponse{}, nil
+}
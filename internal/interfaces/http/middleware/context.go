@@ -2,9 +2,15 @@ package middleware
 
 import (
 	"context"
+	"errors"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
 
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
 	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/problem"
 	"github.com/rs/xid"
 )
 
@@ -13,12 +19,14 @@ type contextKey string
 const (
 	ipKey                 contextKey = "ip"
 	correlationIDKey      contextKey = "correlation_id"
-	sessionTokenKey       contextKey = "sessionTokenKey"
 	currentUserContextKey contextKey = "currentUserContextKey"
+	currentAPIKeyKey      contextKey = "currentAPIKeyKey"
 )
 
 var SessionTokenKey = "x-session-token"
 
+var APIKeyHeader = "x-api-key"
+
 func GetCorrelationIdFromContext(ctx context.Context) string {
 	correlationId, ok := ctx.Value(correlationIDKey).(string)
 	if !ok {
@@ -27,14 +35,6 @@ func GetCorrelationIdFromContext(ctx context.Context) string {
 	return correlationId
 }
 
-func GetSessionTokenFromContext(ctx context.Context) string {
-	sessionToken, ok := ctx.Value(sessionTokenKey).(string)
-	if !ok {
-		return ""
-	}
-	return sessionToken
-}
-
 func GetIPFromContext(ctx context.Context) string {
 	ip, ok := ctx.Value(ipKey).(string)
 	if !ok {
@@ -48,18 +48,67 @@ func (m *middleware) ContextBuilder(next http.Handler) http.Handler {
 		ctx := r.Context()
 		querier := models.New(m.db)
 
-		ctx = context.WithValue(ctx, ipKey, r.RemoteAddr)
-		correlationID := xid.New().String()
-		ctx = context.WithValue(ctx, correlationIDKey, correlationID)
+		ip := r.RemoteAddr
+		if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+			ip = host
+		}
+		ctx = context.WithValue(ctx, ipKey, ip)
+		// RecoverPanic normally assigns the ID first.
+		if GetCorrelationIdFromContext(ctx) == "" {
+			ctx = context.WithValue(ctx, correlationIDKey, xid.New().String())
+		}
 
+		// The token is a credential; it is never placed on the context or logged.
 		sessionToken := r.Header.Get(SessionTokenKey)
-		ctx = context.WithValue(ctx, sessionTokenKey, sessionToken)
 
 		ctx = m.logger.WithContext(ctx)
 
-		userContext, err := m.userService.GetUserContextBySessionToken(ctx, querier, sessionToken)
-		if err == nil {
-			ctx = context.WithValue(ctx, currentUserContextKey, userContext)
+		// Anonymous requests carry no token and need no lookup.
+		if sessionToken != "" {
+			userContext, err := m.userService.GetUserContextBySessionToken(ctx, querier, sessionToken)
+			if err == nil {
+				ctx = context.WithValue(ctx, currentUserContextKey, userContext)
+
+				// Changes made while impersonating must be attributable to the
+				// admin, so a request that cannot be audited is not served.
+				if userContext.IsImpersonated() && r.Method != http.MethodGet && r.Method != http.MethodHead && r.Method != http.MethodOptions {
+					m.logger.Warn().Ctx(ctx).Str("method", r.Method).Str("path", r.URL.Path).Msg("Impersonated request")
+
+					err = m.userService.AuditImpersonatedRequest(ctx, querier, userContext, r.Method, r.URL.Path)
+					if err != nil {
+						m.logger.Err(err).Ctx(ctx).Msg("Failed to audit impersonated request")
+						problem.Write(w, http.StatusInternalServerError, "Failed to audit impersonated request")
+						return
+					}
+				}
+
+				err = m.userService.TouchSession(ctx, querier, userContext, ip)
+				if err != nil {
+					m.logger.Err(err).Ctx(ctx).Msg("Failed to touch session")
+				}
+			}
+		}
+
+		// A key that is sent must be valid; unlike a stale session it is never
+		// treated as anonymous, so a misconfigured client fails loudly.
+		if key := r.Header.Get(APIKeyHeader); key != "" {
+			apiKey, err := m.apiKeyService.Authenticate(ctx, querier, key)
+			if err != nil {
+				if !errors.Is(err, entities.ErrAPIKeyNotFound) {
+					m.logger.Err(err).Ctx(ctx).Msg("Failed to authenticate API key")
+				}
+				problem.Write(w, http.StatusUnauthorized, "Unauthorized")
+				return
+			}
+			ctx = context.WithValue(ctx, currentAPIKeyKey, apiKey)
+
+			allowed, retryAfter := m.rateLimiter.Allow(apiKey.ID.String(), apiKey.RateLimitPerMinute)
+			if !allowed {
+				m.logger.Warn().Ctx(ctx).Msg("API key rate limited")
+				w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				problem.Write(w, http.StatusTooManyRequests, "API key rate limit exceeded")
+				return
+			}
 		}
 
 		r = r.WithContext(ctx)
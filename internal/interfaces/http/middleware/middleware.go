@@ -3,8 +3,10 @@ package middleware
 import (
 	"net/http"
 
+	"github.com/danielgtaylor/huma/v2"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/mcorrigan89/openmic/internal/common"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
 	"github.com/mcorrigan89/openmic/internal/domain/services"
 	"github.com/rs/zerolog"
 )
@@ -14,15 +16,20 @@ type Middleware interface {
 	RecoverPanic(next http.Handler) http.Handler
 	EnabledCORS(next http.Handler) http.Handler
 	Authorization(next http.HandlerFunc) http.HandlerFunc
+	RequireRole(api huma.API, role entities.Role, scopes ...entities.APIKeyScope) func(ctx huma.Context, next func(huma.Context))
+	RequireOrganizationMember(api huma.API, resolver OrganizationResolver) func(ctx huma.Context, next func(huma.Context))
 }
 
 type middleware struct {
-	config      *common.Config
-	logger      *zerolog.Logger
-	db          *pgxpool.Pool
-	userService services.UserService
+	config              *common.Config
+	logger              *zerolog.Logger
+	db                  *pgxpool.Pool
+	userService         services.UserService
+	organizationService services.OrganizationService
+	apiKeyService       services.APIKeyService
+	rateLimiter         *common.RateLimiter
 }
 
-func CreateMiddleware(config *common.Config, db *pgxpool.Pool, logger *zerolog.Logger, userService services.UserService) *middleware {
-	return &middleware{config: config, logger: logger, db: db, userService: userService}
+func CreateMiddleware(config *common.Config, db *pgxpool.Pool, logger *zerolog.Logger, userService services.UserService, organizationService services.OrganizationService, apiKeyService services.APIKeyService) *middleware {
+	return &middleware{config: config, logger: logger, db: db, userService: userService, organizationService: organizationService, apiKeyService: apiKeyService, rateLimiter: common.NewRateLimiter()}
 }
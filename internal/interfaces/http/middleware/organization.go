@@ -0,0 +1,148 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/problem"
+)
+
+type organizationScope int
+
+const (
+	organizationScopeOrganization organizationScope = iota
+	organizationScopeVenue
+	organizationScopeEvent
+	organizationScopeArtist
+)
+
+// OrganizationResolver names the path parameter an operation is scoped by and
+// what kind of resource it identifies.
+type OrganizationResolver struct {
+	scope organizationScope
+	param string
+}
+
+func OrganizationFromParam(param string) OrganizationResolver {
+	return OrganizationResolver{scope: organizationScopeOrganization, param: param}
+}
+
+func OrganizationFromVenueParam(param string) OrganizationResolver {
+	return OrganizationResolver{scope: organizationScopeVenue, param: param}
+}
+
+func OrganizationFromEventParam(param string) OrganizationResolver {
+	return OrganizationResolver{scope: organizationScopeEvent, param: param}
+}
+
+func OrganizationFromArtistParam(param string) OrganizationResolver {
+	return OrganizationResolver{scope: organizationScopeArtist, param: param}
+}
+
+func (m *middleware) resolveOrganizationID(ctx context.Context, resolver OrganizationResolver, id uuid.UUID) (uuid.UUID, error) {
+	querier := models.New(m.db)
+
+	switch resolver.scope {
+	case organizationScopeVenue:
+		organization, err := m.organizationService.GetOrganizationByVenueID(ctx, querier, id)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return organization.ID, nil
+	case organizationScopeEvent:
+		organization, err := m.organizationService.GetOrganizationByEventID(ctx, querier, id)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return organization.ID, nil
+	case organizationScopeArtist:
+		organization, err := m.organizationService.GetOrganizationByArtistID(ctx, querier, id)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		return organization.ID, nil
+	}
+
+	return id, nil
+}
+
+// RequireOrganizationMember is a huma operation middleware that only lets
+// through members of the organization owning the resource in the path.
+// Admins may act on every organization, as may API keys not bound to one.
+func (m *middleware) RequireOrganizationMember(api huma.API, resolver OrganizationResolver) func(ctx huma.Context, next func(huma.Context)) {
+	return func(ctx huma.Context, next func(huma.Context)) {
+		userContext := GetUserFromContext(ctx.Context())
+		if userContext == nil || userContext.IsExpired() {
+			apiKey := GetAPIKeyFromContext(ctx.Context())
+			if apiKey == nil {
+				huma.WriteErr(api, ctx, http.StatusUnauthorized, "Unauthorized")
+				return
+			}
+
+			m.requireAPIKeyOrganization(api, ctx, next, resolver, apiKey)
+			return
+		}
+
+		if userContext.HasRole(entities.RoleAdmin) {
+			next(ctx)
+			return
+		}
+
+		id, err := uuid.Parse(ctx.Param(resolver.param))
+		if err != nil {
+			huma.WriteErr(api, ctx, http.StatusUnprocessableEntity, "Invalid "+resolver.param)
+			return
+		}
+
+		organizationID, err := m.resolveOrganizationID(ctx.Context(), resolver, id)
+		if err != nil {
+			problem.WriteHumaError(api, ctx, err, "Failed to resolve organization")
+			return
+		}
+
+		isMember, err := m.organizationService.IsOrganizationMember(ctx.Context(), models.New(m.db), organizationID, userContext.UserID)
+		if err != nil {
+			problem.WriteHumaError(api, ctx, err, "Failed to check organization membership")
+			return
+		}
+
+		if !isMember {
+			m.logger.Warn().Ctx(ctx.Context()).Str("user_id", userContext.UserID.String()).Str("organization_id", organizationID.String()).Str("operation", ctx.Operation().OperationID).Msg("Not an organization member")
+			huma.WriteErr(api, ctx, http.StatusForbidden, "Forbidden")
+			return
+		}
+
+		next(ctx)
+	}
+}
+
+func (m *middleware) requireAPIKeyOrganization(api huma.API, ctx huma.Context, next func(huma.Context), resolver OrganizationResolver, apiKey *entities.APIKeyEntity) {
+	if apiKey.OrganizationID == nil {
+		next(ctx)
+		return
+	}
+
+	id, err := uuid.Parse(ctx.Param(resolver.param))
+	if err != nil {
+		huma.WriteErr(api, ctx, http.StatusUnprocessableEntity, "Invalid "+resolver.param)
+		return
+	}
+
+	organizationID, err := m.resolveOrganizationID(ctx.Context(), resolver, id)
+	if err != nil {
+		problem.WriteHumaError(api, ctx, err, "Failed to resolve organization")
+		return
+	}
+
+	if !apiKey.CanAccessOrganization(organizationID) {
+		m.logger.Warn().Ctx(ctx.Context()).Str("organization_id", organizationID.String()).Str("operation", ctx.Operation().OperationID).Msg("API key not scoped to organization")
+		huma.WriteErr(api, ctx, http.StatusForbidden, "Forbidden")
+		return
+	}
+
+	next(ctx)
+}
@@ -1,19 +1,36 @@
 package middleware
 
 import (
-	"log"
+	"context"
 	"net/http"
+
+	"github.com/mcorrigan89/openmic/internal/common"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/problem"
+	"github.com/rs/xid"
 )
 
+// RecoverPanic turns a panicking request into a 500 problem response and
+// keeps the server running. It wraps every other middleware, so it assigns
+// the correlation ID itself to log the panic under the same ID as the rest
+// of the request.
 func (m *middleware) RecoverPanic(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		defer func() {
-			if err := recover(); err != nil {
-
-				w.Header().Set("Connect", "close")
+		ctx := context.WithValue(r.Context(), correlationIDKey, xid.New().String())
+		r = r.WithContext(ctx)
 
-				log.Fatalf("Panic not recovered %v \n", err)
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			// Handlers abort a response on purpose with ErrAbortHandler;
+			// net/http expects to see it.
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
 			}
+
+			common.LogPanic(ctx, m.logger, recovered, "Recovered from panic")
+			problem.Write(w, http.StatusInternalServerError, "Internal server error")
 		}()
 
 		next.ServeHTTP(w, r)
@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/mcorrigan89/openmic/internal/common"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoverPanic(t *testing.T) {
+	var buf bytes.Buffer
+	logger := zerolog.New(&buf)
+	m := CreateMiddleware(&common.Config{}, nil, &logger, nil, nil, nil)
+
+	t.Run("panic becomes a 500 problem", func(t *testing.T) {
+		buf.Reset()
+		var correlationID string
+		handler := m.RecoverPanic(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			correlationID = GetCorrelationIdFromContext(r.Context())
+			panic("handler exploded")
+		}))
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/event/1", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+		assert.Equal(t, "application/problem+json", rec.Header().Get("Content-Type"))
+
+		var body map[string]any
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, "Internal server error", body["detail"])
+
+		assert.NotEmpty(t, correlationID)
+		assert.Contains(t, buf.String(), "handler exploded")
+		assert.Contains(t, buf.String(), `"stack"`)
+	})
+
+	t.Run("aborted handlers still abort", func(t *testing.T) {
+		handler := m.RecoverPanic(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic(http.ErrAbortHandler)
+		}))
+
+		assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+		})
+	})
+}
@@ -0,0 +1,84 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+)
+
+// ContentType is the media type of RFC 9457 problem details.
+const ContentType = "application/problem+json"
+
+// Status is the HTTP status for err, based on its domain error kind.
+func Status(err error) int {
+	switch entities.KindOf(err) {
+	case entities.KindNotFound:
+		return http.StatusNotFound
+	case entities.KindConflict:
+		return http.StatusConflict
+	case entities.KindValidation:
+		return http.StatusUnprocessableEntity
+	case entities.KindForbidden:
+		return http.StatusForbidden
+	case entities.KindExpired:
+		return http.StatusGone
+	case entities.KindPreconditionFailed:
+		return http.StatusPreconditionFailed
+	}
+
+	return http.StatusInternalServerError
+}
+
+// FromError translates err into a problem response. Domain errors use their
+// own message as the detail. Anything else is a 500 with fallback as the
+// detail, and the cause is kept out of the response.
+func FromError(err error, fallback string) huma.StatusError {
+	return huma.NewError(statusAndDetail(err, fallback))
+}
+
+// WriteHumaError is FromError for huma operation middleware.
+func WriteHumaError(api huma.API, ctx huma.Context, err error, fallback string) {
+	status, detail := statusAndDetail(err, fallback)
+	huma.WriteErr(api, ctx, status, detail)
+}
+
+// Write sends a problem from the plain mux routes and middleware, in the same
+// shape huma uses for registered operations.
+func Write(w http.ResponseWriter, status int, detail string) {
+	write(w, huma.NewError(status, detail))
+}
+
+// WriteError is FromError for the plain mux routes.
+func WriteError(w http.ResponseWriter, err error, fallback string) {
+	write(w, FromError(err, fallback))
+}
+
+func write(w http.ResponseWriter, problem huma.StatusError) {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(problem.GetStatus())
+	json.NewEncoder(w).Encode(problem)
+}
+
+func statusAndDetail(err error, fallback string) (int, string) {
+	status := Status(err)
+	if status == http.StatusInternalServerError {
+		return status, fallback
+	}
+
+	return status, detail(err)
+}
+
+// detail is the message of the domain error in err's chain, without any
+// context wrapped around it, capitalized for display.
+func detail(err error) string {
+	var domainErr *entities.Error
+	errors.As(err, &domainErr)
+
+	first, size := utf8.DecodeRuneInString(domainErr.Message)
+	return string(unicode.ToUpper(first)) + domainErr.Message[size:]
+}
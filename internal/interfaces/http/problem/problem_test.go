@@ -0,0 +1,60 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromError(t *testing.T) {
+	tests := []struct {
+		name   string
+		err    error
+		status int
+		detail string
+	}{
+		{"not found", entities.ErrUserNotFound, http.StatusNotFound, "User not found"},
+		{"conflict", entities.ErrEmailInUse, http.StatusConflict, "Email in use"},
+		{"validation", entities.ErrInvalidRole, http.StatusUnprocessableEntity, "Invalid role"},
+		{"forbidden", entities.ErrImpersonationNotAllowed, http.StatusForbidden, "Impersonation not allowed"},
+		{"expired", entities.ErrLinkExpired, http.StatusGone, "Link expired"},
+		{"precondition failed", entities.ErrVersionMismatch, http.StatusPreconditionFailed, "Resource has been modified"},
+		{"wrapped", fmt.Errorf("%w: id token has expired", entities.ErrLoginRequestInvalid), http.StatusUnprocessableEntity, "Login request invalid"},
+		{"internal", errors.New("connection refused"), http.StatusInternalServerError, "Failed to get user"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			problem := FromError(tt.err, "Failed to get user")
+
+			model, ok := problem.(*huma.ErrorModel)
+			require.True(t, ok)
+			assert.Equal(t, tt.status, model.Status)
+			assert.Equal(t, tt.detail, model.Detail)
+			assert.Empty(t, model.Errors)
+		})
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	rec := httptest.NewRecorder()
+
+	WriteError(rec, entities.ErrImageNotFound, "Failed to get image")
+
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	assert.Equal(t, ContentType, rec.Header().Get("Content-Type"))
+
+	var body map[string]any
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "Not Found", body["title"])
+	assert.Equal(t, float64(http.StatusNotFound), body["status"])
+	assert.Equal(t, "Image not found", body["detail"])
+}
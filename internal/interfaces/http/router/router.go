@@ -5,17 +5,27 @@ import (
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/danielgtaylor/huma/v2/adapters/humago"
-	"github.com/danielgtaylor/huma/v2/sse"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
 	"github.com/mcorrigan89/openmic/internal/interfaces/http/dto"
 	"github.com/mcorrigan89/openmic/internal/interfaces/http/handlers"
-	"github.com/mcorrigan89/openmic/internal/interfaces/http/middleware"
+	mw "github.com/mcorrigan89/openmic/internal/interfaces/http/middleware"
+	"github.com/mcorrigan89/openmic/internal/interfaces/http/stream"
 )
 
-func NewRouter(mux *http.ServeMux, middleware middleware.Middleware, userHandler *handlers.UserHandler, imageHandler *handlers.ImageHandler, eventHandler *handlers.EventHandler, artistHandler *handlers.ArtistHandler) http.Handler {
+func NewRouter(mux *http.ServeMux, middleware mw.Middleware, userHandler *handlers.UserHandler, imageHandler *handlers.ImageHandler, eventHandler *handlers.EventHandler, artistHandler *handlers.ArtistHandler, organizationHandler *handlers.OrganizationHandler, identityHandler *handlers.IdentityHandler, apiKeyHandler *handlers.APIKeyHandler) http.Handler {
 
 	api := humago.New(mux, huma.DefaultConfig("OpenMic API", "1.0.0"))
 
 	// User routes
+	huma.Register(api, huma.Operation{
+		OperationID: "get-current-user",
+		Method:      http.MethodGet,
+		Path:        "/user/me",
+		Summary:     "The current user and session",
+		Tags:        []string{"User"},
+		Middlewares: huma.Middlewares{middleware.RequireRole(api, entities.RoleAudience)},
+	}, userHandler.GetCurrentUser)
+
 	huma.Register(api, huma.Operation{
 		OperationID: "get-user",
 		Method:      http.MethodGet,
@@ -37,7 +47,9 @@ func NewRouter(mux *http.ServeMux, middleware middleware.Middleware, userHandler
 		Method:      http.MethodPost,
 		Path:        "/user",
 		Summary:     "Create user",
+		Description: "Creates an account without signing in to it. Everyone else joins through an invite or an external login.",
 		Tags:        []string{"User"},
+		Middlewares: huma.Middlewares{middleware.RequireRole(api, entities.RoleAdmin, entities.ScopeUsersManage)},
 	}, userHandler.CreateUser)
 
 	huma.Register(api, huma.Operation{
@@ -46,10 +58,199 @@ func NewRouter(mux *http.ServeMux, middleware middleware.Middleware, userHandler
 		Path:        "/user/{id}",
 		Summary:     "Update user",
 		Tags:        []string{"User"},
+		Errors:      []int{http.StatusNotFound, http.StatusPreconditionFailed},
+		Middlewares: huma.Middlewares{middleware.RequireRole(api, entities.RoleAudience)},
 	}, userHandler.UpdateUser)
 
+	huma.Register(api, huma.Operation{
+		OperationID: "set-user-role",
+		Method:      http.MethodPut,
+		Path:        "/user/{id}/role",
+		Summary:     "Set user role",
+		Description: "The SERVER_TOKEN key holds the users:manage scope, so it can appoint the first admin.",
+		Tags:        []string{"User"},
+		Middlewares: huma.Middlewares{middleware.RequireRole(api, entities.RoleAdmin, entities.ScopeUsersManage)},
+	}, userHandler.SetUserRole)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "start-impersonation",
+		Method:      http.MethodPost,
+		Path:        "/user/{id}/impersonate",
+		Summary:     "Start a time-limited session acting as the user",
+		Tags:        []string{"User"},
+		Middlewares: huma.Middlewares{middleware.RequireRole(api, entities.RoleAdmin)},
+		Errors:      []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound},
+	}, userHandler.StartImpersonation)
+
+	// Auth routes
+	huma.Register(api, huma.Operation{
+		OperationID:   "request-email-login",
+		Method:        http.MethodPost,
+		Path:          "/auth/login/email",
+		Summary:       "Request a login link by email",
+		Tags:          []string{"Auth"},
+		DefaultStatus: http.StatusAccepted,
+	}, userHandler.RequestEmailLogin)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "login-with-token",
+		Method:      http.MethodPost,
+		Path:        "/auth/login/token",
+		Summary:     "Exchange a login link token for a session",
+		Tags:        []string{"Auth"},
+		Errors:      []int{http.StatusNotFound, http.StatusGone, http.StatusUnprocessableEntity},
+	}, userHandler.LoginWithToken)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "invite-user",
+		Method:        http.MethodPost,
+		Path:          "/auth/invite",
+		Summary:       "Invite a user by email",
+		Tags:          []string{"Auth"},
+		Middlewares:   huma.Middlewares{middleware.RequireRole(api, entities.RoleHost)},
+		DefaultStatus: http.StatusAccepted,
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusConflict},
+	}, userHandler.InviteUser)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "accept-invite",
+		Method:      http.MethodPost,
+		Path:        "/auth/invite/accept",
+		Summary:     "Accept an invite and start a session",
+		Tags:        []string{"Auth"},
+		Errors:      []int{http.StatusNotFound, http.StatusGone, http.StatusConflict, http.StatusUnprocessableEntity},
+	}, userHandler.AcceptInvite)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "logout",
+		Method:        http.MethodPost,
+		Path:          "/auth/logout",
+		Summary:       "End the current session",
+		Tags:          []string{"Auth"},
+		Middlewares:   huma.Middlewares{middleware.RequireRole(api, entities.RoleAudience)},
+		DefaultStatus: http.StatusNoContent,
+	}, userHandler.Logout)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "logout-everywhere",
+		Method:        http.MethodPost,
+		Path:          "/auth/logout/all",
+		Summary:       "End every session of the current user",
+		Tags:          []string{"Auth"},
+		Middlewares:   huma.Middlewares{middleware.RequireRole(api, entities.RoleAudience)},
+		DefaultStatus: http.StatusNoContent,
+	}, userHandler.LogoutEverywhere)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-sessions",
+		Method:      http.MethodGet,
+		Path:        "/auth/sessions",
+		Summary:     "Active sessions of the current user",
+		Tags:        []string{"Auth"},
+		Middlewares: huma.Middlewares{middleware.RequireRole(api, entities.RoleAudience)},
+	}, userHandler.GetSessions)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "revoke-session",
+		Method:        http.MethodDelete,
+		Path:          "/auth/sessions/{session_id}",
+		Summary:       "Revoke one of the current user's sessions",
+		Tags:          []string{"Auth"},
+		Middlewares:   huma.Middlewares{middleware.RequireRole(api, entities.RoleAudience)},
+		DefaultStatus: http.StatusNoContent,
+		Errors:        []int{http.StatusUnauthorized, http.StatusNotFound},
+	}, userHandler.RevokeSession)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "end-impersonation",
+		Method:        http.MethodPost,
+		Path:          "/auth/impersonate/end",
+		Summary:       "End the current impersonation session",
+		Tags:          []string{"Auth"},
+		Middlewares:   huma.Middlewares{middleware.RequireRole(api, entities.RoleAudience)},
+		DefaultStatus: http.StatusNoContent,
+		Errors:        []int{http.StatusUnauthorized, http.StatusConflict},
+	}, userHandler.EndImpersonation)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-login-providers",
+		Method:      http.MethodGet,
+		Path:        "/auth/providers",
+		Summary:     "Configured external login providers",
+		Tags:        []string{"Auth"},
+	}, identityHandler.GetLoginProviders)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "start-external-login",
+		Method:      http.MethodPost,
+		Path:        "/auth/oidc/{provider}",
+		Summary:     "Start an OIDC login and get the provider URL to redirect to",
+		Tags:        []string{"Auth"},
+		Errors:      []int{http.StatusNotFound},
+	}, identityHandler.StartExternalLogin)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "complete-external-login",
+		Method:      http.MethodPost,
+		Path:        "/auth/oidc/callback",
+		Summary:     "Exchange an OIDC authorization code for a session",
+		Tags:        []string{"Auth"},
+		Errors:      []int{http.StatusForbidden, http.StatusConflict, http.StatusUnprocessableEntity},
+	}, identityHandler.CompleteExternalLogin)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-user-identities",
+		Method:      http.MethodGet,
+		Path:        "/user/me/identities",
+		Summary:     "External identities linked to the current user",
+		Tags:        []string{"User"},
+		Middlewares: huma.Middlewares{middleware.RequireRole(api, entities.RoleAudience)},
+	}, identityHandler.GetIdentities)
+
+	// API key routes
+	huma.Register(api, huma.Operation{
+		OperationID: "get-api-keys",
+		Method:      http.MethodGet,
+		Path:        "/api-keys",
+		Summary:     "List API keys",
+		Tags:        []string{"API Key"},
+		Middlewares: huma.Middlewares{middleware.RequireRole(api, entities.RoleAdmin, entities.ScopeKeysManage)},
+		Errors:      []int{http.StatusUnauthorized, http.StatusForbidden},
+	}, apiKeyHandler.GetAPIKeys)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "create-api-key",
+		Method:        http.MethodPost,
+		Path:          "/api-key",
+		Summary:       "Create an API key",
+		Tags:          []string{"API Key"},
+		DefaultStatus: http.StatusCreated,
+		Middlewares:   huma.Middlewares{middleware.RequireRole(api, entities.RoleAdmin, entities.ScopeKeysManage)},
+		Errors:        []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity},
+	}, apiKeyHandler.CreateAPIKey)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "rotate-api-key",
+		Method:      http.MethodPost,
+		Path:        "/api-key/{id}/rotate",
+		Summary:     "Replace the secret of an API key",
+		Tags:        []string{"API Key"},
+		Middlewares: huma.Middlewares{middleware.RequireRole(api, entities.RoleAdmin, entities.ScopeKeysManage)},
+		Errors:      []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound},
+	}, apiKeyHandler.RotateAPIKey)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "revoke-api-key",
+		Method:      http.MethodDelete,
+		Path:        "/api-key/{id}",
+		Summary:     "Revoke an API key",
+		Tags:        []string{"API Key"},
+		Middlewares: huma.Middlewares{middleware.RequireRole(api, entities.RoleAdmin, entities.ScopeKeysManage)},
+		Errors:      []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound},
+	}, apiKeyHandler.RevokeAPIKey)
+
 	// Image routes
-	mux.HandleFunc("POST /image/upload", imageHandler.UploadImage)
+	mux.HandleFunc("POST /image/upload", middleware.Authorization(imageHandler.UploadImage))
 	mux.HandleFunc("GET /image/{id}/metadata", middleware.Authorization(imageHandler.GetImageByID))
 	mux.HandleFunc("GET /image/{id}", imageHandler.GetImageDataByID)
 
@@ -65,7 +266,7 @@ func NewRouter(mux *http.ServeMux, middleware middleware.Middleware, userHandler
 	huma.Register(api, huma.Operation{
 		OperationID: "get-current-event",
 		Method:      http.MethodGet,
-		Path:        "/event/now",
+		Path:        "/organization/{organization_id}/event/now",
 		Summary:     "Current Event",
 		Tags:        []string{"Event"},
 	}, eventHandler.GetCurrentEvent)
@@ -73,7 +274,7 @@ func NewRouter(mux *http.ServeMux, middleware middleware.Middleware, userHandler
 	huma.Register(api, huma.Operation{
 		OperationID: "get-events",
 		Method:      http.MethodGet,
-		Path:        "/events",
+		Path:        "/organization/{organization_id}/events",
 		Summary:     "Upcoming Events",
 		Tags:        []string{"Event"},
 	}, eventHandler.GetUpcomingEvents)
@@ -81,9 +282,13 @@ func NewRouter(mux *http.ServeMux, middleware middleware.Middleware, userHandler
 	huma.Register(api, huma.Operation{
 		OperationID: "create-event",
 		Method:      http.MethodPost,
-		Path:        "/event",
+		Path:        "/venue/{venue_id}/event",
 		Summary:     "Create Event",
 		Tags:        []string{"Event"},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost, entities.ScopeEventsWrite),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromVenueParam("venue_id")),
+		},
 	}, eventHandler.CreateEvent)
 
 	huma.Register(api, huma.Operation{
@@ -92,6 +297,11 @@ func NewRouter(mux *http.ServeMux, middleware middleware.Middleware, userHandler
 		Path:        "/event/{id}",
 		Summary:     "Update Event",
 		Tags:        []string{"Event"},
+		Errors:      []int{http.StatusNotFound, http.StatusPreconditionFailed},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost, entities.ScopeEventsWrite),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromEventParam("id")),
+		},
 	}, eventHandler.UpdateEvent)
 
 	huma.Register(api, huma.Operation{
@@ -100,6 +310,11 @@ func NewRouter(mux *http.ServeMux, middleware middleware.Middleware, userHandler
 		Path:        "/event/{id}",
 		Summary:     "Delete Event",
 		Tags:        []string{"Event"},
+		Errors:      []int{http.StatusNotFound, http.StatusPreconditionFailed},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost, entities.ScopeEventsWrite),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromEventParam("id")),
+		},
 	}, eventHandler.DeleteEvent)
 
 	huma.Register(api, huma.Operation{
@@ -108,14 +323,48 @@ func NewRouter(mux *http.ServeMux, middleware middleware.Middleware, userHandler
 		Path:        "/event/{event_id}/add",
 		Summary:     "Add Artist to Event",
 		Tags:        []string{"Event"},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost, entities.ScopeEventsWrite),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromEventParam("event_id")),
+		},
 	}, eventHandler.AddArtistToEvent)
 
+	huma.Register(api, huma.Operation{
+		OperationID: "walk-in",
+		Method:      http.MethodPost,
+		Path:        "/event/{event_id}/walk-in",
+		Summary:     "Add Walk-in to Event",
+		Tags:        []string{"Event"},
+		Errors:      []int{http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost, entities.ScopeEventsWrite),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromEventParam("event_id")),
+		},
+	}, eventHandler.WalkIn)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "add-lineup-item",
+		Method:      http.MethodPost,
+		Path:        "/event/{event_id}/item",
+		Summary:     "Add Lineup Item to Event",
+		Tags:        []string{"Event"},
+		Errors:      []int{http.StatusNotFound, http.StatusUnprocessableEntity},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost, entities.ScopeEventsWrite),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromEventParam("event_id")),
+		},
+	}, eventHandler.AddLineupItem)
+
 	huma.Register(api, huma.Operation{
 		OperationID: "remove-artist-from-event",
 		Method:      http.MethodPost,
 		Path:        "/event/{event_id}/remove",
 		Summary:     "Remove Artist from Event",
 		Tags:        []string{"Event"},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost, entities.ScopeEventsWrite),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromEventParam("event_id")),
+		},
 	}, eventHandler.RemoveArtistFromEvent)
 
 	huma.Register(api, huma.Operation{
@@ -124,6 +373,10 @@ func NewRouter(mux *http.ServeMux, middleware middleware.Middleware, userHandler
 		Path:        "/event/{event_id}/timeslot/marker",
 		Summary:     "Set Timeslot",
 		Tags:        []string{"Event"},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost, entities.ScopeEventsWrite),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromEventParam("event_id")),
+		},
 	}, eventHandler.SetTimeslotMarker)
 
 	huma.Register(api, huma.Operation{
@@ -132,6 +385,10 @@ func NewRouter(mux *http.ServeMux, middleware middleware.Middleware, userHandler
 		Path:        "/event/{event_id}/timeslot/marker",
 		Summary:     "Delete Timeslot",
 		Tags:        []string{"Event"},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost, entities.ScopeEventsWrite),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromEventParam("event_id")),
+		},
 	}, eventHandler.DeleteTimeslotMarker)
 
 	huma.Register(api, huma.Operation{
@@ -140,25 +397,141 @@ func NewRouter(mux *http.ServeMux, middleware middleware.Middleware, userHandler
 		Path:        "/event/{event_id}/sort",
 		Summary:     "Set Sort Order",
 		Tags:        []string{"Event"},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost, entities.ScopeEventsWrite),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromEventParam("event_id")),
+		},
 	}, eventHandler.SetSortOrderRequest)
 
+	huma.Register(api, huma.Operation{
+		OperationID: "reorder-lineup",
+		Method:      http.MethodPut,
+		Path:        "/event/{event_id}/lineup",
+		Summary:     "Reorder Lineup",
+		Tags:        []string{"Event"},
+		Errors:      []int{http.StatusNotFound, http.StatusUnprocessableEntity},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost, entities.ScopeEventsWrite),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromEventParam("event_id")),
+		},
+	}, eventHandler.ReorderLineup)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "rebalance-lineup",
+		Method:      http.MethodPost,
+		Path:        "/event/{event_id}/lineup/rebalance",
+		Summary:     "Rebalance Lineup",
+		Tags:        []string{"Event"},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost, entities.ScopeEventsWrite),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromEventParam("event_id")),
+		},
+	}, eventHandler.RebalanceLineup)
+
 	huma.Register(api, huma.Operation{
 		OperationID: "update-timeslot",
 		Method:      http.MethodPut,
 		Path:        "/event/{event_id}/timeslot/{timeslot_id}",
 		Summary:     "Update Timeslot",
 		Tags:        []string{"Event"},
+		Errors:      []int{http.StatusNotFound, http.StatusPreconditionFailed},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost, entities.ScopeEventsWrite),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromEventParam("event_id")),
+		},
 	}, eventHandler.UpdateTimeSlot)
 
+	huma.Register(api, huma.Operation{
+		OperationID: "patch-timeslot",
+		Method:      http.MethodPatch,
+		Path:        "/event/{event_id}/timeslot/{timeslot_id}",
+		Summary:     "Patch Timeslot",
+		Tags:        []string{"Event"},
+		Errors:      []int{http.StatusNotFound, http.StatusPreconditionFailed},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost, entities.ScopeEventsWrite),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromEventParam("event_id")),
+		},
+	}, eventHandler.PatchTimeSlot)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "delete-timeslot",
+		Method:      http.MethodDelete,
+		Path:        "/event/{event_id}/timeslot/{timeslot_id}",
+		Summary:     "Delete Timeslot",
+		Tags:        []string{"Event"},
+		Errors:      []int{http.StatusNotFound, http.StatusPreconditionFailed},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost, entities.ScopeEventsWrite),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromEventParam("event_id")),
+		},
+	}, eventHandler.DeleteTimeSlot)
+
 	huma.Register(api, huma.Operation{
 		OperationID: "set-now-playing",
 		Method:      http.MethodPut,
 		Path:        "/event/{event_id}/now-playing",
 		Summary:     "Set Now Playing",
 		Tags:        []string{"Event"},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost, entities.ScopeEventsWrite),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromEventParam("event_id")),
+		},
 	}, eventHandler.SetNowPlaying)
 
-	sse.Register(api, huma.Operation{
+	huma.Register(api, huma.Operation{
+		OperationID: "start-show",
+		Method:      http.MethodPost,
+		Path:        "/event/{event_id}/show/start",
+		Summary:     "Start Show",
+		Tags:        []string{"Event"},
+		Errors:      []int{http.StatusNotFound, http.StatusConflict, http.StatusUnprocessableEntity},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost, entities.ScopeEventsWrite),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromEventParam("event_id")),
+		},
+	}, eventHandler.StartShow)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "next-timeslot",
+		Method:      http.MethodPost,
+		Path:        "/event/{event_id}/show/next",
+		Summary:     "Next Timeslot",
+		Tags:        []string{"Event"},
+		Errors:      []int{http.StatusNotFound, http.StatusConflict},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost, entities.ScopeEventsWrite),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromEventParam("event_id")),
+		},
+	}, eventHandler.NextTimeSlot)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "previous-timeslot",
+		Method:      http.MethodPost,
+		Path:        "/event/{event_id}/show/previous",
+		Summary:     "Previous Timeslot",
+		Tags:        []string{"Event"},
+		Errors:      []int{http.StatusNotFound, http.StatusConflict},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost, entities.ScopeEventsWrite),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromEventParam("event_id")),
+		},
+	}, eventHandler.PreviousTimeSlot)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "end-show",
+		Method:      http.MethodPost,
+		Path:        "/event/{event_id}/show/end",
+		Summary:     "End Show",
+		Tags:        []string{"Event"},
+		Errors:      []int{http.StatusNotFound, http.StatusConflict},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost, entities.ScopeEventsWrite),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromEventParam("event_id")),
+		},
+	}, eventHandler.EndShow)
+
+	stream.Register(api, huma.Operation{
 		OperationID: "sse",
 		Method:      http.MethodGet,
 		Path:        "/sse/{event_id}",
@@ -169,6 +542,176 @@ func NewRouter(mux *http.ServeMux, middleware middleware.Middleware, userHandler
 		"message": dto.ListenForChangeEventResponse{},
 	}, eventHandler.ListenForEventChange)
 
+	// Organization routes
+	huma.Register(api, huma.Operation{
+		OperationID: "get-organization",
+		Method:      http.MethodGet,
+		Path:        "/organization/{organization_id}",
+		Summary:     "Get Organization by ID",
+		Tags:        []string{"Organization"},
+	}, organizationHandler.GetOrganizationByID)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-my-organizations",
+		Method:      http.MethodGet,
+		Path:        "/organizations",
+		Summary:     "Organizations the current user is a member of",
+		Tags:        []string{"Organization"},
+		Middlewares: huma.Middlewares{middleware.RequireRole(api, entities.RoleAudience)},
+	}, organizationHandler.GetMyOrganizations)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-organization",
+		Method:      http.MethodPost,
+		Path:        "/organization",
+		Summary:     "Create Organization",
+		Tags:        []string{"Organization"},
+		Middlewares: huma.Middlewares{middleware.RequireRole(api, entities.RoleAdmin)},
+		Errors:      []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusConflict},
+	}, organizationHandler.CreateOrganization)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-organization",
+		Method:      http.MethodPut,
+		Path:        "/organization/{organization_id}",
+		Summary:     "Update Organization",
+		Tags:        []string{"Organization"},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromParam("organization_id")),
+		},
+		Errors: []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound, http.StatusConflict},
+	}, organizationHandler.UpdateOrganization)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-organization-members",
+		Method:      http.MethodGet,
+		Path:        "/organization/{organization_id}/members",
+		Summary:     "Organization Members",
+		Tags:        []string{"Organization"},
+		Middlewares: huma.Middlewares{
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromParam("organization_id")),
+		},
+	}, organizationHandler.GetOrganizationMembers)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "add-organization-member",
+		Method:        http.MethodPost,
+		Path:          "/organization/{organization_id}/member",
+		Summary:       "Add Organization Member",
+		Tags:          []string{"Organization"},
+		DefaultStatus: http.StatusNoContent,
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromParam("organization_id")),
+		},
+		Errors: []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound},
+	}, organizationHandler.AddOrganizationMember)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "remove-organization-member",
+		Method:        http.MethodDelete,
+		Path:          "/organization/{organization_id}/member/{user_id}",
+		Summary:       "Remove Organization Member",
+		Tags:          []string{"Organization"},
+		DefaultStatus: http.StatusNoContent,
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromParam("organization_id")),
+		},
+	}, organizationHandler.RemoveOrganizationMember)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-event-type-windows",
+		Method:      http.MethodGet,
+		Path:        "/organization/{organization_id}/event-type-windows",
+		Summary:     "Current Event Windows by Event Type",
+		Tags:        []string{"Organization"},
+	}, organizationHandler.GetEventTypeWindows)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "set-event-type-window",
+		Method:      http.MethodPut,
+		Path:        "/organization/{organization_id}/event-type-window/{event_type}",
+		Summary:     "Set Current Event Window for an Event Type",
+		Tags:        []string{"Organization"},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromParam("organization_id")),
+		},
+		Errors: []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound},
+	}, organizationHandler.SetEventTypeWindow)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-event-type-window",
+		Method:        http.MethodDelete,
+		Path:          "/organization/{organization_id}/event-type-window/{event_type}",
+		Summary:       "Delete Current Event Window for an Event Type",
+		Tags:          []string{"Organization"},
+		DefaultStatus: http.StatusNoContent,
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromParam("organization_id")),
+		},
+		Errors: []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound},
+	}, organizationHandler.DeleteEventTypeWindow)
+
+	// Venue routes
+	huma.Register(api, huma.Operation{
+		OperationID: "get-venues",
+		Method:      http.MethodGet,
+		Path:        "/organization/{organization_id}/venues",
+		Summary:     "Venues of an Organization",
+		Tags:        []string{"Venue"},
+	}, organizationHandler.GetVenues)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-venue",
+		Method:      http.MethodGet,
+		Path:        "/venue/{venue_id}",
+		Summary:     "Get Venue by ID",
+		Tags:        []string{"Venue"},
+	}, organizationHandler.GetVenueByID)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "create-venue",
+		Method:      http.MethodPost,
+		Path:        "/organization/{organization_id}/venue",
+		Summary:     "Create Venue",
+		Tags:        []string{"Venue"},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromParam("organization_id")),
+		},
+		Errors: []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound},
+	}, organizationHandler.CreateVenue)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-venue",
+		Method:      http.MethodPut,
+		Path:        "/venue/{venue_id}",
+		Summary:     "Update Venue",
+		Tags:        []string{"Venue"},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromVenueParam("venue_id")),
+		},
+		Errors: []int{http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound},
+	}, organizationHandler.UpdateVenue)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "delete-venue",
+		Method:        http.MethodDelete,
+		Path:          "/venue/{venue_id}",
+		Summary:       "Delete Venue",
+		Tags:          []string{"Venue"},
+		DefaultStatus: http.StatusNoContent,
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleHost),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromVenueParam("venue_id")),
+		},
+	}, organizationHandler.DeleteVenue)
+
 	// Artist routes
 	huma.Register(api, huma.Operation{
 		OperationID: "get-artist",
@@ -181,7 +724,7 @@ func NewRouter(mux *http.ServeMux, middleware middleware.Middleware, userHandler
 	huma.Register(api, huma.Operation{
 		OperationID: "get-artists-by-title",
 		Method:      http.MethodGet,
-		Path:        "/artists/search",
+		Path:        "/organization/{organization_id}/artists/search",
 		Summary:     "Get Artists by Title",
 		Tags:        []string{"Artist"},
 	}, artistHandler.GetArtistsByTitle)
@@ -189,7 +732,7 @@ func NewRouter(mux *http.ServeMux, middleware middleware.Middleware, userHandler
 	huma.Register(api, huma.Operation{
 		OperationID: "get-all-artists",
 		Method:      http.MethodGet,
-		Path:        "/artists",
+		Path:        "/organization/{organization_id}/artists",
 		Summary:     "Get All Artists",
 		Tags:        []string{"Artist"},
 	}, artistHandler.GetAllArtists)
@@ -197,9 +740,13 @@ func NewRouter(mux *http.ServeMux, middleware middleware.Middleware, userHandler
 	huma.Register(api, huma.Operation{
 		OperationID: "create-artist",
 		Method:      http.MethodPost,
-		Path:        "/artist",
+		Path:        "/organization/{organization_id}/artist",
 		Summary:     "Create Artist",
 		Tags:        []string{"Artist"},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RolePerformer),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromParam("organization_id")),
+		},
 	}, artistHandler.CreateArtist)
 
 	huma.Register(api, huma.Operation{
@@ -208,6 +755,11 @@ func NewRouter(mux *http.ServeMux, middleware middleware.Middleware, userHandler
 		Path:        "/artist/{id}",
 		Summary:     "Update Artist",
 		Tags:        []string{"Artist"},
+		Errors:      []int{http.StatusNotFound, http.StatusPreconditionFailed},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleAudience),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromArtistParam("id")),
+		},
 	}, artistHandler.UpdateArtist)
 
 	huma.Register(api, huma.Operation{
@@ -216,6 +768,11 @@ func NewRouter(mux *http.ServeMux, middleware middleware.Middleware, userHandler
 		Path:        "/artist/{id}",
 		Summary:     "Delete Artist",
 		Tags:        []string{"Artist"},
+		Errors:      []int{http.StatusNotFound, http.StatusPreconditionFailed},
+		Middlewares: huma.Middlewares{
+			middleware.RequireRole(api, entities.RoleAudience),
+			middleware.RequireOrganizationMember(api, mw.OrganizationFromArtistParam("id")),
+		},
 	}, artistHandler.DeleteArtist)
 
 	return middleware.RecoverPanic(middleware.EnabledCORS(middleware.ContextBuilder(mux)))
@@ -0,0 +1,182 @@
+// Package stream registers Server Sent Events operations. It follows huma's
+// sse package but hands handlers a Sender that can also write comment lines,
+// which keep idle connections from being dropped by proxies.
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/danielgtaylor/huma/v2/sse"
+	"github.com/mcorrigan89/openmic/internal/common"
+	"github.com/rs/zerolog"
+)
+
+type Sender interface {
+	Send(msg sse.Message) error
+	Comment(text string) error
+}
+
+type sender struct {
+	writer      io.Writer
+	encoder     *json.Encoder
+	controller  *http.ResponseController
+	typeToEvent map[reflect.Type]string
+}
+
+func deref(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func (s *sender) prepare() {
+	if s.controller != nil {
+		s.controller.SetWriteDeadline(time.Now().Add(sse.WriteTimeout))
+	}
+}
+
+func (s *sender) flush() error {
+	if s.controller != nil {
+		return s.controller.Flush()
+	}
+	if f, ok := s.writer.(http.Flusher); ok {
+		f.Flush()
+		return nil
+	}
+	return fmt.Errorf("unable to flush: %w", http.ErrNotSupported)
+}
+
+func (s *sender) Send(msg sse.Message) error {
+	s.prepare()
+
+	if msg.ID > 0 {
+		fmt.Fprintf(s.writer, "id: %d\n", msg.ID)
+	}
+	if msg.Retry > 0 {
+		fmt.Fprintf(s.writer, "retry: %d\n", msg.Retry)
+	}
+
+	event, ok := s.typeToEvent[deref(reflect.TypeOf(msg.Data))]
+	if !ok {
+		return fmt.Errorf("unknown event type %v", reflect.TypeOf(msg.Data))
+	}
+	if event != "" && event != "message" {
+		fmt.Fprintf(s.writer, "event: %s\n", event)
+	}
+
+	if _, err := s.writer.Write([]byte("data: ")); err != nil {
+		return err
+	}
+	if err := s.encoder.Encode(msg.Data); err != nil {
+		return err
+	}
+	if _, err := s.writer.Write([]byte("\n")); err != nil {
+		return err
+	}
+
+	return s.flush()
+}
+
+func (s *sender) Comment(text string) error {
+	s.prepare()
+
+	if _, err := fmt.Fprintf(s.writer, ": %s\n\n", text); err != nil {
+		return err
+	}
+
+	return s.flush()
+}
+
+// Register an SSE operation. The eventTypeMap maps each event name to the Go
+// type sent as its data, exactly as with huma's sse.Register.
+func Register[I any](api huma.API, op huma.Operation, eventTypeMap map[string]any, f func(ctx context.Context, input *I, send Sender)) {
+	if op.Responses == nil {
+		op.Responses = map[string]*huma.Response{}
+	}
+	if op.Responses["200"] == nil {
+		op.Responses["200"] = &huma.Response{}
+	}
+	if op.Responses["200"].Content == nil {
+		op.Responses["200"].Content = map[string]*huma.MediaType{}
+	}
+
+	typeToEvent := make(map[reflect.Type]string, len(eventTypeMap))
+	dataSchemas := make([]*huma.Schema, 0, len(eventTypeMap))
+	for k, v := range eventTypeMap {
+		vt := deref(reflect.TypeOf(v))
+		typeToEvent[vt] = k
+		required := []string{"data"}
+		if k != "" && k != "message" {
+			required = append(required, "event")
+		}
+		dataSchemas = append(dataSchemas, &huma.Schema{
+			Title: "Event " + k,
+			Type:  huma.TypeObject,
+			Properties: map[string]*huma.Schema{
+				"id": {
+					Type:        huma.TypeInteger,
+					Description: "The event ID.",
+				},
+				"event": {
+					Type:        huma.TypeString,
+					Description: "The event name.",
+					Extensions: map[string]interface{}{
+						"const": k,
+					},
+				},
+				"data": api.OpenAPI().Components.Schemas.Schema(vt, true, k),
+				"retry": {
+					Type:        huma.TypeInteger,
+					Description: "The retry time in milliseconds.",
+				},
+			},
+			Required: required,
+		})
+	}
+
+	op.Responses["200"].Content["text/event-stream"] = &huma.MediaType{
+		Schema: &huma.Schema{
+			Title:       "Server Sent Events",
+			Description: "Each oneOf object in the array represents one possible Server Sent Events (SSE) message, serialized as UTF-8 text according to the SSE specification.",
+			Type:        huma.TypeArray,
+			Items: &huma.Schema{
+				Extensions: map[string]interface{}{
+					"oneOf": dataSchemas,
+				},
+			},
+		},
+	}
+
+	huma.Register(api, op, func(ctx context.Context, input *I) (*huma.StreamResponse, error) {
+		return &huma.StreamResponse{
+			Body: func(ctx huma.Context) {
+				ctx.SetHeader("Content-Type", "text/event-stream")
+				ctx.SetHeader("Cache-Control", "no-cache")
+				bw := ctx.BodyWriter()
+
+				s := &sender{
+					writer:      bw,
+					encoder:     json.NewEncoder(bw),
+					typeToEvent: typeToEvent,
+				}
+
+				if w, ok := bw.(http.ResponseWriter); ok {
+					s.controller = http.NewResponseController(w)
+				}
+
+				// The response has started, so a panic can only end the stream.
+				defer common.RecoverPanic(ctx.Context(), zerolog.Ctx(ctx.Context()), "Recovered from panic in event stream")
+
+				f(ctx.Context(), input, s)
+			},
+		}, nil
+	})
+}
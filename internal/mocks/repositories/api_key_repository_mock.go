@@ -0,0 +1,67 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockAPIKeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockAPIKeyRepository) GetAPIKeyByID(ctx context.Context, querier models.Querier, apiKeyID uuid.UUID) (*entities.APIKeyEntity, error) {
+	args := m.Called(ctx, querier, apiKeyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.APIKeyEntity), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetActiveAPIKeyByHash(ctx context.Context, querier models.Querier, keyHash string) (*entities.APIKeyEntity, error) {
+	args := m.Called(ctx, querier, keyHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.APIKeyEntity), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) GetAPIKeys(ctx context.Context, querier models.Querier) ([]*entities.APIKeyEntity, error) {
+	args := m.Called(ctx, querier)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.APIKeyEntity), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) CreateAPIKey(ctx context.Context, querier models.Querier, apiKey *entities.APIKeyEntity) (*entities.APIKeyEntity, error) {
+	args := m.Called(ctx, querier, apiKey)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.APIKeyEntity), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) RotateAPIKey(ctx context.Context, querier models.Querier, apiKeyID uuid.UUID, keyHash string) (*entities.APIKeyEntity, error) {
+	args := m.Called(ctx, querier, apiKeyID, keyHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.APIKeyEntity), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) RevokeAPIKey(ctx context.Context, querier models.Querier, apiKeyID uuid.UUID) (*entities.APIKeyEntity, error) {
+	args := m.Called(ctx, querier, apiKeyID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.APIKeyEntity), args.Error(1)
+}
+
+func (m *MockAPIKeyRepository) TouchAPIKey(ctx context.Context, querier models.Querier, apiKeyID uuid.UUID) error {
+	args := m.Called(ctx, querier, apiKeyID)
+	return args.Error(0)
+}
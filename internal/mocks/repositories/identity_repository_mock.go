@@ -0,0 +1,59 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/mcorrigan89/openmic/internal/domain/entities"
+	"github.com/mcorrigan89/openmic/internal/infrastructure/postgres/models"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIdentityRepository struct {
+	mock.Mock
+}
+
+func (m *MockIdentityRepository) GetIdentity(ctx context.Context, querier models.Querier, provider string, subject string) (*entities.IdentityEntity, error) {
+	args := m.Called(ctx, querier, provider, subject)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.IdentityEntity), args.Error(1)
+}
+
+func (m *MockIdentityRepository) GetIdentitiesByUserID(ctx context.Context, querier models.Querier, userID uuid.UUID) ([]*entities.IdentityEntity, error) {
+	args := m.Called(ctx, querier, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.IdentityEntity), args.Error(1)
+}
+
+func (m *MockIdentityRepository) CreateIdentity(ctx context.Context, querier models.Querier, identity *entities.IdentityEntity) (*entities.IdentityEntity, error) {
+	args := m.Called(ctx, querier, identity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.IdentityEntity), args.Error(1)
+}
+
+func (m *MockIdentityRepository) CreateLoginRequest(ctx context.Context, querier models.Querier, loginRequest *entities.LoginRequestEntity) (*entities.LoginRequestEntity, error) {
+	args := m.Called(ctx, querier, loginRequest)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.LoginRequestEntity), args.Error(1)
+}
+
+func (m *MockIdentityRepository) ConsumeLoginRequest(ctx context.Context, querier models.Querier, stateHash string) (*entities.LoginRequestEntity, error) {
+	args := m.Called(ctx, querier, stateHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.LoginRequestEntity), args.Error(1)
+}
+
+func (m *MockIdentityRepository) DeleteExpiredLoginRequests(ctx context.Context, querier models.Querier) error {
+	args := m.Called(ctx, querier)
+	return args.Error(0)
+}
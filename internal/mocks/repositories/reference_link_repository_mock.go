@@ -29,8 +29,8 @@ func (m *MockReferenceLinkRepository) GetReferenceLinkByID(ctx context.Context,
 	return args.Get(0).(*entities.ReferenceLinkEntity), args.Error(1)
 }
 
-func (m *MockReferenceLinkRepository) GetReferenceLinkByToken(ctx context.Context, querier models.Querier, token string) (*entities.ReferenceLinkEntity, error) {
-	args := m.Called(ctx, querier, token)
+func (m *MockReferenceLinkRepository) GetReferenceLinkByTokenHash(ctx context.Context, querier models.Querier, tokenHash string) (*entities.ReferenceLinkEntity, error) {
+	args := m.Called(ctx, querier, tokenHash)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -40,8 +40,8 @@ func (m *MockUserRepository) GetUserByHandle(ctx context.Context, querier models
 	return args.Get(0).(*entities.UserEntity), args.Error(1)
 }
 
-func (m *MockUserRepository) GetUserContextBySessionToken(ctx context.Context, querier models.Querier, sessionToken string) (*entities.UserContextEntity, error) {
-	args := m.Called(ctx, querier, sessionToken)
+func (m *MockUserRepository) GetUserContextBySessionTokenHash(ctx context.Context, querier models.Querier, tokenHash string) (*entities.UserContextEntity, error) {
+	args := m.Called(ctx, querier, tokenHash)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -64,8 +64,8 @@ func (m *MockUserRepository) UpdateUser(ctx context.Context, querier models.Quer
 	return args.Get(0).(*entities.UserEntity), args.Error(1)
 }
 
-func (m *MockUserRepository) CreateSession(ctx context.Context, querier models.Querier, user *entities.UserEntity, token string, expiresAt time.Time) (*entities.UserContextEntity, error) {
-	args := m.Called(ctx, querier, user, token, expiresAt)
+func (m *MockUserRepository) CreateSession(ctx context.Context, querier models.Querier, user *entities.UserEntity, tokenHash string, expiresAt time.Time) (*entities.UserContextEntity, error) {
+	args := m.Called(ctx, querier, user, tokenHash, expiresAt)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -79,3 +79,47 @@ func (m *MockUserRepository) SetAvatarImage(ctx context.Context, querier models.
 	}
 	return args.Get(0).(*entities.UserEntity), args.Error(1)
 }
+
+func (m *MockUserRepository) SetUserRole(ctx context.Context, querier models.Querier, userID uuid.UUID, role entities.Role) (*entities.UserEntity, error) {
+	args := m.Called(ctx, querier, userID, role)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.UserEntity), args.Error(1)
+}
+
+func (m *MockUserRepository) GetActiveSessions(ctx context.Context, querier models.Querier, userID uuid.UUID) ([]*entities.SessionEntity, error) {
+	args := m.Called(ctx, querier, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entities.SessionEntity), args.Error(1)
+}
+
+func (m *MockUserRepository) TouchSession(ctx context.Context, querier models.Querier, sessionID uuid.UUID, ipAddress string, expiresAt time.Time) error {
+	args := m.Called(ctx, querier, sessionID, ipAddress, expiresAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ExpireSession(ctx context.Context, querier models.Querier, userID uuid.UUID, sessionID uuid.UUID) error {
+	args := m.Called(ctx, querier, userID, sessionID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) ExpireAllSessions(ctx context.Context, querier models.Querier, userID uuid.UUID) error {
+	args := m.Called(ctx, querier, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) CreateImpersonationSession(ctx context.Context, querier models.Querier, user *entities.UserEntity, impersonatorID uuid.UUID, tokenHash string, expiresAt time.Time) (*entities.UserContextEntity, error) {
+	args := m.Called(ctx, querier, user, impersonatorID, tokenHash, expiresAt)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*entities.UserContextEntity), args.Error(1)
+}
+
+func (m *MockUserRepository) CreateImpersonationAudit(ctx context.Context, querier models.Querier, impersonatorID uuid.UUID, userID uuid.UUID, sessionID uuid.UUID, method string, path string) error {
+	args := m.Called(ctx, querier, impersonatorID, userID, sessionID, method, path)
+	return args.Error(0)
+}
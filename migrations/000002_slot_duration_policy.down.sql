@@ -0,0 +1,5 @@
+ALTER TABLE timeslot DROP COLUMN IF EXISTS duration_override_minutes;
+
+ALTER TABLE event DROP COLUMN IF EXISTS changeover_minutes;
+ALTER TABLE event DROP COLUMN IF EXISTS base_slot_minutes;
+ALTER TABLE event DROP COLUMN IF EXISTS minutes_per_song;
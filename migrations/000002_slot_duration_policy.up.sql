@@ -0,0 +1,5 @@
+ALTER TABLE event ADD COLUMN IF NOT EXISTS minutes_per_song integer NOT NULL DEFAULT 3;
+ALTER TABLE event ADD COLUMN IF NOT EXISTS base_slot_minutes integer NOT NULL DEFAULT 2;
+ALTER TABLE event ADD COLUMN IF NOT EXISTS changeover_minutes integer NOT NULL DEFAULT 0;
+
+ALTER TABLE timeslot ADD COLUMN IF NOT EXISTS duration_override_minutes integer;
@@ -0,0 +1 @@
+ALTER TABLE event DROP COLUMN IF EXISTS change_seq;
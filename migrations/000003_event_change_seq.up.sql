@@ -0,0 +1 @@
+ALTER TABLE event ADD COLUMN IF NOT EXISTS change_seq bigint NOT NULL DEFAULT 0;
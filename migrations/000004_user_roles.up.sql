@@ -0,0 +1,2 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS user_role TEXT NOT NULL DEFAULT 'audience'
+  CHECK (user_role IN ('admin', 'host', 'performer', 'audience'));
@@ -0,0 +1,6 @@
+ALTER TABLE artist DROP COLUMN IF EXISTS organization_id;
+ALTER TABLE event DROP COLUMN IF EXISTS venue_id;
+
+DROP TABLE IF EXISTS venue;
+DROP TABLE IF EXISTS organization_member;
+DROP TABLE IF EXISTS organization;
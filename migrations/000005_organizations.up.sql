@@ -0,0 +1,57 @@
+CREATE TABLE IF NOT EXISTS organization (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  organization_name TEXT NOT NULL,
+  organization_handle TEXT NOT NULL UNIQUE,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  version integer NOT NULL DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS organization_member (
+  organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE TABLE IF NOT EXISTS venue (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+  venue_name TEXT NOT NULL,
+  venue_address TEXT,
+  created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  version integer NOT NULL DEFAULT 1
+);
+
+CREATE INDEX IF NOT EXISTS venue_organization_id_idx ON venue (organization_id);
+
+ALTER TABLE event ADD COLUMN IF NOT EXISTS venue_id UUID REFERENCES venue(id) ON DELETE CASCADE;
+ALTER TABLE artist ADD COLUMN IF NOT EXISTS organization_id UUID REFERENCES organization(id) ON DELETE CASCADE;
+
+-- Everything created before organizations existed belongs to one default
+-- organization and venue. No account holds a role above audience yet, so the
+-- organization starts without members: until an admin adds them, only admins
+-- and global API keys can manage what was migrated into it.
+DO $$
+DECLARE
+  default_organization_id UUID := uuid_generate_v4();
+  default_venue_id UUID := uuid_generate_v4();
+BEGIN
+  IF EXISTS (SELECT 1 FROM event WHERE venue_id IS NULL) OR EXISTS (SELECT 1 FROM artist WHERE organization_id IS NULL) THEN
+    INSERT INTO organization (id, organization_name, organization_handle)
+    VALUES (default_organization_id, 'Open Mic', 'default');
+
+    INSERT INTO venue (id, organization_id, venue_name)
+    VALUES (default_venue_id, default_organization_id, 'Main Stage');
+
+    UPDATE event SET venue_id = default_venue_id WHERE venue_id IS NULL;
+    UPDATE artist SET organization_id = default_organization_id WHERE organization_id IS NULL;
+  END IF;
+END $$;
+
+ALTER TABLE event ALTER COLUMN venue_id SET NOT NULL;
+ALTER TABLE artist ALTER COLUMN organization_id SET NOT NULL;
+
+CREATE INDEX IF NOT EXISTS event_venue_id_idx ON event (venue_id);
+CREATE INDEX IF NOT EXISTS artist_organization_id_idx ON artist (organization_id);
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS user_session_user_id_idx;
+DROP INDEX IF EXISTS user_session_token_idx;
+
+ALTER TABLE user_session DROP COLUMN IF EXISTS ip_address;
+ALTER TABLE user_session DROP COLUMN IF EXISTS last_seen_at;
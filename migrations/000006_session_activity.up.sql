@@ -0,0 +1,7 @@
+ALTER TABLE user_session ADD COLUMN IF NOT EXISTS last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP;
+ALTER TABLE user_session ADD COLUMN IF NOT EXISTS ip_address TEXT;
+
+UPDATE user_session SET last_seen_at = COALESCE(updated_at, created_at, CURRENT_TIMESTAMP);
+
+CREATE INDEX IF NOT EXISTS user_session_token_idx ON user_session (token);
+CREATE INDEX IF NOT EXISTS user_session_user_id_idx ON user_session (user_id);
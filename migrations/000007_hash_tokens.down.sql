@@ -0,0 +1,9 @@
+-- Hashes cannot be turned back into tokens, so everything issued is revoked.
+DROP INDEX IF EXISTS reference_link_token_hash_idx;
+DELETE FROM reference_link;
+ALTER TABLE reference_link RENAME COLUMN token_hash TO token;
+
+DROP INDEX IF EXISTS user_session_token_hash_idx;
+UPDATE user_session SET user_expired = TRUE;
+ALTER TABLE user_session RENAME COLUMN token_hash TO token;
+CREATE INDEX IF NOT EXISTS user_session_token_idx ON user_session (token);
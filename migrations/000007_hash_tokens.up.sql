@@ -0,0 +1,11 @@
+-- Only a SHA-256 hash of each token is kept. Tokens issued before this were
+-- guessable, so they are hashed only to keep the column unique and every
+-- session and link already handed out is expired.
+ALTER TABLE user_session RENAME COLUMN token TO token_hash;
+UPDATE user_session SET token_hash = encode(sha256(convert_to(token_hash, 'UTF8')), 'hex'), user_expired = TRUE;
+DROP INDEX IF EXISTS user_session_token_idx;
+CREATE UNIQUE INDEX IF NOT EXISTS user_session_token_hash_idx ON user_session (token_hash);
+
+ALTER TABLE reference_link RENAME COLUMN token TO token_hash;
+UPDATE reference_link SET token_hash = encode(sha256(convert_to(token_hash, 'UTF8')), 'hex'), expires_at = LEAST(expires_at, NOW());
+CREATE UNIQUE INDEX IF NOT EXISTS reference_link_token_hash_idx ON reference_link (token_hash);
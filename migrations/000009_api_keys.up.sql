@@ -0,0 +1,14 @@
+CREATE TABLE IF NOT EXISTS api_key (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  key_name TEXT NOT NULL,
+  key_hash TEXT NOT NULL UNIQUE,
+  scopes TEXT[] NOT NULL DEFAULT '{}',
+  organization_id UUID REFERENCES organization(id) ON DELETE CASCADE,
+  rate_limit_per_minute INTEGER NOT NULL DEFAULT 120,
+  created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+  last_used_at TIMESTAMP WITH TIME ZONE,
+  revoked_at TIMESTAMP WITH TIME ZONE,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+  version integer NOT NULL DEFAULT 1
+);
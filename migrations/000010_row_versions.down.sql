@@ -0,0 +1,13 @@
+DROP TRIGGER IF EXISTS api_key_set_updated_at ON api_key;
+DROP TRIGGER IF EXISTS user_identity_set_updated_at ON user_identity;
+DROP TRIGGER IF EXISTS venue_set_updated_at ON venue;
+DROP TRIGGER IF EXISTS organization_set_updated_at ON organization;
+DROP TRIGGER IF EXISTS timeslot_set_updated_at ON timeslot;
+DROP TRIGGER IF EXISTS artist_set_updated_at ON artist;
+DROP TRIGGER IF EXISTS event_set_updated_at ON event;
+DROP TRIGGER IF EXISTS reference_link_set_updated_at ON reference_link;
+DROP TRIGGER IF EXISTS user_session_set_updated_at ON user_session;
+DROP TRIGGER IF EXISTS users_set_updated_at ON users;
+DROP TRIGGER IF EXISTS images_set_updated_at ON images;
+
+DROP FUNCTION IF EXISTS set_updated_at();
@@ -0,0 +1 @@
+ALTER TABLE timeslot DROP CONSTRAINT IF EXISTS timeslot_event_id_sort_key_key;
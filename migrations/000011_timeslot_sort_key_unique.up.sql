@@ -0,0 +1,11 @@
+-- Concurrent sign-ups could give two timeslots the same key. Every duplicate
+-- after the first gets its own key just after the original.
+UPDATE timeslot
+SET sort_key = timeslot.sort_key || repeat('V', duplicate.position::integer - 1)
+FROM (
+  SELECT id, row_number() OVER (PARTITION BY event_id, sort_key ORDER BY created_at, id) AS position
+  FROM timeslot
+) AS duplicate
+WHERE timeslot.id = duplicate.id AND duplicate.position > 1;
+
+ALTER TABLE timeslot ADD CONSTRAINT timeslot_event_id_sort_key_key UNIQUE (event_id, sort_key);
@@ -0,0 +1,2 @@
+ALTER TABLE timeslot DROP CONSTRAINT IF EXISTS timeslot_event_id_sort_key_key;
+ALTER TABLE timeslot ADD CONSTRAINT timeslot_event_id_sort_key_key UNIQUE (event_id, sort_key);
@@ -0,0 +1,4 @@
+-- Reordering a lineup rewrites all of its keys in one statement, which can swap
+-- two of them. A deferrable constraint is checked once the statement is done.
+ALTER TABLE timeslot DROP CONSTRAINT IF EXISTS timeslot_event_id_sort_key_key;
+ALTER TABLE timeslot ADD CONSTRAINT timeslot_event_id_sort_key_key UNIQUE (event_id, sort_key) DEFERRABLE INITIALLY IMMEDIATE;
@@ -0,0 +1,3 @@
+ALTER TABLE timeslot DROP COLUMN IF EXISTS notes;
+
+ALTER TABLE event DROP COLUMN IF EXISTS allow_duplicate_artists;
@@ -0,0 +1,4 @@
+-- Whether the same artist may hold more than one timeslot in an event's lineup.
+ALTER TABLE event ADD COLUMN IF NOT EXISTS allow_duplicate_artists boolean NOT NULL DEFAULT true;
+
+ALTER TABLE timeslot ADD COLUMN IF NOT EXISTS notes text;
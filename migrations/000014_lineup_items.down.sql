@@ -0,0 +1,5 @@
+DELETE FROM timeslot WHERE item_type <> 'ARTIST';
+
+ALTER TABLE timeslot DROP CONSTRAINT IF EXISTS timeslot_item_type_check;
+ALTER TABLE timeslot ALTER COLUMN artist_id SET NOT NULL;
+ALTER TABLE timeslot DROP COLUMN IF EXISTS item_type;
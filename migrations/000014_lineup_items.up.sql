@@ -0,0 +1,10 @@
+-- Lineup items that are not artist sets, such as breaks, share the timeslot
+-- ordering. They carry their own label and duration instead of an artist.
+ALTER TABLE timeslot ADD COLUMN IF NOT EXISTS item_type text NOT NULL DEFAULT 'ARTIST';
+ALTER TABLE timeslot ALTER COLUMN artist_id DROP NOT NULL;
+
+ALTER TABLE timeslot DROP CONSTRAINT IF EXISTS timeslot_item_type_check;
+ALTER TABLE timeslot ADD CONSTRAINT timeslot_item_type_check CHECK (
+  (item_type = 'ARTIST' AND artist_id IS NOT NULL)
+  OR (item_type <> 'ARTIST' AND artist_id IS NULL AND artist_name_override IS NOT NULL AND duration_override_minutes IS NOT NULL)
+);
@@ -0,0 +1,25 @@
+DROP INDEX IF EXISTS timeslot_marker_playing_idx;
+ALTER TABLE timeslot_marker DROP CONSTRAINT IF EXISTS timeslot_marker_timeslot_id_marker_type_key;
+ALTER TABLE timeslot_marker DROP CONSTRAINT IF EXISTS timeslot_marker_type_check;
+
+ALTER TABLE timeslot_marker ADD COLUMN IF NOT EXISTS timeslot_index integer;
+ALTER TABLE timeslot_marker ADD COLUMN IF NOT EXISTS marker_value TEXT;
+
+UPDATE timeslot_marker
+SET timeslot_index = lineup.position
+FROM (
+  SELECT id, row_number() OVER (PARTITION BY event_id ORDER BY sort_key) - 1 AS position
+  FROM timeslot
+) AS lineup
+WHERE lineup.id = timeslot_marker.timeslot_id;
+
+UPDATE timeslot_marker
+SET marker_value = CASE
+  WHEN marker_type = 'PLAYING' THEN 'Playing'
+  ELSE to_char(marker_time AT TIME ZONE 'UTC', 'Dy, DD Mon YYYY HH24:MI:SS +0000')
+END;
+
+ALTER TABLE timeslot_marker ALTER COLUMN timeslot_index SET NOT NULL;
+ALTER TABLE timeslot_marker ALTER COLUMN marker_value SET NOT NULL;
+ALTER TABLE timeslot_marker DROP COLUMN IF EXISTS marker_time;
+ALTER TABLE timeslot_marker DROP COLUMN IF EXISTS timeslot_id;
@@ -0,0 +1,61 @@
+-- Markers used to point at a zero-based position in the lineup and keep their
+-- time as display text. They now belong to a timeslot and keep a real time.
+ALTER TABLE timeslot_marker ADD COLUMN IF NOT EXISTS timeslot_id UUID REFERENCES timeslot(id) ON DELETE CASCADE;
+ALTER TABLE timeslot_marker ADD COLUMN IF NOT EXISTS marker_time TIMESTAMP WITH TIME ZONE;
+
+CREATE OR REPLACE FUNCTION pg_temp.try_timestamptz(value text) RETURNS timestamptz AS $$
+BEGIN
+  RETURN value::timestamptz;
+EXCEPTION WHEN others THEN
+  RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+UPDATE timeslot_marker SET marker_type = upper(marker_type);
+
+UPDATE timeslot_marker
+SET timeslot_id = lineup.id
+FROM (
+  SELECT id, event_id, row_number() OVER (PARTITION BY event_id ORDER BY sort_key) - 1 AS position
+  FROM timeslot
+) AS lineup
+WHERE lineup.event_id = timeslot_marker.event_id AND lineup.position = timeslot_marker.timeslot_index;
+
+UPDATE timeslot_marker
+SET marker_time = pg_temp.try_timestamptz(marker_value)
+WHERE marker_type = 'TIME';
+
+-- Markers past the end of the lineup, of an unknown kind, or with a time that
+-- cannot be read have nothing left to show.
+DELETE FROM timeslot_marker
+WHERE timeslot_id IS NULL
+  OR marker_type NOT IN ('TIME', 'PLAYING')
+  OR (marker_type = 'TIME' AND marker_time IS NULL);
+
+DELETE FROM timeslot_marker
+USING (
+  SELECT id, row_number() OVER (PARTITION BY timeslot_id, marker_type ORDER BY id) AS position
+  FROM timeslot_marker
+) AS duplicate
+WHERE timeslot_marker.id = duplicate.id AND duplicate.position > 1;
+
+DELETE FROM timeslot_marker
+USING (
+  SELECT id, row_number() OVER (PARTITION BY event_id ORDER BY id) AS position
+  FROM timeslot_marker
+  WHERE marker_type = 'PLAYING'
+) AS duplicate
+WHERE timeslot_marker.id = duplicate.id AND duplicate.position > 1;
+
+UPDATE timeslot_marker SET marker_time = NULL WHERE marker_type = 'PLAYING';
+
+ALTER TABLE timeslot_marker ALTER COLUMN timeslot_id SET NOT NULL;
+ALTER TABLE timeslot_marker DROP COLUMN IF EXISTS timeslot_index;
+ALTER TABLE timeslot_marker DROP COLUMN IF EXISTS marker_value;
+
+ALTER TABLE timeslot_marker ADD CONSTRAINT timeslot_marker_type_check CHECK (
+  (marker_type = 'TIME' AND marker_time IS NOT NULL)
+  OR (marker_type = 'PLAYING' AND marker_time IS NULL)
+);
+ALTER TABLE timeslot_marker ADD CONSTRAINT timeslot_marker_timeslot_id_marker_type_key UNIQUE (timeslot_id, marker_type);
+CREATE UNIQUE INDEX IF NOT EXISTS timeslot_marker_playing_idx ON timeslot_marker (event_id) WHERE marker_type = 'PLAYING';
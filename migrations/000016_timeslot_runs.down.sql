@@ -0,0 +1 @@
+DROP TABLE IF EXISTS timeslot_run;
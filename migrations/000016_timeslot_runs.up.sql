@@ -0,0 +1,14 @@
+-- The run log: when each timeslot actually went on stage and came off.
+CREATE TABLE IF NOT EXISTS timeslot_run (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  event_id UUID NOT NULL REFERENCES event(id) ON DELETE CASCADE,
+  timeslot_id UUID NOT NULL REFERENCES timeslot(id) ON DELETE CASCADE,
+  started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  ended_at TIMESTAMP WITH TIME ZONE,
+  CONSTRAINT timeslot_run_ended_check CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+CREATE INDEX IF NOT EXISTS timeslot_run_event_idx ON timeslot_run (event_id, started_at);
+
+-- Only one timeslot is on stage at a time.
+CREATE UNIQUE INDEX IF NOT EXISTS timeslot_run_open_idx ON timeslot_run (event_id) WHERE ended_at IS NULL;
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS event_venue_start_time_idx;
+
+DROP TABLE IF EXISTS event_type_window;
+
+ALTER TABLE venue DROP CONSTRAINT IF EXISTS venue_current_window_check;
+ALTER TABLE venue DROP COLUMN IF EXISTS current_minutes_after;
+ALTER TABLE venue DROP COLUMN IF EXISTS current_minutes_before;
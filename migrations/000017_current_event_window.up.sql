@@ -0,0 +1,19 @@
+-- How long before and after its start an event counts as the current one. A
+-- venue's own window wins over its organization's window for the event type;
+-- without either, only open mics count, from 30 hours before to 8 hours after.
+ALTER TABLE venue ADD COLUMN IF NOT EXISTS current_minutes_before integer;
+ALTER TABLE venue ADD COLUMN IF NOT EXISTS current_minutes_after integer;
+ALTER TABLE venue ADD CONSTRAINT venue_current_window_check CHECK (
+  (current_minutes_before IS NULL) = (current_minutes_after IS NULL)
+  AND (current_minutes_before IS NULL OR (current_minutes_before >= 0 AND current_minutes_after >= 0))
+);
+
+CREATE TABLE IF NOT EXISTS event_type_window (
+  organization_id UUID NOT NULL REFERENCES organization(id) ON DELETE CASCADE,
+  event_type TEXT NOT NULL,
+  minutes_before integer NOT NULL CHECK (minutes_before >= 0),
+  minutes_after integer NOT NULL CHECK (minutes_after >= 0),
+  PRIMARY KEY (organization_id, event_type)
+);
+
+CREATE INDEX IF NOT EXISTS event_venue_start_time_idx ON event (venue_id, start_time);
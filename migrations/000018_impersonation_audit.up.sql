@@ -0,0 +1,14 @@
+-- Every mutating request made through an impersonation session. Rows outlive
+-- the accounts and sessions they name, so there are no foreign keys.
+CREATE TABLE IF NOT EXISTS impersonation_audit (
+  id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+  impersonator_id UUID NOT NULL,
+  user_id UUID NOT NULL,
+  session_id UUID NOT NULL,
+  request_method TEXT NOT NULL,
+  request_path TEXT NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS impersonation_audit_impersonator_idx ON impersonation_audit (impersonator_id, created_at);
+CREATE INDEX IF NOT EXISTS impersonation_audit_user_idx ON impersonation_audit (user_id, created_at);
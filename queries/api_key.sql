@@ -0,0 +1,41 @@
+-- name: GetAPIKeyByID :one
+SELECT sqlc.embed(api_key) FROM api_key
+WHERE api_key.id = $1;
+
+-- name: GetActiveAPIKeyByHash :one
+SELECT sqlc.embed(api_key) FROM api_key
+WHERE api_key.key_hash = sqlc.arg(key_hash) AND api_key.revoked_at IS NULL;
+
+-- name: GetAPIKeys :many
+SELECT sqlc.embed(api_key) FROM api_key
+ORDER BY api_key.created_at;
+
+-- name: CreateAPIKey :one
+INSERT INTO api_key (id, key_name, key_hash, scopes, organization_id, rate_limit_per_minute, created_by)
+VALUES (
+    sqlc.arg(id),
+    sqlc.arg(key_name),
+    sqlc.arg(key_hash),
+    sqlc.arg(scopes)::text[],
+    sqlc.narg(organization_id),
+    sqlc.arg(rate_limit_per_minute),
+    sqlc.narg(created_by)
+) RETURNING *;
+
+-- name: RotateAPIKey :one
+UPDATE api_key SET
+    key_hash = sqlc.arg(key_hash),
+    updated_at = now(),
+    version = version + 1
+WHERE api_key.id = sqlc.arg(id) AND api_key.revoked_at IS NULL RETURNING *;
+
+-- name: RevokeAPIKey :one
+UPDATE api_key SET
+    revoked_at = now(),
+    updated_at = now(),
+    version = version + 1
+WHERE api_key.id = sqlc.arg(id) AND api_key.revoked_at IS NULL RETURNING *;
+
+-- name: TouchAPIKey :exec
+UPDATE api_key SET last_used_at = now()
+WHERE api_key.id = $1 AND (api_key.last_used_at IS NULL OR api_key.last_used_at < now() - interval '1 minute');
@@ -4,22 +4,23 @@ WHERE artist.id = $1;
 
 -- name: GetAllArtists :many
 SELECT sqlc.embed(artist) FROM artist
+WHERE artist.organization_id = sqlc.arg(organization_id)
 ORDER BY artist.artist_title ASC;
 
 -- name: GetArtistsByTitle :many
 SELECT sqlc.embed(artist) FROM artist
-WHERE similarity(artist.artist_title, sqlc.arg(title)) > sqlc.arg(min_similarity)
+WHERE artist.organization_id = sqlc.arg(organization_id) AND similarity(artist.artist_title, sqlc.arg(title)) > sqlc.arg(min_similarity)
 ORDER BY similarity(artist.artist_title, sqlc.arg(title)) DESC;
 
 -- name: CreateArtist :one
-INSERT INTO artist (id, artist_title, artist_subtitle, bio, avatar_id)
-VALUES ($1, $2, $3, $4, $5) RETURNING *;
+INSERT INTO artist (id, artist_title, artist_subtitle, bio, avatar_id, user_id, organization_id)
+VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING *;
 
 -- name: UpdateArtist :one
 UPDATE artist
-SET artist_title = $2, artist_subtitle = $3, bio = $4, avatar_id = $5
-WHERE id = $1 RETURNING *;
+SET artist_title = $2, artist_subtitle = $3, bio = $4, avatar_id = $5, version = version + 1
+WHERE id = $1 AND version = $6 RETURNING *;
 
--- name: DeleteArtist :exec
+-- name: DeleteArtist :execrows
 DELETE FROM artist
-WHERE id = $1;
\ No newline at end of file
+WHERE id = $1 AND version = $2;
\ No newline at end of file
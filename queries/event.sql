@@ -4,48 +4,95 @@ LEFT JOIN timeslot_marker ON event.id = timeslot_marker.event_id
 WHERE event.id = sqlc.arg(id)
 GROUP BY event.id;
 
+-- name: LockEvent :one
+SELECT id FROM event
+WHERE id = sqlc.arg(id)
+FOR UPDATE;
+
 -- name: CreateEvent :one
-INSERT INTO event (id, event_type, start_time, end_time)
-VALUES (sqlc.arg(id), sqlc.arg(event_type), sqlc.arg(start_time), sqlc.arg(end_time)) RETURNING *;
+INSERT INTO event (id, venue_id, event_type, start_time, end_time, minutes_per_song, base_slot_minutes, changeover_minutes, allow_duplicate_artists)
+VALUES (sqlc.arg(id), sqlc.arg(venue_id), sqlc.arg(event_type), sqlc.arg(start_time), sqlc.arg(end_time), sqlc.arg(minutes_per_song), sqlc.arg(base_slot_minutes), sqlc.arg(changeover_minutes), sqlc.arg(allow_duplicate_artists)) RETURNING *;
 
 -- name: UpdateEvent :one
 UPDATE event
-SET event_type = sqlc.arg(event_type), start_time = sqlc.arg(start_time), end_time = sqlc.arg(end_time)
-WHERE id = sqlc.arg(id) RETURNING *;
+SET event_type = sqlc.arg(event_type), start_time = sqlc.arg(start_time), end_time = sqlc.arg(end_time),
+    minutes_per_song = sqlc.arg(minutes_per_song), base_slot_minutes = sqlc.arg(base_slot_minutes), changeover_minutes = sqlc.arg(changeover_minutes),
+    allow_duplicate_artists = sqlc.arg(allow_duplicate_artists), version = version + 1
+WHERE id = sqlc.arg(id) AND version = sqlc.arg(version) RETURNING *;
 
--- name: DeleteEvent :exec
+-- name: DeleteEvent :execrows
 DELETE FROM event
-WHERE id = sqlc.arg(id);
+WHERE id = sqlc.arg(id) AND version = sqlc.arg(version);
 
 -- name: GetAllEvents :many
 SELECT sqlc.embed(event), COALESCE(json_agg(timeslot_marker.*) FILTER (WHERE timeslot_marker.event_id IS NOT NULL), '[]')::json as markers FROM event
+JOIN venue ON event.venue_id = venue.id
 LEFT JOIN timeslot_marker ON event.id = timeslot_marker.event_id
-WHERE event.start_time >= sqlc.arg(start_time)
+WHERE venue.organization_id = sqlc.arg(organization_id) AND event.start_time >= sqlc.arg(start_time)
 GROUP BY event.id
 ORDER BY event.start_time ASC;
 
--- name: AddArtistToEvent :exec
-INSERT INTO timeslot (id, event_id, artist_id, artist_name_override, sort_key)
-VALUES (sqlc.arg(id), sqlc.arg(event_id), sqlc.arg(artist_id), sqlc.narg(artist_name_override), sqlc.arg(sort_key));
+-- name: GetCurrentEvent :one
+-- The current event is the one whose window holds now. A show with a timeslot
+-- on stage wins over the others, then the one starting closest to now.
+SELECT sqlc.embed(event), COALESCE(json_agg(timeslot_marker.*) FILTER (WHERE timeslot_marker.event_id IS NOT NULL), '[]')::json as markers,
+  COALESCE(venue.current_minutes_before, event_type_window.minutes_before, sqlc.arg(default_minutes_before)::integer)::integer AS minutes_before,
+  COALESCE(venue.current_minutes_after, event_type_window.minutes_after, sqlc.arg(default_minutes_after)::integer)::integer AS minutes_after
+FROM event
+JOIN venue ON event.venue_id = venue.id
+LEFT JOIN event_type_window ON event_type_window.organization_id = venue.organization_id AND event_type_window.event_type = event.event_type
+LEFT JOIN timeslot_marker ON event.id = timeslot_marker.event_id
+WHERE venue.organization_id = sqlc.arg(organization_id)
+  AND (sqlc.narg(venue_id)::uuid IS NULL OR venue.id = sqlc.narg(venue_id)::uuid)
+  AND (venue.current_minutes_before IS NOT NULL OR event_type_window.event_type IS NOT NULL OR event.event_type = sqlc.arg(default_event_type)::text)
+  AND sqlc.arg(now)::timestamptz >= event.start_time - make_interval(mins => COALESCE(venue.current_minutes_before, event_type_window.minutes_before, sqlc.arg(default_minutes_before)::integer))
+  AND sqlc.arg(now)::timestamptz <= event.start_time + make_interval(mins => COALESCE(venue.current_minutes_after, event_type_window.minutes_after, sqlc.arg(default_minutes_after)::integer))
+GROUP BY event.id, venue.id, event_type_window.organization_id, event_type_window.event_type
+ORDER BY EXISTS (SELECT 1 FROM timeslot_run WHERE timeslot_run.event_id = event.id AND timeslot_run.ended_at IS NULL) DESC,
+  abs(extract(epoch FROM event.start_time - sqlc.arg(now)::timestamptz)) ASC
+LIMIT 1;
+
+-- name: AddArtistToEvent :execrows
+INSERT INTO timeslot (id, event_id, artist_id, artist_name_override, sort_key, song_count)
+SELECT sqlc.arg(id), event.id, artist.id, sqlc.narg(artist_name_override), sqlc.arg(sort_key), sqlc.arg(song_count)
+FROM event
+JOIN venue ON venue.id = event.venue_id
+JOIN artist ON artist.organization_id = venue.organization_id
+WHERE event.id = sqlc.arg(event_id) AND artist.id = sqlc.arg(artist_id);
+
+-- name: AddLineupItem :exec
+INSERT INTO timeslot (id, event_id, item_type, artist_name_override, sort_key, song_count, duration_override_minutes, notes)
+VALUES (sqlc.arg(id), sqlc.arg(event_id), sqlc.arg(item_type), sqlc.arg(title)::text, sqlc.arg(sort_key), 0, sqlc.arg(duration_minutes)::integer, sqlc.narg(notes));
 
 -- name: RemoveArtistFromEvent :exec
 DELETE FROM timeslot
 WHERE event_id = sqlc.arg(event_id) AND artist_id = sqlc.arg(artist_id);
 
 -- name: TimeSlotsByEventID :many
-SELECT sqlc.embed(timeslot), sqlc.embed(artist) FROM timeslot
-JOIN artist ON timeslot.artist_id = artist.id
+SELECT sqlc.embed(timeslot), to_json(artist.*) AS artist FROM timeslot
+LEFT JOIN artist ON timeslot.artist_id = artist.id
 WHERE timeslot.event_id = sqlc.arg(event_id)
 ORDER BY timeslot.sort_key ASC;
 
--- name: UpdateTimeSlot :many
+-- name: UpdateTimeSlot :execrows
 UPDATE timeslot
-SET artist_name_override = sqlc.arg(artist_name_override), sort_key = sqlc.arg(sort_key), song_count = sqlc.arg(song_count)
-WHERE id = sqlc.arg(id) RETURNING *;
+SET artist_name_override = sqlc.narg(artist_name_override), sort_key = sqlc.arg(sort_key), song_count = sqlc.arg(song_count), duration_override_minutes = sqlc.narg(duration_override_minutes),
+    notes = sqlc.narg(notes), version = version + 1
+WHERE id = sqlc.arg(id) AND version = sqlc.arg(version);
+
+-- name: DeleteTimeSlot :execrows
+DELETE FROM timeslot
+WHERE id = sqlc.arg(id) AND event_id = sqlc.arg(event_id) AND version = sqlc.arg(version);
+
+-- name: SetTimeslotSortKeys :execrows
+UPDATE timeslot
+SET sort_key = new_order.sort_key, version = timeslot.version + 1
+FROM (SELECT unnest(sqlc.arg(ids)::uuid[]) AS id, unnest(sqlc.arg(sort_keys)::text[]) AS sort_key) AS new_order
+WHERE timeslot.id = new_order.id AND timeslot.event_id = sqlc.arg(event_id);
 
 -- name: CreateTimeslotMarker :one
-INSERT INTO timeslot_marker (id, event_id, marker_type, marker_value, timeslot_index)
-VALUES (sqlc.arg(id), sqlc.arg(event_id), sqlc.arg(marker_type), sqlc.arg(marker_value), sqlc.arg(timeslot_index)) RETURNING *;
+INSERT INTO timeslot_marker (id, event_id, timeslot_id, marker_type, marker_time)
+VALUES (sqlc.arg(id), sqlc.arg(event_id), sqlc.arg(timeslot_id), sqlc.arg(marker_type), sqlc.narg(marker_time)) RETURNING *;
 
 -- name: DeleteTimeslotMarker :exec
 DELETE FROM timeslot_marker
@@ -53,5 +100,24 @@ WHERE id = sqlc.arg(id);
 
 -- name: UpdateTimeslotMarker :one
 UPDATE timeslot_marker
-SET marker_type = sqlc.arg(marker_type), marker_value = sqlc.arg(marker_value), timeslot_index = sqlc.arg(timeslot_index)
-WHERE id = sqlc.arg(id) RETURNING *;
\ No newline at end of file
+SET timeslot_id = sqlc.arg(timeslot_id), marker_type = sqlc.arg(marker_type), marker_time = sqlc.narg(marker_time)
+WHERE id = sqlc.arg(id) RETURNING *;
+
+-- name: NextEventChangeSeq :one
+UPDATE event
+SET change_seq = change_seq + 1
+WHERE id = sqlc.arg(id) RETURNING change_seq;
+
+-- name: TimeSlotRunsByEventID :many
+SELECT * FROM timeslot_run
+WHERE event_id = sqlc.arg(event_id)
+ORDER BY started_at, id;
+
+-- name: StartTimeSlotRun :one
+INSERT INTO timeslot_run (id, event_id, timeslot_id, started_at)
+VALUES (sqlc.arg(id), sqlc.arg(event_id), sqlc.arg(timeslot_id), sqlc.arg(started_at)) RETURNING *;
+
+-- name: EndTimeSlotRuns :exec
+UPDATE timeslot_run
+SET ended_at = sqlc.arg(ended_at)
+WHERE event_id = sqlc.arg(event_id) AND ended_at IS NULL;
@@ -0,0 +1,22 @@
+-- name: GetUserIdentity :one
+SELECT sqlc.embed(user_identity) FROM user_identity
+WHERE user_identity.provider = sqlc.arg(provider) AND user_identity.subject = sqlc.arg(subject);
+
+-- name: GetUserIdentitiesByUserID :many
+SELECT sqlc.embed(user_identity) FROM user_identity
+WHERE user_identity.user_id = $1
+ORDER BY user_identity.created_at;
+
+-- name: CreateUserIdentity :one
+INSERT INTO user_identity (id, user_id, provider, subject, email)
+VALUES (sqlc.arg(id), sqlc.arg(user_id), sqlc.arg(provider), sqlc.arg(subject), sqlc.arg(email)) RETURNING *;
+
+-- name: CreateOIDCLoginRequest :one
+INSERT INTO oidc_login_request (state_hash, provider, nonce, code_verifier, expires_at)
+VALUES (sqlc.arg(state_hash), sqlc.arg(provider), sqlc.arg(nonce), sqlc.arg(code_verifier), sqlc.arg(expires_at)) RETURNING *;
+
+-- name: ConsumeOIDCLoginRequest :one
+DELETE FROM oidc_login_request WHERE oidc_login_request.state_hash = sqlc.arg(state_hash) RETURNING *;
+
+-- name: DeleteExpiredOIDCLoginRequests :exec
+DELETE FROM oidc_login_request WHERE oidc_login_request.expires_at < now();
@@ -0,0 +1,93 @@
+-- name: GetOrganizationByID :one
+SELECT sqlc.embed(organization) FROM organization
+WHERE organization.id = sqlc.arg(id);
+
+-- name: GetOrganizationByVenueID :one
+SELECT sqlc.embed(organization) FROM organization
+JOIN venue ON venue.organization_id = organization.id
+WHERE venue.id = sqlc.arg(venue_id);
+
+-- name: GetOrganizationByEventID :one
+SELECT sqlc.embed(organization) FROM organization
+JOIN venue ON venue.organization_id = organization.id
+JOIN event ON event.venue_id = venue.id
+WHERE event.id = sqlc.arg(event_id);
+
+-- name: GetOrganizationByArtistID :one
+SELECT sqlc.embed(organization) FROM organization
+JOIN artist ON artist.organization_id = organization.id
+WHERE artist.id = sqlc.arg(artist_id);
+
+-- name: GetOrganizationsByUserID :many
+SELECT sqlc.embed(organization) FROM organization
+JOIN organization_member ON organization_member.organization_id = organization.id
+WHERE organization_member.user_id = sqlc.arg(user_id)
+ORDER BY organization.organization_name ASC;
+
+-- name: CreateOrganization :one
+INSERT INTO organization (id, organization_name, organization_handle)
+VALUES (sqlc.arg(id), sqlc.arg(organization_name), sqlc.arg(organization_handle)) RETURNING *;
+
+-- name: UpdateOrganization :one
+UPDATE organization
+SET organization_name = sqlc.arg(organization_name), organization_handle = sqlc.arg(organization_handle)
+WHERE id = sqlc.arg(id) RETURNING *;
+
+-- name: GetOrganizationMembers :many
+SELECT sqlc.embed(users) FROM users
+JOIN organization_member ON organization_member.user_id = users.id
+WHERE organization_member.organization_id = sqlc.arg(organization_id)
+ORDER BY users.email ASC;
+
+-- name: IsOrganizationMember :one
+SELECT EXISTS (
+    SELECT 1 FROM organization_member
+    WHERE organization_id = sqlc.arg(organization_id) AND user_id = sqlc.arg(user_id)
+);
+
+-- name: AddOrganizationMember :exec
+INSERT INTO organization_member (organization_id, user_id)
+VALUES (sqlc.arg(organization_id), sqlc.arg(user_id))
+ON CONFLICT DO NOTHING;
+
+-- name: RemoveOrganizationMember :exec
+DELETE FROM organization_member
+WHERE organization_id = sqlc.arg(organization_id) AND user_id = sqlc.arg(user_id);
+
+-- name: GetVenueByID :one
+SELECT sqlc.embed(venue) FROM venue
+WHERE venue.id = sqlc.arg(id);
+
+-- name: GetVenuesByOrganizationID :many
+SELECT sqlc.embed(venue) FROM venue
+WHERE venue.organization_id = sqlc.arg(organization_id)
+ORDER BY venue.venue_name ASC;
+
+-- name: CreateVenue :one
+INSERT INTO venue (id, organization_id, venue_name, venue_address, current_minutes_before, current_minutes_after)
+VALUES (sqlc.arg(id), sqlc.arg(organization_id), sqlc.arg(venue_name), sqlc.narg(venue_address), sqlc.narg(current_minutes_before), sqlc.narg(current_minutes_after)) RETURNING *;
+
+-- name: UpdateVenue :one
+UPDATE venue
+SET venue_name = sqlc.arg(venue_name), venue_address = sqlc.narg(venue_address), current_minutes_before = sqlc.narg(current_minutes_before), current_minutes_after = sqlc.narg(current_minutes_after)
+WHERE id = sqlc.arg(id) RETURNING *;
+
+-- name: DeleteVenue :exec
+DELETE FROM venue
+WHERE id = sqlc.arg(id);
+
+-- name: GetEventTypeWindows :many
+SELECT * FROM event_type_window
+WHERE organization_id = sqlc.arg(organization_id)
+ORDER BY event_type ASC;
+
+-- name: SetEventTypeWindow :one
+INSERT INTO event_type_window (organization_id, event_type, minutes_before, minutes_after)
+VALUES (sqlc.arg(organization_id), sqlc.arg(event_type), sqlc.arg(minutes_before), sqlc.arg(minutes_after))
+ON CONFLICT (organization_id, event_type) DO UPDATE
+SET minutes_before = EXCLUDED.minutes_before, minutes_after = EXCLUDED.minutes_after
+RETURNING *;
+
+-- name: DeleteEventTypeWindow :execrows
+DELETE FROM event_type_window
+WHERE organization_id = sqlc.arg(organization_id) AND event_type = sqlc.arg(event_type);
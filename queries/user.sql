@@ -10,10 +10,10 @@ WHERE users.email = $1;
 SELECT sqlc.embed(users) FROM users
 WHERE users.user_handle = $1;
 
--- name: GetUserBySessionToken :one
+-- name: GetUserBySessionTokenHash :one
 SELECT sqlc.embed(users), sqlc.embed(user_session) FROM users
 JOIN user_session ON users.id = user_session.user_id
-WHERE user_session.token = $1;
+WHERE user_session.token_hash = sqlc.arg(token_hash);
 
 -- name: CreateUser :one
 INSERT INTO users (id, given_name, family_name, email, email_verified, claimed, user_handle) 
@@ -35,14 +35,41 @@ UPDATE users SET
     email = sqlc.arg(email),
     email_verified = sqlc.arg(email_verified)::boolean,
     claimed = sqlc.arg(claimed),
-    user_handle = sqlc.arg(user_handle)
-WHERE id = sqlc.arg(id) RETURNING *;
+    user_handle = sqlc.arg(user_handle),
+    version = version + 1
+WHERE id = sqlc.arg(id) AND version = sqlc.arg(version) RETURNING *;
+
+-- name: SetUserRole :one
+UPDATE users SET user_role = sqlc.arg(user_role), version = version + 1 WHERE id = sqlc.arg(id) RETURNING *;
 
 -- name: SetAvatarImage :one
-UPDATE users SET avatar_id = sqlc.arg(image_id) WHERE id = sqlc.arg(user_id) RETURNING *;
+UPDATE users SET avatar_id = sqlc.arg(image_id), version = version + 1 WHERE id = sqlc.arg(user_id) RETURNING *;
 
 -- name: CreateUserSession :one
-INSERT INTO user_session (user_id, token, expires_at) VALUES (sqlc.arg(user_id), sqlc.arg(token), sqlc.arg(expires_at)) RETURNING *;
+INSERT INTO user_session (user_id, token_hash, expires_at) VALUES (sqlc.arg(user_id), sqlc.arg(token_hash), sqlc.arg(expires_at)) RETURNING *;
+
+-- name: CreateImpersonationSession :one
+INSERT INTO user_session (user_id, impersonator_id, token_hash, expires_at)
+VALUES (sqlc.arg(user_id), sqlc.arg(impersonator_id), sqlc.arg(token_hash), sqlc.arg(expires_at)) RETURNING *;
+
+-- name: CreateImpersonationAudit :exec
+INSERT INTO impersonation_audit (impersonator_id, user_id, session_id, request_method, request_path)
+VALUES (sqlc.arg(impersonator_id), sqlc.arg(user_id), sqlc.arg(session_id), sqlc.arg(request_method), sqlc.arg(request_path));
+
+-- name: ExpireUserSession :execrows
+UPDATE user_session SET user_expired = TRUE
+WHERE user_session.id = sqlc.arg(id) AND user_session.user_id = sqlc.arg(user_id) AND user_session.user_expired = FALSE;
+
+-- name: ExpireAllUserSessions :exec
+UPDATE user_session SET user_expired = TRUE
+WHERE user_session.user_id = sqlc.arg(user_id) AND user_session.user_expired = FALSE;
+
+-- name: GetActiveUserSessions :many
+SELECT sqlc.embed(user_session) FROM user_session
+WHERE user_session.user_id = sqlc.arg(user_id) AND user_session.user_expired = FALSE AND user_session.expires_at > now()
+ORDER BY user_session.last_seen_at DESC;
 
--- name: ExpireUserSession :exec
-UPDATE user_session SET user_expired = TRUE WHERE user_session.id = $1;
+-- name: TouchUserSession :exec
+UPDATE user_session
+SET last_seen_at = now(), ip_address = sqlc.narg(ip_address), expires_at = GREATEST(expires_at, sqlc.arg(expires_at))
+WHERE user_session.id = sqlc.arg(id) AND user_session.user_expired = FALSE;